@@ -1,5 +1,7 @@
 package gophercloud
 
+import "context"
+
 /*
 AuthOptions stores information needed to authenticate to an OpenStack Cloud.
 You can populate one manually, or use a provider's AuthOptionsFromEnv() function
@@ -48,6 +50,23 @@ type AuthOptions struct {
 	// Passcode is used in TOTP authentication method
 	Passcode string `json:"passcode,omitempty"`
 
+	// PasscodeFunc, if set, is called to obtain a TOTP passcode when the
+	// identity service answers with an auth receipt requiring the totp
+	// method. It lets interactive tools prompt the user for the passcode
+	// only when a multi-factor authentication rule asks for it.
+	//
+	// When AllowReauth is set, PasscodeFunc is also called each time the
+	// provider client reauthenticates, from the goroutine of the request
+	// that found the token expired, and that request waits for it to
+	// return. It must honour the cancellation of ctx.
+	PasscodeFunc func(ctx context.Context) (string, error) `json:"-"`
+
+	// Receipt is an auth receipt returned by the identity service when only
+	// part of the methods of a multi-factor authentication rule were
+	// satisfied. Sending it back with the missing methods completes
+	// authentication.
+	Receipt string `json:"-"`
+
 	// At most one of DomainID and DomainName must be provided if using Username
 	// with Identity V3. Otherwise, either are optional.
 	DomainID   string `json:"-"`
@@ -93,6 +112,19 @@ type AuthOptions struct {
 	ApplicationCredentialID     string `json:"-"`
 	ApplicationCredentialName   string `json:"-"`
 	ApplicationCredentialSecret string `json:"-"`
+
+	// FederatedAuth, if set, makes openstack.Authenticate obtain the token
+	// through identity federation, on the Identity v3 API, instead of with
+	// the credentials above. It is a *federation.OIDCAuthOptions or a
+	// *federation.SAML2ECPAuthOptions, whose Scope and AllowReauth apply.
+	FederatedAuth FederatedAuthOptions `json:"-"`
+}
+
+// FederatedAuthOptions is implemented by the options of the federated
+// authentication flows of the openstack/identity/v3/federation package.
+type FederatedAuthOptions interface {
+	ToTokenV3ScopeMap() (map[string]any, error)
+	CanReauth() bool
 }
 
 // AuthScope allows a created token to be limited to a specific domain or project.
@@ -193,7 +225,27 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]an
 	// if insufficient or incompatible information is present.
 	var req request
 
-	if opts.Password == "" && opts.Passcode == "" {
+	// totpUser builds the user of the totp method, when it is combined with
+	// application credential authentication.
+	totpUser := func() (*userReq, error) {
+		if opts.UserID != "" {
+			return &userReq{ID: &opts.UserID, Passcode: &opts.Passcode}, nil
+		}
+		if opts.Username == "" {
+			return nil, ErrUsernameOrUserID{}
+		}
+		if opts.DomainID != "" {
+			return &userReq{Name: &opts.Username, Passcode: &opts.Passcode, Domain: &domainReq{ID: &opts.DomainID}}, nil
+		}
+		if opts.DomainName != "" {
+			return &userReq{Name: &opts.Username, Passcode: &opts.Passcode, Domain: &domainReq{Name: &opts.DomainName}}, nil
+		}
+		return nil, ErrDomainIDOrDomainName{}
+	}
+
+	withApplicationCredential := opts.TokenID == "" && (opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "")
+
+	if opts.Password == "" && (opts.Passcode == "" || withApplicationCredential) {
 		if opts.TokenID != "" {
 			// Because we aren't using password authentication, it's an error to also provide any of the user-based authentication
 			// parameters.
@@ -279,6 +331,16 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]an
 			// If no password or token ID or ApplicationCredential are available, authentication can't continue.
 			return nil, ErrMissingPassword{}
 		}
+
+		// TOTP authentication combined with ApplicationCredential authentication.
+		if withApplicationCredential && opts.Passcode != "" {
+			user, err := totpUser()
+			if err != nil {
+				return nil, err
+			}
+			req.Auth.Identity.Methods = append(req.Auth.Identity.Methods, "totp")
+			req.Auth.Identity.TOTP = &totpReq{User: user}
+		}
 	} else {
 		// Password authentication.
 		if opts.Password != "" {
@@ -511,7 +573,11 @@ func (opts AuthOptions) CanReauth() bool {
 }
 
 // ToTokenV3HeadersMap allows AuthOptions to satisfy the AuthOptionsBuilder
-// interface in the v3 tokens package.
+// interface in the v3 tokens package. It sets the auth receipt header when
+// Receipt is set.
 func (opts *AuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
-	return nil, nil
+	if opts.Receipt == "" {
+		return nil, nil
+	}
+	return map[string]string{"Openstack-Auth-Receipt": opts.Receipt}, nil
 }
@@ -1,5 +1,7 @@
 package gophercloud
 
+import "strings"
+
 // Availability indicates to whom a specific service endpoint is accessible:
 // the internet at large, internal networks only, or only to administrators.
 // Different identity services use different terminology for these. Identity v2
@@ -51,6 +53,83 @@ type EndpointOpts struct {
 	// Availability is not required, and defaults to AvailabilityPublic. Not all
 	// providers or services offer all Availability options.
 	Availability Availability
+
+	// Overrides [optional] holds per-service settings, keyed by service type
+	// (e.g., "compute", "block-storage"), that take precedence over the
+	// values above and over the service catalog for the matching service.
+	// They are usually populated from the `<service>_endpoint_override`,
+	// `<service>_service_type`, `<service>_service_name`,
+	// `<service>_interface` and `<service>_api_version` keys of a
+	// clouds.yaml file.
+	Overrides map[string]ServiceOverride
+}
+
+// ServiceOverride holds the settings of a single service that override the
+// defaults used by the service client factory functions.
+type ServiceOverride struct {
+	// Endpoint, if set, is used as the service endpoint instead of the one
+	// found in the service catalog.
+	Endpoint string
+
+	// Type, if set, replaces the service type used to search the catalog.
+	Type string
+
+	// Name, if set, replaces the service name used to search the catalog.
+	Name string
+
+	// Availability, if set, replaces the endpoint interface used to search
+	// the catalog.
+	Availability Availability
+
+	// APIVersion is the API version requested for the service. When it
+	// carries a minor version (e.g., "2.79") and the service supports
+	// microversions, such as compute, block storage v3, shared file systems,
+	// bare metal and placement, it is used as the default microversion of
+	// the service client. It is ignored for the other services.
+	APIVersion string
+}
+
+// serviceTypeAliases maps historical and client-specific service types to the
+// official service type they refer to.
+var serviceTypeAliases = map[string]string{
+	"volume":      "block-storage",
+	"volumev2":    "block-storage",
+	"volumev3":    "block-storage",
+	"block-store": "block-storage",
+	"share":       "shared-file-system",
+	"sharev2":     "shared-file-system",
+	"workflowv2":  "workflow",
+	"container":   "application-container",
+}
+
+// CanonicalServiceType returns the official service type for the given
+// service type or alias. Underscores are treated as dashes, so that
+// "block_storage" and "volumev3" both resolve to "block-storage".
+func CanonicalServiceType(serviceType string) string {
+	t := strings.ReplaceAll(strings.ToLower(serviceType), "_", "-")
+	if alias, ok := serviceTypeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+// Override returns the ServiceOverride registered for the given service type,
+// if any. Service types are compared after resolving aliases with
+// CanonicalServiceType.
+func (eo EndpointOpts) Override(serviceType string) (ServiceOverride, bool) {
+	if len(eo.Overrides) == 0 {
+		return ServiceOverride{}, false
+	}
+	if o, ok := eo.Overrides[serviceType]; ok {
+		return o, true
+	}
+	want := CanonicalServiceType(serviceType)
+	for t, o := range eo.Overrides {
+		if CanonicalServiceType(t) == want {
+			return o, true
+		}
+	}
+	return ServiceOverride{}, false
 }
 
 /*
@@ -66,7 +145,20 @@ type EndpointLocator func(EndpointOpts) (string, error)
 //
 // It sets EndpointOpts fields if not already set, including a default type.
 // Currently, EndpointOpts.Availability defaults to the public endpoint.
+// Type, Name and Availability set in a ServiceOverride registered for the
+// default type take precedence.
 func (eo *EndpointOpts) ApplyDefaults(t string) {
+	if o, ok := eo.Override(t); ok {
+		if o.Type != "" {
+			eo.Type = o.Type
+		}
+		if o.Name != "" {
+			eo.Name = o.Name
+		}
+		if o.Availability != "" {
+			eo.Availability = o.Availability
+		}
+	}
 	if eo.Type == "" {
 		eo.Type = t
 	}
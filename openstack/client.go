@@ -2,6 +2,7 @@ package openstack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/vnpaycloud-console/gophercloud/v2"
 	tokens2 "github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v2/tokens"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/ec2tokens"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/federation"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/oauth1"
 	tokens3 "github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/tokens"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/utils"
@@ -86,12 +88,25 @@ func AuthenticatedClient(ctx context.Context, options gophercloud.AuthOptions) (
 
 // Authenticate authenticates or re-authenticates against the most
 // recent identity service supported at the provided endpoint.
+//
+// When options.FederatedAuth is set, the token is obtained through identity
+// federation, which requires the identity v3 service.
 func Authenticate(ctx context.Context, client *gophercloud.ProviderClient, options gophercloud.AuthOptions) error {
 	versions := []*utils.Version{
 		{ID: v2, Priority: 20, Suffix: "/v2.0/"},
 		{ID: v3, Priority: 30, Suffix: "/v3/"},
 	}
 
+	var federated federation.AuthOptionsBuilder
+	if options.FederatedAuth != nil {
+		var ok bool
+		federated, ok = options.FederatedAuth.(federation.AuthOptionsBuilder)
+		if !ok {
+			return fmt.Errorf("unsupported federated authentication options: %T", options.FederatedAuth)
+		}
+		versions = versions[1:]
+	}
+
 	chosen, endpoint, err := utils.ChooseVersion(ctx, client, versions)
 	if err != nil {
 		return err
@@ -101,6 +116,9 @@ func Authenticate(ctx context.Context, client *gophercloud.ProviderClient, optio
 	case v2:
 		return v2auth(ctx, client, endpoint, &options, gophercloud.EndpointOpts{})
 	case v3:
+		if federated != nil {
+			return v3auth(ctx, client, endpoint, federated, gophercloud.EndpointOpts{})
+		}
 		return v3auth(ctx, client, endpoint, &options, gophercloud.EndpointOpts{})
 	default:
 		// The switch statement must be out of date from the versions list.
@@ -221,13 +239,28 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 		}
 	} else {
 		var result tokens3.CreateResult
-		switch opts.(type) {
+		switch o := opts.(type) {
 		case *ec2tokens.AuthOptions:
 			result = ec2tokens.Create(ctx, v3Client, opts)
 		case *oauth1.AuthOptions:
 			result = oauth1.Create(ctx, v3Client, opts)
+		case federation.AuthOptionsBuilder:
+			result = federation.Create(ctx, v3Client, o)
 		default:
 			result = tokens3.Create(ctx, v3Client, opts)
+
+			// A multi-factor authentication rule may require a TOTP
+			// passcode on top of the supplied methods.
+			var receiptErr tokens3.ErrReceiptRequired
+			if errors.As(result.Err, &receiptErr) {
+				next, ok, err := tokens3.ContinueWithPasscode(ctx, opts, receiptErr)
+				if err != nil {
+					return err
+				}
+				if ok {
+					result = tokens3.Create(ctx, v3Client, next)
+				}
+			}
 		}
 
 		err = client.SetTokenAndAuthResult(result)
@@ -270,6 +303,14 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 			o := *ot
 			o.AllowReauth = false
 			tao = &o
+		case *federation.OIDCAuthOptions:
+			o := *ot
+			o.AllowReauth = false
+			tao = &o
+		case *federation.SAML2ECPAuthOptions:
+			o := *ot
+			o.AllowReauth = false
+			tao = &o
 		default:
 			tao = opts
 		}
@@ -296,8 +337,9 @@ func NewIdentityV2(client *gophercloud.ProviderClient, eo gophercloud.EndpointOp
 	clientType := "identity"
 	var err error
 	if !reflect.DeepEqual(eo, gophercloud.EndpointOpts{}) {
+		override, _ := eo.Override(clientType)
 		eo.ApplyDefaults(clientType)
-		endpoint, err = client.EndpointLocator(eo)
+		endpoint, err = locateEndpoint(client, eo, override)
 		if err != nil {
 			return nil, err
 		}
@@ -317,8 +359,9 @@ func NewIdentityV3(client *gophercloud.ProviderClient, eo gophercloud.EndpointOp
 	clientType := "identity"
 	var err error
 	if !reflect.DeepEqual(eo, gophercloud.EndpointOpts{}) {
+		override, _ := eo.Override(clientType)
 		eo.ApplyDefaults(clientType)
-		endpoint, err = client.EndpointLocator(eo)
+		endpoint, err = locateEndpoint(client, eo, override)
 		if err != nil {
 			return nil, err
 		}
@@ -346,17 +389,53 @@ func NewIdentityV3(client *gophercloud.ProviderClient, eo gophercloud.EndpointOp
 
 func initClientOpts(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts, clientType string) (*gophercloud.ServiceClient, error) {
 	sc := new(gophercloud.ServiceClient)
+	override, _ := eo.Override(clientType)
 	eo.ApplyDefaults(clientType)
-	url, err := client.EndpointLocator(eo)
+	url, err := locateEndpoint(client, eo, override)
 	if err != nil {
 		return sc, err
 	}
 	sc.ProviderClient = client
 	sc.Endpoint = url
 	sc.Type = clientType
+	sc.Microversion = overrideMicroversion(override, clientType)
 	return sc, nil
 }
 
+// locateEndpoint returns the endpoint override, if any, or searches the
+// service catalog of the provider client.
+func locateEndpoint(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts, override gophercloud.ServiceOverride) (string, error) {
+	if override.Endpoint != "" {
+		return gophercloud.NormalizeURL(override.Endpoint), nil
+	}
+	return client.EndpointLocator(eo)
+}
+
+// microversionedClientTypes are the client types of the services which
+// support microversions.
+var microversionedClientTypes = map[string]bool{
+	"compute":                 true,
+	"volumev3":                true,
+	"sharev2":                 true,
+	"baremetal":               true,
+	"baremetal-introspection": true,
+	"placement":               true,
+}
+
+// overrideMicroversion returns the microversion carried by the API version of
+// a service override, or an empty string if the API version only specifies a
+// major version or the service does not support microversions.
+func overrideMicroversion(override gophercloud.ServiceOverride, clientType string) string {
+	if !microversionedClientTypes[clientType] {
+		return ""
+	}
+	v := strings.TrimPrefix(override.APIVersion, "v")
+	if !strings.Contains(v, ".") {
+		return ""
+	}
+	return v
+}
+
 // NewBareMetalV1 creates a ServiceClient that may be used with the v1
 // bare metal package.
 func NewBareMetalV1(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error) {
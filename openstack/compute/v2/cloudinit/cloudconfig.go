@@ -0,0 +1,112 @@
+package cloudinit
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+// CloudConfigOpts is a cloud-config document. It covers the most common
+// modules; others can be set in Extra.
+type CloudConfigOpts struct {
+	// Hostname is the hostname of the server.
+	Hostname string `yaml:"hostname,omitempty"`
+
+	// Users are the users to create. Use DefaultUser to keep the default
+	// user of the image.
+	Users []User `yaml:"users,omitempty"`
+
+	// SSHAuthorizedKeys are added to the default user.
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+
+	// WriteFiles are the files to write.
+	WriteFiles []WriteFile `yaml:"write_files,omitempty"`
+
+	// PackageUpdate updates the package database on first boot.
+	PackageUpdate bool `yaml:"package_update,omitempty"`
+
+	// Packages are the packages to install.
+	Packages []string `yaml:"packages,omitempty"`
+
+	// RunCmd are the commands to run on first boot. Each command is either a
+	// string, run by the shell, or a []string, run without a shell.
+	RunCmd []any `yaml:"runcmd,omitempty"`
+
+	// Extra holds the other modules, merged into the document.
+	Extra map[string]any `yaml:",inline"`
+}
+
+// DefaultUser stands for the default user of the image in
+// CloudConfigOpts.Users.
+var DefaultUser = User{Name: "default"}
+
+// User is a user created by cloud-init.
+type User struct {
+	// Name is the name of the user.
+	Name string `yaml:"name"`
+
+	// Gecos is the full name of the user.
+	Gecos string `yaml:"gecos,omitempty"`
+
+	// Groups are the supplementary groups of the user.
+	Groups []string `yaml:"groups,omitempty"`
+
+	// Shell is the login shell of the user.
+	Shell string `yaml:"shell,omitempty"`
+
+	// Sudo is the sudoers rule of the user, for example
+	// "ALL=(ALL) NOPASSWD:ALL".
+	Sudo string `yaml:"sudo,omitempty"`
+
+	// LockPasswd locks the password of the user.
+	LockPasswd *bool `yaml:"lock_passwd,omitempty"`
+
+	// SSHAuthorizedKeys are the SSH public keys of the user.
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+}
+
+// MarshalYAML writes DefaultUser as the "default" keyword.
+func (u User) MarshalYAML() (any, error) {
+	if u.isDefault() {
+		return "default", nil
+	}
+	type user User
+	return user(u), nil
+}
+
+func (u User) isDefault() bool {
+	return u.Name == DefaultUser.Name && u.Gecos == "" && len(u.Groups) == 0 && u.Shell == "" &&
+		u.Sudo == "" && u.LockPasswd == nil && len(u.SSHAuthorizedKeys) == 0
+}
+
+// WriteFile is a file written by cloud-init.
+type WriteFile struct {
+	// Path is the path of the file.
+	Path string `yaml:"path"`
+
+	// Content is the content of the file, encoded as Encoding says.
+	Content string `yaml:"content,omitempty"`
+
+	// Encoding is the encoding of Content: empty for plain text, "b64" or
+	// "gzip+b64".
+	Encoding string `yaml:"encoding,omitempty"`
+
+	// Owner is the owner of the file, as "user:group".
+	Owner string `yaml:"owner,omitempty"`
+
+	// Permissions are the octal permissions of the file, for example "0644".
+	Permissions string `yaml:"permissions,omitempty"`
+
+	// Append appends Content to the file instead of replacing it.
+	Append bool `yaml:"append,omitempty"`
+
+	// Defer writes the file after users and packages are set up.
+	Defer bool `yaml:"defer,omitempty"`
+}
+
+// ToCloudConfig returns the cloud-config document, with its header.
+func (opts CloudConfigOpts) ToCloudConfig() ([]byte, error) {
+	b, err := yaml.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("#cloud-config\n"), b...), nil
+}
@@ -0,0 +1,48 @@
+/*
+Package cloudinit composes the user data of servers for cloud-init: a
+cloud-config document, shell scripts, boothooks and included URLs, combined
+in a multipart MIME message and optionally compressed.
+
+The result is meant for servers.CreateOpts.UserData and
+servers.RebuildOpts.UserData. It is checked against the size limit of the
+Compute service before any request is sent.
+
+Example to Compose User Data
+
+	config, err := cloudinit.CloudConfigPart(cloudinit.CloudConfigOpts{
+		Users: []cloudinit.User{
+			cloudinit.DefaultUser,
+			{
+				Name:              "deploy",
+				Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+				SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA... deploy@example.com"},
+			},
+		},
+		WriteFiles: []cloudinit.WriteFile{
+			{Path: "/etc/motd", Content: "Welcome\n", Permissions: "0644"},
+		},
+		RunCmd: []any{"systemctl restart sshd"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	userData, err := cloudinit.UserDataOpts{
+		Parts: []cloudinit.Part{
+			config,
+			cloudinit.ShellScriptPart("setup.sh", "apt-get install -y nginx\n"),
+		},
+		Gzip: true,
+	}.ToUserData()
+	if err != nil {
+		panic(err)
+	}
+
+	createOpts := servers.CreateOpts{
+		Name:      "web-1",
+		ImageRef:  "image-uuid",
+		FlavorRef: "flavor-uuid",
+		UserData:  userData,
+	}
+*/
+package cloudinit
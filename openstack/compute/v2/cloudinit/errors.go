@@ -0,0 +1,20 @@
+package cloudinit
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrUserDataTooLarge is returned when the user data exceeds MaxUserDataSize
+// once base64-encoded.
+type ErrUserDataTooLarge struct {
+	gophercloud.BaseError
+
+	// Size is the size of the base64-encoded user data.
+	Size int
+}
+
+func (e ErrUserDataTooLarge) Error() string {
+	return fmt.Sprintf("user data is %d bytes once base64-encoded, more than the %d bytes accepted by the Compute service", e.Size, MaxUserDataSize)
+}
@@ -0,0 +1,228 @@
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// MaxUserDataSize is the maximum size, once base64-encoded, of the user data
+// accepted by the Compute service.
+const MaxUserDataSize = 65535
+
+// ContentType is the MIME type of a part of the user data. It tells
+// cloud-init how to handle the part.
+type ContentType string
+
+const (
+	// CloudConfig parts are cloud-config YAML documents.
+	CloudConfig ContentType = "text/cloud-config"
+
+	// ShellScript parts are run once, late in the boot.
+	ShellScript ContentType = "text/x-shellscript"
+
+	// Boothook parts are run early, on every boot.
+	Boothook ContentType = "text/cloud-boothook"
+
+	// IncludeURL parts list URLs, one per line, whose content is fetched and
+	// processed as user data.
+	IncludeURL ContentType = "text/x-include-url"
+)
+
+// startMarkers are the prefixes of the first line by which cloud-init
+// recognizes the type of user data which is not a MIME message, and the
+// first line added to the content without it.
+var startMarkers = map[ContentType]struct{ prefix, line string }{
+	CloudConfig: {"#cloud-config", "#cloud-config"},
+	ShellScript: {"#!", "#!/bin/sh"},
+	Boothook:    {"#cloud-boothook", "#cloud-boothook"},
+	IncludeURL:  {"#include", "#include"},
+}
+
+// Part is a part of the user data.
+type Part struct {
+	// ContentType is the type of the part.
+	ContentType ContentType
+
+	// Filename is the file name of the part. Defaults to part-NNN.
+	Filename string
+
+	// Content is the content of the part.
+	Content []byte
+}
+
+// CloudConfigPart returns a part holding the given cloud-config.
+func CloudConfigPart(config CloudConfigOpts) (Part, error) {
+	content, err := config.ToCloudConfig()
+	if err != nil {
+		return Part{}, err
+	}
+	return Part{ContentType: CloudConfig, Filename: "cloud-config.yaml", Content: content}, nil
+}
+
+// ShellScriptPart returns a part holding the given shell script. A shebang is
+// added if the script has none.
+func ShellScriptPart(filename, script string) Part {
+	if !strings.HasPrefix(script, "#!") {
+		script = "#!/bin/sh\n" + script
+	}
+	return Part{ContentType: ShellScript, Filename: filename, Content: []byte(script)}
+}
+
+// BoothookPart returns a part holding the given boothook script.
+func BoothookPart(filename, script string) Part {
+	return Part{ContentType: Boothook, Filename: filename, Content: []byte(script)}
+}
+
+// IncludeURLPart returns a part including the content of the given URLs.
+func IncludeURLPart(urls ...string) Part {
+	return Part{ContentType: IncludeURL, Content: []byte(strings.Join(urls, "\n") + "\n")}
+}
+
+// UserDataOptsBuilder allows extensions to add additional parts to the user
+// data.
+type UserDataOptsBuilder interface {
+	ToUserData() ([]byte, error)
+}
+
+// UserDataOpts represents the user data of a server, made of one or more
+// parts.
+type UserDataOpts struct {
+	// Parts are the parts of the user data, processed by cloud-init in order.
+	Parts []Part
+
+	// Gzip compresses the user data. cloud-init decompresses it.
+	Gzip bool
+
+	// Boundary is the MIME boundary between the parts. If empty, a random
+	// boundary is used.
+	Boundary string
+}
+
+// ToUserData builds the user data, to be set as servers.CreateOpts.UserData
+// or servers.RebuildOpts.UserData.
+//
+// A single part of a type cloud-init recognizes by its first line is sent
+// as is, with this line added if its content doesn't start with it. Other
+// single parts, and several parts, are composed as a multipart/mixed MIME
+// message, whose Content-Type headers tell cloud-init the type of the parts.
+// An ErrUserDataTooLarge is returned if the result exceeds MaxUserDataSize
+// once base64-encoded.
+func (opts UserDataOpts) ToUserData() ([]byte, error) {
+	if len(opts.Parts) == 0 {
+		return nil, gophercloud.ErrMissingInput{Argument: "Parts"}
+	}
+	for i, part := range opts.Parts {
+		if part.ContentType == "" {
+			return nil, gophercloud.ErrMissingInput{Argument: fmt.Sprintf("Parts[%d].ContentType", i)}
+		}
+	}
+
+	var userData []byte
+	if marker, ok := startMarkers[opts.Parts[0].ContentType]; ok && len(opts.Parts) == 1 {
+		userData = opts.Parts[0].Content
+		if !bytes.HasPrefix(userData, []byte(marker.prefix)) {
+			userData = append([]byte(marker.line+"\n"), userData...)
+		}
+	} else {
+		var err error
+		userData, err = opts.multipart()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Gzip {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(userData); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		userData = buf.Bytes()
+	}
+
+	if size := base64.StdEncoding.EncodedLen(len(userData)); size > MaxUserDataSize {
+		return nil, ErrUserDataTooLarge{Size: size}
+	}
+
+	return userData, nil
+}
+
+// multipart composes the parts as a multipart/mixed MIME message.
+func (opts UserDataOpts) multipart() ([]byte, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if opts.Boundary != "" {
+		if err := w.SetBoundary(opts.Boundary); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, part := range opts.Parts {
+		filename := part.Filename
+		if filename == "" {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", mime.FormatMediaType(string(part.ContentType), map[string]string{"charset": "utf-8"}))
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+		content := part.Content
+		if isASCII(content) {
+			header.Set("Content-Transfer-Encoding", "7bit")
+		} else {
+			header.Set("Content-Transfer-Encoding", "base64")
+			content = wrapBase64(content)
+		}
+
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := pw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("Content-Type: " + mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": w.Boundary()}) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapBase64 base64-encodes b in lines of 76 characters.
+func wrapBase64(b []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(b)
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	return buf.Bytes()
+}
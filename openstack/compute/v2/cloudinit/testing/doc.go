@@ -0,0 +1,2 @@
+// cloudinit unit tests
+package testing
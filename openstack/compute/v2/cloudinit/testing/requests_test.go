@@ -0,0 +1,228 @@
+package testing
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/cloudinit"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const ExpectedCloudConfig = `#cloud-config
+hostname: web-1
+users:
+- default
+- name: deploy
+  groups:
+  - sudo
+  shell: /bin/bash
+  sudo: ALL=(ALL) NOPASSWD:ALL
+  ssh_authorized_keys:
+  - ssh-ed25519 AAAA deploy@example.com
+write_files:
+- path: /etc/motd
+  content: |
+    Managed by VNPay Cloud
+  permissions: "0644"
+runcmd:
+- systemctl restart sshd
+- - touch
+  - /var/lib/ready
+timezone: Asia/Ho_Chi_Minh
+`
+
+var cloudConfig = cloudinit.CloudConfigOpts{
+	Hostname: "web-1",
+	Users: []cloudinit.User{
+		cloudinit.DefaultUser,
+		{
+			Name:              "deploy",
+			Groups:            []string{"sudo"},
+			Shell:             "/bin/bash",
+			Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+			SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA deploy@example.com"},
+		},
+	},
+	WriteFiles: []cloudinit.WriteFile{
+		{
+			Path:        "/etc/motd",
+			Content:     "Managed by VNPay Cloud\n",
+			Permissions: "0644",
+		},
+	},
+	RunCmd: []any{
+		"systemctl restart sshd",
+		[]string{"touch", "/var/lib/ready"},
+	},
+	Extra: map[string]any{
+		"timezone": "Asia/Ho_Chi_Minh",
+	},
+}
+
+func TestCloudConfig(t *testing.T) {
+	actual, err := cloudConfig.ToCloudConfig()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ExpectedCloudConfig, string(actual))
+}
+
+func TestSinglePart(t *testing.T) {
+	part, err := cloudinit.CloudConfigPart(cloudConfig)
+	th.AssertNoErr(t, err)
+
+	userData, err := cloudinit.UserDataOpts{Parts: []cloudinit.Part{part}}.ToUserData()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ExpectedCloudConfig, string(userData))
+}
+
+func TestSinglePartStartMarker(t *testing.T) {
+	for _, tc := range []struct {
+		part     cloudinit.Part
+		expected string
+	}{
+		{
+			cloudinit.IncludeURLPart("https://example.com/a.yaml", "https://example.com/b.sh"),
+			"#include\nhttps://example.com/a.yaml\nhttps://example.com/b.sh\n",
+		},
+		{
+			cloudinit.BoothookPart("boothook.sh", "#!/bin/sh\necho boot\n"),
+			"#cloud-boothook\n#!/bin/sh\necho boot\n",
+		},
+		{
+			cloudinit.BoothookPart("boothook.sh", "#cloud-boothook\necho boot\n"),
+			"#cloud-boothook\necho boot\n",
+		},
+		{
+			cloudinit.Part{ContentType: cloudinit.ShellScript, Content: []byte("echo hello\n")},
+			"#!/bin/sh\necho hello\n",
+		},
+		{
+			cloudinit.Part{ContentType: cloudinit.CloudConfig, Content: []byte("hostname: web-1\n")},
+			"#cloud-config\nhostname: web-1\n",
+		},
+	} {
+		userData, err := cloudinit.UserDataOpts{Parts: []cloudinit.Part{tc.part}}.ToUserData()
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, tc.expected, string(userData))
+	}
+}
+
+func TestSinglePartOtherType(t *testing.T) {
+	part := cloudinit.Part{ContentType: "text/jinja2", Filename: "config.j2", Content: []byte("## template: jinja\n#cloud-config\n")}
+
+	userData, err := cloudinit.UserDataOpts{Parts: []cloudinit.Part{part}, Boundary: "vnpaycloud-boundary"}.ToUserData()
+	th.AssertNoErr(t, err)
+
+	// The type of the part is only known from its MIME header.
+	msg, err := mail.ReadMessage(bytes.NewReader(userData))
+	th.AssertNoErr(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "multipart/mixed", mediaType)
+
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	p, err := r.NextPart()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "text/jinja2; charset=utf-8", p.Header.Get("Content-Type"))
+	content, err := io.ReadAll(p)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "## template: jinja\n#cloud-config\n", string(content))
+	_, err = r.NextPart()
+	th.CheckEquals(t, io.EOF, err)
+}
+
+func TestMultipart(t *testing.T) {
+	configPart, err := cloudinit.CloudConfigPart(cloudConfig)
+	th.AssertNoErr(t, err)
+
+	opts := cloudinit.UserDataOpts{
+		Parts: []cloudinit.Part{
+			configPart,
+			cloudinit.ShellScriptPart("setup.sh", "echo xin chào > /tmp/hello\n"),
+			cloudinit.BoothookPart("boothook.sh", "#cloud-boothook\n#!/bin/sh\necho boot\n"),
+			cloudinit.IncludeURLPart("https://example.com/a.yaml", "https://example.com/b.sh"),
+		},
+		Gzip:     true,
+		Boundary: "vnpaycloud-boundary",
+	}
+	compressed, err := opts.ToUserData()
+	th.AssertNoErr(t, err)
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	th.AssertNoErr(t, err)
+	userData, err := io.ReadAll(r)
+	th.AssertNoErr(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(userData))
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "1.0", msg.Header.Get("MIME-Version"))
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "multipart/mixed", mediaType)
+	th.CheckEquals(t, "vnpaycloud-boundary", params["boundary"])
+
+	type part struct {
+		contentType, filename, encoding, content string
+	}
+	var parts []part
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		th.AssertNoErr(t, err)
+		contentType, _, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		th.AssertNoErr(t, err)
+		content, err := io.ReadAll(p)
+		th.AssertNoErr(t, err)
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			content, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(string(content), "\r\n", ""))
+			th.AssertNoErr(t, err)
+		}
+		parts = append(parts, part{contentType, p.FileName(), p.Header.Get("Content-Transfer-Encoding"), string(content)})
+	}
+
+	th.CheckDeepEquals(t, []part{
+		{"text/cloud-config", "cloud-config.yaml", "7bit", ExpectedCloudConfig},
+		{"text/x-shellscript", "setup.sh", "base64", "#!/bin/sh\necho xin chào > /tmp/hello\n"},
+		{"text/cloud-boothook", "boothook.sh", "7bit", "#cloud-boothook\n#!/bin/sh\necho boot\n"},
+		{"text/x-include-url", "part-004", "7bit", "https://example.com/a.yaml\nhttps://example.com/b.sh\n"},
+	}, parts)
+}
+
+func TestUserDataTooLarge(t *testing.T) {
+	opts := cloudinit.UserDataOpts{
+		Parts: []cloudinit.Part{
+			cloudinit.ShellScriptPart("big.sh", strings.Repeat("echo padding\n", 4000)),
+		},
+	}
+	_, err := opts.ToUserData()
+	var tooLarge cloudinit.ErrUserDataTooLarge
+	th.AssertEquals(t, true, errors.As(err, &tooLarge))
+
+	// The same script fits once compressed.
+	opts.Gzip = true
+	_, err = opts.ToUserData()
+	th.AssertNoErr(t, err)
+}
+
+func TestRebuildUserData(t *testing.T) {
+	userData, err := cloudinit.UserDataOpts{
+		Parts: []cloudinit.Part{cloudinit.ShellScriptPart("", "reboot\n")},
+	}.ToUserData()
+	th.AssertNoErr(t, err)
+
+	b, err := servers.RebuildOpts{ImageRef: "image-uuid", UserData: userData}.ToServerRebuildMap()
+	th.AssertNoErr(t, err)
+	rebuild := b["rebuild"].(map[string]any)
+	th.CheckEquals(t, base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\nreboot\n")), *rebuild["user_data"].(*string))
+}
@@ -0,0 +1,47 @@
+/*
+Package drain moves all the servers out of a compute host, before a
+maintenance or a decommissioning.
+
+Drain disables the compute service of the host, so that no new server is
+scheduled there, then live migrates the servers with bounded concurrency.
+Failed live migrations are retried and, optionally, replaced by a cold
+migration. Each server is followed until it lands on another host, and the
+reason of failed migrations is taken from the instance actions of the server.
+
+The Report tells what happened to each server. It can be persisted, and
+passed back to Drain to resume an interrupted drain: migrations still in
+progress are waited for instead of being requested again.
+
+Example to Drain a Host
+
+	report, err := drain.Drain(context.TODO(), computeClient, drain.DrainOpts{
+		Host:                "compute-1",
+		Concurrency:         4,
+		ColdMigrateFallback: true,
+		DisabledReason:      "hardware maintenance",
+		OnUpdate: func(i drain.InstanceReport) {
+			fmt.Printf("%s: %s\n", i.ID, i.Status)
+		},
+	})
+	if err != nil {
+		// Store the report to resume the drain with DrainOpts.Previous.
+		b, _ := json.Marshal(report)
+		os.WriteFile("drain-compute-1.json", b, 0600)
+		panic(err)
+	}
+
+Example to Plan a Drain
+
+	report, err := drain.Drain(context.TODO(), computeClient, drain.DrainOpts{
+		Host:   "compute-1",
+		DryRun: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, i := range report.Instances {
+		fmt.Printf("%s: %s %s\n", i.ID, i.Status, i.Method)
+	}
+*/
+package drain
@@ -0,0 +1,49 @@
+package drain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrDrainIncomplete is returned by Drain when some servers could not be
+// migrated. The report tells which ones, and why.
+type ErrDrainIncomplete struct {
+	gophercloud.BaseError
+
+	Host string
+
+	// Failed are the IDs of the servers left on the host.
+	Failed []string
+}
+
+func (e ErrDrainIncomplete) Error() string {
+	return fmt.Sprintf("%d servers could not be migrated out of host %s: %s", len(e.Failed), e.Host, strings.Join(e.Failed, ", "))
+}
+
+// ErrMigrationFailed is the reason a migration failed, as recorded in the
+// instance actions of the server.
+type ErrMigrationFailed struct {
+	gophercloud.BaseError
+
+	ServerID string
+	Method   Method
+
+	// Message is the message of the instance action.
+	Message string
+
+	// Events are the names of the events of the action which failed.
+	Events []string
+}
+
+func (e ErrMigrationFailed) Error() string {
+	msg := fmt.Sprintf("%s of server %s failed", e.Method, e.ServerID)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if len(e.Events) > 0 {
+		msg += " (" + strings.Join(e.Events, ", ") + ")"
+	}
+	return msg
+}
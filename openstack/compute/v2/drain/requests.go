@@ -0,0 +1,471 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/instanceactions"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/services"
+)
+
+const (
+	// DefaultMaxAttempts is the number of live migrations attempted for a
+	// server when DrainOpts.MaxAttempts is not set.
+	DefaultMaxAttempts = 2
+
+	// DefaultServerTimeout is the time given to each migration when
+	// DrainOpts.ServerTimeout is not set.
+	DefaultServerTimeout = time.Hour
+
+	// DefaultPollInterval is the time between two polls of a server being
+	// migrated when DrainOpts.PollInterval is not set.
+	DefaultPollInterval = 5 * time.Second
+)
+
+// DrainOpts configures Drain.
+type DrainOpts struct {
+	// Host is the compute host to drain. Required.
+	Host string
+
+	// Concurrency is the number of servers migrated at the same time.
+	// Defaults to 1.
+	Concurrency int
+
+	// MaxAttempts is the number of live migrations attempted for each server.
+	// Defaults to DefaultMaxAttempts.
+	MaxAttempts int
+
+	// ColdMigrateFallback cold migrates the servers which could not be live
+	// migrated. Cold migration reboots the server, and is confirmed as soon as
+	// the server lands on its new host.
+	ColdMigrateFallback bool
+
+	// BlockMigration is passed to servers.LiveMigrate.
+	BlockMigration *bool
+
+	// KeepServiceEnabled leaves the compute service of the host enabled.
+	// By default, it is disabled before the servers are migrated, so that no
+	// new server is scheduled there.
+	KeepServiceEnabled bool
+
+	// DisabledReason is the reason recorded on the disabled compute service.
+	DisabledReason string
+
+	// DryRun lists the servers and the way they would be migrated, without
+	// changing anything.
+	DryRun bool
+
+	// ServerTimeout bounds the time spent migrating each server. Defaults to
+	// DefaultServerTimeout.
+	ServerTimeout time.Duration
+
+	// PollInterval is the time between two polls of a server being migrated.
+	// Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// Previous is the report of an interrupted drain of the same host, to
+	// resume. The servers still being migrated are waited for instead of
+	// being migrated again.
+	Previous *Report
+
+	// OnUpdate, if set, is called every time the report of a server changes.
+	// Calls are serialized.
+	OnUpdate func(InstanceReport)
+}
+
+// Drain moves all the servers out of a compute host, with live migration
+// and, optionally, cold migration as a fallback.
+//
+// Drain returns the report along with any error, including when the context
+// expires, so that the drain can be resumed. If some servers could not be
+// migrated, it returns an ErrDrainIncomplete.
+//
+// The servers are listed with all_tenants and the migrations require
+// administrative privileges.
+func Drain(ctx context.Context, client *gophercloud.ServiceClient, opts DrainOpts) (*Report, error) {
+	if opts.Host == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "Host"}
+	}
+	if opts.Previous != nil && opts.Previous.Host != opts.Host {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "Previous"
+		err.Value = opts.Previous.Host
+		err.Info = "the previous report is about another host"
+		return nil, err
+	}
+
+	d := &drainer{client: client, opts: opts, report: &Report{Host: opts.Host, DryRun: opts.DryRun}}
+	if opts.Previous != nil {
+		d.report.ServiceID = opts.Previous.ServiceID
+		d.report.ServiceDisabled = opts.Previous.ServiceDisabled
+		d.report.Instances = append(d.report.Instances, opts.Previous.Instances...)
+	}
+
+	if !opts.DryRun && !opts.KeepServiceEnabled && !d.report.ServiceDisabled {
+		if err := d.disableService(ctx); err != nil {
+			return d.report, err
+		}
+	}
+
+	allPages, err := servers.List(client, servers.ListOpts{Host: opts.Host, AllTenants: true}).AllPages(ctx)
+	if err != nil {
+		return d.report, err
+	}
+	onHost, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return d.report, err
+	}
+
+	if err := d.reconcile(ctx, onHost); err != nil {
+		return d.report, err
+	}
+
+	concurrency := max(opts.Concurrency, 1)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, server := range onHost {
+		i := d.instance(server)
+		if opts.DryRun {
+			d.update(i, func(r *InstanceReport) {
+				r.Status, r.Method = StatusPlanned, planMethod(server.Status)
+				if r.Method == "" {
+					r.Status, r.Error = StatusFailed, fmt.Sprintf("servers in status %s can't be migrated", server.Status)
+				}
+			})
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(server servers.Server) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			d.migrate(ctx, i, server)
+		}(server)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return d.report, err
+	}
+	if failed := d.report.Failed(); len(failed) > 0 {
+		err := ErrDrainIncomplete{Host: opts.Host}
+		for _, f := range failed {
+			err.Failed = append(err.Failed, f.ID)
+		}
+		return d.report, err
+	}
+	return d.report, nil
+}
+
+// planMethod returns the way a server in the given status is migrated, or
+// an empty method if it can't be. Stopped servers can only be cold migrated.
+func planMethod(status string) Method {
+	switch status {
+	case "ACTIVE", "PAUSED", "MIGRATING":
+		return MethodLive
+	case "SHUTOFF", "RESIZE", "VERIFY_RESIZE":
+		return MethodCold
+	}
+	return ""
+}
+
+type drainer struct {
+	client *gophercloud.ServiceClient
+	opts   DrainOpts
+
+	mu     sync.Mutex
+	report *Report
+}
+
+// instance returns the index of the report of a server, adding it if needed.
+func (d *drainer) instance(server servers.Server) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, r := range d.report.Instances {
+		if r.ID == server.ID {
+			return i
+		}
+	}
+	d.report.Instances = append(d.report.Instances, InstanceReport{ID: server.ID, Name: server.Name, Status: StatusPending})
+	return len(d.report.Instances) - 1
+}
+
+// update changes the report of a server.
+func (d *drainer) update(i int, change func(*InstanceReport)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	change(&d.report.Instances[i])
+	if d.opts.OnUpdate != nil {
+		d.opts.OnUpdate(d.report.Instances[i])
+	}
+}
+
+// disableService disables the compute service of the host.
+func (d *drainer) disableService(ctx context.Context) error {
+	allPages, err := services.List(d.client, services.ListOpts{Binary: "nova-compute", Host: d.opts.Host}).AllPages(ctx)
+	if err != nil {
+		return err
+	}
+	found, err := services.ExtractServices(allPages)
+	if err != nil {
+		return err
+	}
+	if len(found) != 1 {
+		return fmt.Errorf("found %d nova-compute services on host %s", len(found), d.opts.Host)
+	}
+
+	reason := d.opts.DisabledReason
+	if reason == "" {
+		reason = "drained"
+	}
+	if _, err := services.Update(ctx, d.client, found[0].ID, services.UpdateOpts{
+		Status:         services.ServiceDisabled,
+		DisabledReason: reason,
+	}).Extract(); err != nil {
+		return err
+	}
+
+	d.report.ServiceID = found[0].ID
+	d.report.ServiceDisabled = true
+	return nil
+}
+
+// reconcile updates the servers of a previous report which are not on the
+// host anymore.
+func (d *drainer) reconcile(ctx context.Context, onHost []servers.Server) error {
+	listed := make(map[string]bool)
+	for _, server := range onHost {
+		listed[server.ID] = true
+	}
+
+	for i, r := range d.report.Instances {
+		if listed[r.ID] || r.Status == StatusMigrated || r.Status == StatusGone {
+			continue
+		}
+		server, err := servers.Get(ctx, d.client, r.ID).Extract()
+		switch {
+		case gophercloud.ResponseCodeIs(err, http.StatusNotFound):
+			d.update(i, func(r *InstanceReport) { r.Status = StatusGone })
+		case err != nil:
+			return err
+		default:
+			d.update(i, func(r *InstanceReport) {
+				r.Status, r.DestinationHost, r.Error = StatusMigrated, server.Host, ""
+				if r.FinishedAt.IsZero() {
+					r.FinishedAt = time.Now()
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// migrate moves a server out of the host, and records the outcome.
+func (d *drainer) migrate(ctx context.Context, i int, server servers.Server) {
+	d.update(i, func(r *InstanceReport) {
+		r.Status, r.Error, r.StartedAt, r.FinishedAt = StatusMigrating, "", time.Now(), time.Time{}
+	})
+
+	landed, err := d.move(ctx, i, server)
+	if ctx.Err() != nil {
+		// The server may still be moving: leave it to the resumed drain.
+		return
+	}
+	d.update(i, func(r *InstanceReport) {
+		r.FinishedAt = time.Now()
+		switch {
+		case gophercloud.ResponseCodeIs(err, http.StatusNotFound):
+			r.Status = StatusGone
+		case err != nil:
+			r.Status, r.Error = StatusFailed, err.Error()
+		default:
+			r.Status, r.DestinationHost = StatusMigrated, landed.Host
+		}
+	})
+}
+
+// move tries the migration methods in turn, and returns the server once on
+// another host.
+func (d *drainer) move(ctx context.Context, i int, server servers.Server) (*servers.Server, error) {
+	current := &server
+
+	// Migrations left in progress by an interrupted drain.
+	switch current.Status {
+	case "MIGRATING", "RESIZE", "VERIFY_RESIZE":
+		landed, err := d.finish(ctx, current.ID)
+		if err == nil || ctx.Err() != nil {
+			return landed, err
+		}
+		if landed == nil {
+			return nil, err
+		}
+		current = landed
+	}
+
+	method := planMethod(current.Status)
+	if method == "" {
+		return nil, fmt.Errorf("servers in status %s can't be migrated", current.Status)
+	}
+
+	var err error
+	if method == MethodLive {
+		attempts := d.opts.MaxAttempts
+		if attempts <= 0 {
+			attempts = DefaultMaxAttempts
+		}
+		for n := 0; n < attempts; n++ {
+			var landed *servers.Server
+			landed, err = d.attempt(ctx, i, current.ID, MethodLive)
+			if err == nil || ctx.Err() != nil {
+				return landed, err
+			}
+			if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return nil, err
+			}
+		}
+		if !d.opts.ColdMigrateFallback {
+			return nil, err
+		}
+	}
+
+	return d.attempt(ctx, i, current.ID, MethodCold)
+}
+
+// attempt requests a migration of a server, and waits for it to land.
+func (d *drainer) attempt(ctx context.Context, i int, id string, method Method) (*servers.Server, error) {
+	d.update(i, func(r *InstanceReport) {
+		r.Method = method
+		r.Attempts++
+	})
+
+	var err error
+	if method == MethodLive {
+		err = servers.LiveMigrate(ctx, d.client, id, servers.LiveMigrateOpts{BlockMigration: d.opts.BlockMigration}).ExtractErr()
+	} else {
+		err = servers.Migrate(ctx, d.client, id).ExtractErr()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	landed, err := d.finish(ctx, id)
+	if err != nil && landed != nil && ctx.Err() == nil {
+		// Prefer the reason recorded by Nova.
+		if actionErr := d.actionError(ctx, id, method); actionErr != nil {
+			err = actionErr
+		}
+	}
+	return landed, err
+}
+
+// finish waits for the migration of a server to end, confirms cold
+// migrations, and checks that the server left the host. On failure, it
+// returns the server as last seen along with the error.
+func (d *drainer) finish(ctx context.Context, id string) (*servers.Server, error) {
+	timeout := d.opts.ServerTimeout
+	if timeout <= 0 {
+		timeout = DefaultServerTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	server, err := d.settle(ctx, id)
+	if err != nil {
+		return server, err
+	}
+
+	if server.Status == "VERIFY_RESIZE" {
+		if err := servers.ConfirmResize(ctx, d.client, id).ExtractErr(); err != nil {
+			return server, err
+		}
+		if server, err = d.settle(ctx, id); err != nil {
+			return server, err
+		}
+	}
+
+	if server.Host == d.opts.Host {
+		return server, fmt.Errorf("server %s is still on host %s", id, d.opts.Host)
+	}
+	return server, nil
+}
+
+// settle polls a server until no task is running on it.
+func (d *drainer) settle(ctx context.Context, id string) (*servers.Server, error) {
+	interval := d.opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		server, err := servers.Get(ctx, d.client, id).Extract()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case server.Status == "ERROR":
+			return server, servers.ErrServerFailed{
+				ID:        server.ID,
+				Status:    server.Status,
+				TaskState: server.TaskState,
+				Fault:     server.Fault,
+			}
+		case server.TaskState == "" && server.Status != "MIGRATING" && server.Status != "RESIZE":
+			return server, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return server, ctx.Err()
+		}
+	}
+}
+
+// actionError returns the failure of the last migration of a server, as
+// recorded in its instance actions, or nil if it can't be found.
+func (d *drainer) actionError(ctx context.Context, id string, method Method) error {
+	allPages, err := instanceactions.List(d.client, id, nil).AllPages(ctx)
+	if err != nil {
+		return nil
+	}
+	actions, err := instanceactions.ExtractInstanceActions(allPages)
+	if err != nil {
+		return nil
+	}
+
+	// Actions are listed from the most recent one.
+	for _, action := range actions {
+		if action.Action != string(method) {
+			continue
+		}
+		migrationErr := ErrMigrationFailed{ServerID: id, Method: method, Message: action.Message}
+		detail, err := instanceactions.Get(ctx, d.client, id, action.RequestID).Extract()
+		if err == nil && detail.Events != nil {
+			for _, event := range *detail.Events {
+				if event.Result == "Error" {
+					migrationErr.Events = append(migrationErr.Events, event.Event)
+				}
+			}
+		}
+		if migrationErr.Message == "" && len(migrationErr.Events) == 0 {
+			return nil
+		}
+		return migrationErr
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package drain
+
+import (
+	"time"
+)
+
+// InstanceStatus is the state of a server in a drain.
+type InstanceStatus string
+
+const (
+	// StatusPending is a server which has not been migrated yet.
+	StatusPending InstanceStatus = "pending"
+
+	// StatusPlanned is a server which would be migrated, in a dry run.
+	StatusPlanned InstanceStatus = "planned"
+
+	// StatusMigrating is a server being migrated.
+	StatusMigrating InstanceStatus = "migrating"
+
+	// StatusMigrated is a server which landed on another host.
+	StatusMigrated InstanceStatus = "migrated"
+
+	// StatusFailed is a server which could not be migrated.
+	StatusFailed InstanceStatus = "failed"
+
+	// StatusGone is a server which was deleted during the drain.
+	StatusGone InstanceStatus = "gone"
+)
+
+// Method is the way a server is moved out of the host.
+type Method string
+
+const (
+	MethodLive Method = "live-migration"
+	MethodCold Method = "migrate"
+)
+
+// InstanceReport is the outcome of the drain for a server.
+type InstanceReport struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Status is the state of the server in the drain.
+	Status InstanceStatus `json:"status"`
+
+	// Method is the last migration method used, or planned in a dry run.
+	Method Method `json:"method,omitempty"`
+
+	// Attempts is the number of migrations requested.
+	Attempts int `json:"attempts"`
+
+	// DestinationHost is the host the server landed on.
+	DestinationHost string `json:"destination_host,omitempty"`
+
+	// Error is the reason of the last failure.
+	Error string `json:"error,omitempty"`
+
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Report is the outcome of a drain. It can be stored, for example as JSON,
+// and passed back in DrainOpts.Previous to resume an interrupted drain.
+type Report struct {
+	// Host is the drained host.
+	Host string `json:"host"`
+
+	// DryRun tells whether the servers were actually migrated.
+	DryRun bool `json:"dry_run"`
+
+	// ServiceID is the ID of the compute service of the host, and
+	// ServiceDisabled tells whether the drain disabled it.
+	ServiceID       string `json:"service_id,omitempty"`
+	ServiceDisabled bool   `json:"service_disabled"`
+
+	// Instances are the servers found on the host, in the order they were
+	// listed.
+	Instances []InstanceReport `json:"instances"`
+}
+
+// Done tells whether no server is left on the host.
+func (r Report) Done() bool {
+	for _, i := range r.Instances {
+		if i.Status != StatusMigrated && i.Status != StatusGone {
+			return false
+		}
+	}
+	return !r.DryRun
+}
+
+// Failed returns the servers which could not be migrated.
+func (r Report) Failed() []InstanceReport {
+	var failed []InstanceReport
+	for _, i := range r.Instances {
+		if i.Status == StatusFailed {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
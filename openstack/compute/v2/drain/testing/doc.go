@@ -0,0 +1,2 @@
+// drain unit tests
+package testing
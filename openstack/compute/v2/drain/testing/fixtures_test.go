@@ -0,0 +1,227 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+const ServiceListOutput = `
+{
+	"services": [
+		{
+			"id": "4c720fa0-02c3-4834-8279-9eecf9edb6cb",
+			"binary": "nova-compute",
+			"host": "compute-1",
+			"status": "enabled",
+			"state": "up",
+			"zone": "nova",
+			"updated_at": "2024-10-17T10:12:14.000000"
+		}
+	]
+}
+`
+
+const ServiceUpdateRequest = `
+{
+	"status": "disabled",
+	"disabled_reason": "hardware maintenance"
+}
+`
+
+const ServiceUpdateOutput = `
+{
+	"service": {
+		"id": "4c720fa0-02c3-4834-8279-9eecf9edb6cb",
+		"binary": "nova-compute",
+		"host": "compute-1",
+		"status": "disabled",
+		"disabled_reason": "hardware maintenance",
+		"state": "up",
+		"zone": "nova",
+		"updated_at": "2024-10-17T10:12:14.000000"
+	}
+}
+`
+
+// FakeServer is a server of the fake cloud.
+type FakeServer struct {
+	ID        string
+	Status    string
+	TaskState string
+	Host      string
+
+	// LiveMigrationFailures is the number of live migrations which fail,
+	// leaving the server on its host.
+	LiveMigrationFailures int
+
+	// pending is the action the next poll completes.
+	pending string
+
+	// resizedOf is the status of the server before a cold migration.
+	resizedOf string
+}
+
+// Cloud is a fake Compute service, migrating servers from compute-1 to
+// compute-2 in one poll.
+type Cloud struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	servers map[string]*FakeServer
+	order   []string
+
+	// Actions are the server actions received, as "<id> <action>".
+	Actions []string
+
+	// ServiceUpdates is the number of service updates received.
+	ServiceUpdates int
+}
+
+func (c *Cloud) render(s *FakeServer) map[string]any {
+	var taskState any
+	if s.TaskState != "" {
+		taskState = s.TaskState
+	}
+	return map[string]any{
+		"id":                    s.ID,
+		"name":                  "server-" + s.ID,
+		"status":                s.Status,
+		"OS-EXT-STS:task_state": taskState,
+		"OS-EXT-SRV-ATTR:host":  s.Host,
+	}
+}
+
+// poll completes the pending action of a server.
+func (c *Cloud) poll(s *FakeServer) {
+	switch s.pending {
+	case "live-migration":
+		s.Status, s.TaskState = "ACTIVE", ""
+		if s.LiveMigrationFailures > 0 {
+			s.LiveMigrationFailures--
+		} else {
+			s.Host = "compute-2"
+		}
+	case "migrate":
+		s.Status, s.TaskState, s.Host = "VERIFY_RESIZE", "", "compute-2"
+	}
+	s.pending = ""
+}
+
+// HandleCloud sets up the fake cloud with the given servers.
+func HandleCloud(t *testing.T, servers ...*FakeServer) *Cloud {
+	c := &Cloud{t: t, servers: make(map[string]*FakeServer)}
+	for _, s := range servers {
+		c.servers[s.ID] = s
+		c.order = append(c.order, s.ID)
+	}
+
+	th.Mux.HandleFunc("/os-services", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"binary": "nova-compute", "host": "compute-1"})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ServiceListOutput)
+	})
+
+	th.Mux.HandleFunc("/os-services/4c720fa0-02c3-4834-8279-9eecf9edb6cb", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, ServiceUpdateRequest)
+
+		c.mu.Lock()
+		c.ServiceUpdates++
+		c.mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ServiceUpdateOutput)
+	})
+
+	th.Mux.HandleFunc("/servers/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"host": "compute-1", "all_tenants": "true"})
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		list := []map[string]any{}
+		for _, id := range c.order {
+			if s := c.servers[id]; s.Host == "compute-1" {
+				list = append(list, c.render(s))
+			}
+		}
+		w.Header().Add("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"servers": list})
+	})
+
+	th.Mux.HandleFunc("/servers/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/servers/"), "/")
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		s, ok := c.servers[parts[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case len(parts) == 1:
+			th.TestMethod(t, r, "GET")
+			w.Header().Add("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"server": c.render(s)})
+			c.poll(s)
+
+		case parts[1] == "action":
+			th.TestMethod(t, r, "POST")
+			var action map[string]any
+			th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&action))
+			for name := range action {
+				c.Actions = append(c.Actions, s.ID+" "+name)
+				switch name {
+				case "os-migrateLive":
+					s.Status, s.TaskState, s.pending = "MIGRATING", "migrating", "live-migration"
+				case "migrate":
+					s.resizedOf = s.Status
+					s.Status, s.TaskState, s.pending = "RESIZE", "resize_prep", "migrate"
+				case "confirmResize":
+					s.Status, s.resizedOf = s.resizedOf, ""
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+
+		case parts[1] == "os-instance-actions" && len(parts) == 2:
+			th.TestMethod(t, r, "GET")
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"instanceActions": [
+				{"action": "live-migration", "instance_uuid": "%[1]s", "message": "Error", "request_id": "req-2", "start_time": "2024-10-17T10:12:14.000000"},
+				{"action": "live-migration", "instance_uuid": "%[1]s", "message": null, "request_id": "req-1", "start_time": "2024-10-17T10:10:14.000000"}
+			]}`, s.ID)
+
+		case parts[1] == "os-instance-actions":
+			th.TestMethod(t, r, "GET")
+			th.CheckEquals(t, "req-2", parts[2])
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"instanceAction": {
+				"action": "live-migration", "instance_uuid": "%s", "message": "Error", "request_id": "req-2",
+				"start_time": "2024-10-17T10:12:14.000000",
+				"events": [
+					{"event": "conductor_live_migrate_instance", "result": "Success", "start_time": "2024-10-17T10:12:14.000000", "finish_time": "2024-10-17T10:12:15.000000"},
+					{"event": "compute_check_can_live_migrate_destination", "result": "Error", "start_time": "2024-10-17T10:12:15.000000", "finish_time": "2024-10-17T10:12:16.000000"}
+				]
+			}}`, s.ID)
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	return c
+}
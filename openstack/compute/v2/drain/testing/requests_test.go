@@ -0,0 +1,151 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/drain"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+func TestDrain(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := HandleCloud(t,
+		&FakeServer{ID: "1", Status: "ACTIVE", Host: "compute-1"},
+		&FakeServer{ID: "2", Status: "ACTIVE", Host: "compute-1", LiveMigrationFailures: 2},
+		&FakeServer{ID: "3", Status: "SHUTOFF", Host: "compute-1"},
+		&FakeServer{ID: "4", Status: "ERROR", Host: "compute-1"},
+	)
+
+	var updates int
+	report, err := drain.Drain(context.TODO(), client.ServiceClient(), drain.DrainOpts{
+		Host:                "compute-1",
+		Concurrency:         2,
+		ColdMigrateFallback: true,
+		DisabledReason:      "hardware maintenance",
+		PollInterval:        time.Millisecond,
+		OnUpdate:            func(drain.InstanceReport) { updates++ },
+	})
+
+	var incomplete drain.ErrDrainIncomplete
+	th.AssertEquals(t, true, errors.As(err, &incomplete))
+	th.CheckDeepEquals(t, []string{"4"}, incomplete.Failed)
+
+	th.CheckEquals(t, 1, cloud.ServiceUpdates)
+	th.CheckEquals(t, "4c720fa0-02c3-4834-8279-9eecf9edb6cb", report.ServiceID)
+	th.CheckEquals(t, true, report.ServiceDisabled)
+	th.CheckEquals(t, false, report.Done())
+	th.AssertEquals(t, 4, len(report.Instances))
+
+	for i, expected := range []struct {
+		status   drain.InstanceStatus
+		method   drain.Method
+		attempts int
+	}{
+		{drain.StatusMigrated, drain.MethodLive, 1},
+		{drain.StatusMigrated, drain.MethodCold, 3},
+		{drain.StatusMigrated, drain.MethodCold, 1},
+		{drain.StatusFailed, "", 0},
+	} {
+		actual := report.Instances[i]
+		th.CheckEquals(t, expected.status, actual.Status)
+		th.CheckEquals(t, expected.method, actual.Method)
+		th.CheckEquals(t, expected.attempts, actual.Attempts)
+		if expected.status == drain.StatusMigrated {
+			th.CheckEquals(t, "compute-2", actual.DestinationHost)
+		}
+	}
+	th.CheckEquals(t, "servers in status ERROR can't be migrated", report.Instances[3].Error)
+	th.CheckEquals(t, "SHUTOFF", cloud.servers["3"].Status)
+	th.CheckEquals(t, "ACTIVE", cloud.servers["2"].Status)
+	th.CheckEquals(t, true, updates > 0)
+}
+
+func TestDrainLiveMigrationFailure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := HandleCloud(t,
+		&FakeServer{ID: "1", Status: "ACTIVE", Host: "compute-1", LiveMigrationFailures: 1},
+	)
+
+	report, err := drain.Drain(context.TODO(), client.ServiceClient(), drain.DrainOpts{
+		Host:               "compute-1",
+		MaxAttempts:        1,
+		KeepServiceEnabled: true,
+		PollInterval:       time.Millisecond,
+	})
+	th.AssertEquals(t, true, errors.As(err, new(drain.ErrDrainIncomplete)))
+	th.CheckEquals(t, 0, cloud.ServiceUpdates)
+	th.CheckDeepEquals(t, []string{"1 os-migrateLive"}, cloud.Actions)
+
+	failed := report.Failed()
+	th.AssertEquals(t, 1, len(failed))
+	th.CheckEquals(t, "live-migration of server 1 failed: Error (compute_check_can_live_migrate_destination)", failed[0].Error)
+}
+
+func TestDrainDryRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := HandleCloud(t,
+		&FakeServer{ID: "1", Status: "ACTIVE", Host: "compute-1"},
+		&FakeServer{ID: "2", Status: "SHUTOFF", Host: "compute-1"},
+	)
+
+	report, err := drain.Drain(context.TODO(), client.ServiceClient(), drain.DrainOpts{
+		Host:   "compute-1",
+		DryRun: true,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 0, cloud.ServiceUpdates)
+	th.CheckEquals(t, 0, len(cloud.Actions))
+	th.CheckDeepEquals(t, []drain.InstanceReport{
+		{ID: "1", Name: "server-1", Status: drain.StatusPlanned, Method: drain.MethodLive},
+		{ID: "2", Name: "server-2", Status: drain.StatusPlanned, Method: drain.MethodCold},
+	}, report.Instances)
+	th.CheckEquals(t, false, report.Done())
+}
+
+func TestDrainResume(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := HandleCloud(t,
+		&FakeServer{ID: "1", Status: "ACTIVE", Host: "compute-2"},
+		&FakeServer{ID: "2", Status: "ACTIVE", Host: "compute-1"},
+		&FakeServer{ID: "3", Status: "ACTIVE", Host: "compute-1"},
+	)
+	// The interrupted drain left server 2 migrating.
+	cloud.servers["2"].Status, cloud.servers["2"].TaskState = "MIGRATING", "migrating"
+	cloud.servers["2"].pending = "live-migration"
+
+	previous := &drain.Report{
+		Host:            "compute-1",
+		ServiceID:       "4c720fa0-02c3-4834-8279-9eecf9edb6cb",
+		ServiceDisabled: true,
+		Instances: []drain.InstanceReport{
+			{ID: "1", Name: "server-1", Status: drain.StatusMigrating, Method: drain.MethodLive, Attempts: 1},
+			{ID: "2", Name: "server-2", Status: drain.StatusMigrating, Method: drain.MethodLive, Attempts: 1},
+			{ID: "5", Name: "server-5", Status: drain.StatusPending},
+		},
+	}
+
+	report, err := drain.Drain(context.TODO(), client.ServiceClient(), drain.DrainOpts{
+		Host:         "compute-1",
+		PollInterval: time.Millisecond,
+		Previous:     previous,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, true, report.Done())
+	th.CheckEquals(t, 0, cloud.ServiceUpdates)
+	th.CheckDeepEquals(t, []string{"3 os-migrateLive"}, cloud.Actions)
+
+	th.AssertEquals(t, 4, len(report.Instances))
+	for i, status := range []drain.InstanceStatus{drain.StatusMigrated, drain.StatusMigrated, drain.StatusGone, drain.StatusMigrated} {
+		th.CheckEquals(t, status, report.Instances[i].Status)
+	}
+	th.CheckEquals(t, 1, report.Instances[1].Attempts)
+	th.CheckEquals(t, "3", report.Instances[3].ID)
+}
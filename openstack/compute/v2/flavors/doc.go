@@ -146,5 +146,32 @@ Example to Delete an Extra Spec for a Flavor
 	if err != nil {
 		panic(err)
 	}
+
+Example to Choose a Flavor
+
+	matchOpts := flavors.MatchOpts{
+		MinVCPUs:   4,
+		MinRAM:     8192,
+		MinDisk:    40,
+		CPUPolicy:  "dedicated",
+		PCIDevices: map[string]int{"a100": 1},
+		Prices: map[string]float64{
+			"g1.large":  2.5,
+			"g1.xlarge": 4,
+		},
+	}
+
+	result, err := flavors.Match(context.TODO(), computeClient, matchOpts)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, candidate := range result.Candidates {
+		fmt.Printf("%s: %.2f\n", candidate.Flavor.Name, candidate.Price)
+	}
+
+	for _, exclusion := range result.Excluded {
+		fmt.Printf("%s: %s\n", exclusion.Flavor.Name, strings.Join(exclusion.Reasons, ", "))
+	}
 */
 package flavors
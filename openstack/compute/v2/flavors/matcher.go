@@ -0,0 +1,295 @@
+package flavors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// Extra specs understood by Match.
+const (
+	// ExtraSpecCPUPolicy is the CPU pinning policy of a flavor: "shared",
+	// "dedicated" or "mixed". Flavors without it use shared CPUs.
+	ExtraSpecCPUPolicy = "hw:cpu_policy"
+
+	// ExtraSpecPCIAlias lists the PCI devices of a flavor, as comma separated
+	// "alias:count" pairs.
+	ExtraSpecPCIAlias = "pci_passthrough:alias"
+
+	// ExtraSpecVGPU is the number of virtual GPUs of a flavor.
+	ExtraSpecVGPU = "resources:VGPU"
+)
+
+// MatchOpts are the requirements a flavor must meet to be returned by Match.
+// Zero values are not checked.
+type MatchOpts struct {
+	// MinVCPUs is the minimum number of vCPUs.
+	MinVCPUs int
+
+	// MinRAM is the minimum amount of memory, in MB.
+	MinRAM int
+
+	// MinDisk is the minimum size of the root disk, in GB. Set BootFromVolume
+	// to accept flavors without a root disk.
+	MinDisk int
+
+	// BootFromVolume tells that the root disk is a volume, whose size does not
+	// depend on the flavor.
+	BootFromVolume bool
+
+	// MinEphemeral is the minimum size of the ephemeral disk, in GB.
+	MinEphemeral int
+
+	// VGPUs is the minimum number of virtual GPUs.
+	VGPUs int
+
+	// PCIDevices maps PCI aliases, such as GPUs passed through, to the
+	// minimum number of devices.
+	PCIDevices map[string]int
+
+	// CPUPolicy is the required value of hw:cpu_policy.
+	CPUPolicy string
+
+	// ExtraSpecs are other extra specs that flavors must have, with the same
+	// value.
+	ExtraSpecs map[string]string
+
+	// AccessType is passed to ListDetail. See AccessType for the flavors
+	// visible with each value.
+	AccessType AccessType
+
+	// ProjectID, if set, excludes the private flavors the project has no
+	// access to. This is useful to administrators, who see all flavors.
+	ProjectID string
+
+	// Prices maps flavor IDs or names to a price. Candidates are ranked by
+	// ascending price, the flavors without a price coming last.
+	Prices map[string]float64
+
+	// RequirePrice excludes the flavors missing from Prices.
+	RequirePrice bool
+}
+
+// needsExtraSpecs tells whether the requirements depend on extra specs.
+func (opts MatchOpts) needsExtraSpecs() bool {
+	return opts.VGPUs > 0 || len(opts.PCIDevices) > 0 || opts.CPUPolicy != "" || len(opts.ExtraSpecs) > 0
+}
+
+// price returns the price of a flavor.
+func (opts MatchOpts) price(f Flavor) (float64, bool) {
+	if price, ok := opts.Prices[f.ID]; ok {
+		return price, true
+	}
+	price, ok := opts.Prices[f.Name]
+	return price, ok
+}
+
+// check returns the reasons why a flavor does not meet the requirements.
+func (opts MatchOpts) check(f Flavor) []string {
+	var reasons []string
+	if f.VCPUs < opts.MinVCPUs {
+		reasons = append(reasons, fmt.Sprintf("%d vCPUs, %d required", f.VCPUs, opts.MinVCPUs))
+	}
+	if f.RAM < opts.MinRAM {
+		reasons = append(reasons, fmt.Sprintf("%d MB of RAM, %d MB required", f.RAM, opts.MinRAM))
+	}
+	if !opts.BootFromVolume && f.Disk < opts.MinDisk {
+		reasons = append(reasons, fmt.Sprintf("%d GB of disk, %d GB required", f.Disk, opts.MinDisk))
+	}
+	if f.Ephemeral < opts.MinEphemeral {
+		reasons = append(reasons, fmt.Sprintf("%d GB of ephemeral disk, %d GB required", f.Ephemeral, opts.MinEphemeral))
+	}
+	if opts.RequirePrice {
+		if _, ok := opts.price(f); !ok {
+			reasons = append(reasons, "no price")
+		}
+	}
+
+	if opts.VGPUs > 0 {
+		vgpus, _ := strconv.Atoi(f.ExtraSpecs[ExtraSpecVGPU])
+		if vgpus < opts.VGPUs {
+			reasons = append(reasons, fmt.Sprintf("%d vGPUs, %d required", vgpus, opts.VGPUs))
+		}
+	}
+	if len(opts.PCIDevices) > 0 {
+		devices := parsePCIAlias(f.ExtraSpecs[ExtraSpecPCIAlias])
+		for _, alias := range sortedKeys(opts.PCIDevices) {
+			if count := opts.PCIDevices[alias]; devices[alias] < count {
+				reasons = append(reasons, fmt.Sprintf("%d PCI devices %s, %d required", devices[alias], alias, count))
+			}
+		}
+	}
+	if opts.CPUPolicy != "" {
+		policy := f.ExtraSpecs[ExtraSpecCPUPolicy]
+		if policy == "" {
+			policy = "shared"
+		}
+		if policy != opts.CPUPolicy {
+			reasons = append(reasons, fmt.Sprintf("CPU policy %s, %s required", policy, opts.CPUPolicy))
+		}
+	}
+	for _, key := range sortedKeys(opts.ExtraSpecs) {
+		value, ok := f.ExtraSpecs[key]
+		switch {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("no extra spec %s", key))
+		case value != opts.ExtraSpecs[key]:
+			reasons = append(reasons, fmt.Sprintf("extra spec %s is %q, %q required", key, value, opts.ExtraSpecs[key]))
+		}
+	}
+	return reasons
+}
+
+// parsePCIAlias parses the value of pci_passthrough:alias into a count of
+// devices per alias.
+func parsePCIAlias(value string) map[string]int {
+	devices := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		alias, count, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if alias == "" {
+			continue
+		}
+		n := 1
+		if found {
+			n, _ = strconv.Atoi(count)
+		}
+		devices[alias] += n
+	}
+	return devices
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Candidate is a flavor meeting the requirements given to Match.
+type Candidate struct {
+	Flavor Flavor
+
+	// Price is the price of the flavor, if HasPrice is true.
+	Price    float64
+	HasPrice bool
+}
+
+// Exclusion is a flavor which does not meet the requirements given to Match.
+type Exclusion struct {
+	Flavor Flavor
+
+	// Reasons explain which requirements the flavor does not meet.
+	Reasons []string
+}
+
+// MatchResult is the result of Match.
+type MatchResult struct {
+	// Candidates are the flavors meeting the requirements, best first: the
+	// cheapest ones, then the smallest ones.
+	Candidates []Candidate
+
+	// Excluded are the other flavors.
+	Excluded []Exclusion
+}
+
+// Best returns the best candidate, or false if no flavor meets the
+// requirements.
+func (r MatchResult) Best() (Candidate, bool) {
+	if len(r.Candidates) == 0 {
+		return Candidate{}, false
+	}
+	return r.Candidates[0], true
+}
+
+// Match lists the flavors and ranks the ones meeting the requirements.
+//
+// Extra specs are part of flavors since microversion 2.61. With earlier
+// microversions, they are requested for each flavor when the requirements
+// depend on them.
+func Match(ctx context.Context, client *gophercloud.ServiceClient, opts MatchOpts) (*MatchResult, error) {
+	// The flavors are not filtered by the API, so that all the exclusions
+	// can be explained.
+	allPages, err := ListDetail(client, ListOpts{AccessType: opts.AccessType}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allFlavors, err := ExtractFlavors(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(MatchResult)
+	for _, f := range allFlavors {
+		if f.ExtraSpecs == nil && opts.needsExtraSpecs() {
+			f.ExtraSpecs, err = ListExtraSpecs(ctx, client, f.ID).Extract()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		reasons := opts.check(f)
+		if len(reasons) == 0 && opts.ProjectID != "" && !f.IsPublic {
+			allowed, err := hasAccess(ctx, client, f.ID, opts.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				reasons = append(reasons, fmt.Sprintf("private flavor, not shared with project %s", opts.ProjectID))
+			}
+		}
+
+		if len(reasons) > 0 {
+			result.Excluded = append(result.Excluded, Exclusion{Flavor: f, Reasons: reasons})
+			continue
+		}
+		candidate := Candidate{Flavor: f}
+		candidate.Price, candidate.HasPrice = opts.price(f)
+		result.Candidates = append(result.Candidates, candidate)
+	}
+
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		a, b := result.Candidates[i], result.Candidates[j]
+		if a.HasPrice != b.HasPrice {
+			return a.HasPrice
+		}
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		}
+		if a.Flavor.VCPUs != b.Flavor.VCPUs {
+			return a.Flavor.VCPUs < b.Flavor.VCPUs
+		}
+		if a.Flavor.RAM != b.Flavor.RAM {
+			return a.Flavor.RAM < b.Flavor.RAM
+		}
+		if a.Flavor.Disk+a.Flavor.Ephemeral != b.Flavor.Disk+b.Flavor.Ephemeral {
+			return a.Flavor.Disk+a.Flavor.Ephemeral < b.Flavor.Disk+b.Flavor.Ephemeral
+		}
+		return a.Flavor.Name < b.Flavor.Name
+	})
+
+	return result, nil
+}
+
+// hasAccess tells whether a project has access to a private flavor.
+func hasAccess(ctx context.Context, client *gophercloud.ServiceClient, flavorID, projectID string) (bool, error) {
+	allPages, err := ListAccesses(client, flavorID).AllPages(ctx)
+	if err != nil {
+		return false, err
+	}
+	accesses, err := ExtractAccesses(allPages)
+	if err != nil {
+		return false, err
+	}
+	for _, access := range accesses {
+		if access.TenantID == projectID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
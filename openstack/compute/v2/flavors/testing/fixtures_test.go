@@ -114,3 +114,66 @@ func HandleExtraSpecDeleteSuccessfully(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+// MatchFlavorsBody provides the flavors of the matcher tests. The extra specs
+// are missing, as with microversions below 2.61.
+const MatchFlavorsBody = `
+{
+    "flavors": [
+        {"id": "1", "name": "m1.small", "vcpus": 1, "ram": 2048, "disk": 20, "os-flavor-access:is_public": true, "OS-FLV-EXT-DATA:ephemeral": 0},
+        {"id": "2", "name": "m1.medium", "vcpus": 2, "ram": 4096, "disk": 40, "os-flavor-access:is_public": true, "OS-FLV-EXT-DATA:ephemeral": 0},
+        {"id": "3", "name": "m1.large", "vcpus": 4, "ram": 8192, "disk": 80, "os-flavor-access:is_public": true, "OS-FLV-EXT-DATA:ephemeral": 0},
+        {"id": "4", "name": "c1.large", "vcpus": 4, "ram": 8192, "disk": 80, "os-flavor-access:is_public": true, "OS-FLV-EXT-DATA:ephemeral": 0},
+        {"id": "5", "name": "g1.large", "vcpus": 8, "ram": 16384, "disk": 80, "os-flavor-access:is_public": false, "OS-FLV-EXT-DATA:ephemeral": 0},
+        {"id": "6", "name": "g1.xlarge", "vcpus": 16, "ram": 32768, "disk": 80, "os-flavor-access:is_public": false, "OS-FLV-EXT-DATA:ephemeral": 0}
+    ]
+}
+`
+
+// MatchExtraSpecs are the extra specs of the flavors of MatchFlavorsBody.
+var MatchExtraSpecs = map[string]string{
+	"1": `{"extra_specs": {}}`,
+	"2": `{"extra_specs": {"hw:cpu_policy": "shared"}}`,
+	"3": `{"extra_specs": {"hw:cpu_policy": "dedicated"}}`,
+	"4": `{"extra_specs": {"hw:cpu_policy": "dedicated", "pci_passthrough:alias": "a100:1"}}`,
+	"5": `{"extra_specs": {"hw:cpu_policy": "dedicated", "pci_passthrough:alias": "a100:2, nic:1"}}`,
+	"6": `{"extra_specs": {"hw:cpu_policy": "dedicated", "pci_passthrough:alias": "a100:4"}}`,
+}
+
+// HandleMatchFlavorsSuccessfully sets up the test server to respond to the
+// requests of flavors.Match. Flavor 5 is shared with the project
+// 15153a0979884b59b0592248ef947921, flavor 6 is not.
+func HandleMatchFlavorsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/flavors/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"is_public": "None"})
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Form.Get("marker") != "" {
+			fmt.Fprint(w, `{"flavors": []}`)
+			return
+		}
+		fmt.Fprint(w, MatchFlavorsBody)
+	})
+
+	for id, body := range MatchExtraSpecs {
+		th.Mux.HandleFunc("/flavors/"+id+"/os-extra_specs", func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, body)
+		})
+	}
+
+	for id, project := range map[string]string{"5": "15153a0979884b59b0592248ef947921", "6": "2f2b8d7b4c2c4b8f8d7b1b8f3c1a9e0d"} {
+		th.Mux.HandleFunc("/flavors/"+id+"/os-flavor-access", func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"flavor_access": [{"flavor_id": "%s", "tenant_id": "%s"}]}`, id, project)
+		})
+	}
+}
@@ -464,3 +464,63 @@ func TestFlavorExtraSpecDelete(t *testing.T) {
 	res := flavors.DeleteExtraSpec(context.TODO(), fake.ServiceClient(), "1", "hw:cpu_policy")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestMatchFlavors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleMatchFlavorsSuccessfully(t)
+
+	result, err := flavors.Match(context.TODO(), fake.ServiceClient(), flavors.MatchOpts{
+		MinVCPUs:   2,
+		MinRAM:     4096,
+		CPUPolicy:  "dedicated",
+		AccessType: flavors.AllAccess,
+		ProjectID:  "15153a0979884b59b0592248ef947921",
+		Prices: map[string]float64{
+			"m1.large": 0.20,
+			"5":        0.15,
+		},
+	})
+	th.AssertNoErr(t, err)
+
+	var candidates []string
+	for _, c := range result.Candidates {
+		candidates = append(candidates, c.Flavor.Name)
+	}
+	th.CheckDeepEquals(t, []string{"g1.large", "m1.large", "c1.large"}, candidates)
+	th.CheckEquals(t, 0.15, result.Candidates[0].Price)
+	th.CheckEquals(t, false, result.Candidates[2].HasPrice)
+	th.CheckDeepEquals(t, map[string]string{"hw:cpu_policy": "dedicated", "pci_passthrough:alias": "a100:1"}, result.Candidates[2].Flavor.ExtraSpecs)
+
+	excluded := make(map[string][]string)
+	for _, e := range result.Excluded {
+		excluded[e.Flavor.Name] = e.Reasons
+	}
+	th.CheckDeepEquals(t, map[string][]string{
+		"m1.small":  {"1 vCPUs, 2 required", "2048 MB of RAM, 4096 MB required", "CPU policy shared, dedicated required"},
+		"m1.medium": {"CPU policy shared, dedicated required"},
+		"g1.xlarge": {"private flavor, not shared with project 15153a0979884b59b0592248ef947921"},
+	}, excluded)
+}
+
+func TestMatchFlavorsPCIDevices(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleMatchFlavorsSuccessfully(t)
+
+	result, err := flavors.Match(context.TODO(), fake.ServiceClient(), flavors.MatchOpts{
+		PCIDevices:   map[string]int{"a100": 2},
+		AccessType:   flavors.AllAccess,
+		Prices:       map[string]float64{"g1.large": 2.5, "g1.xlarge": 4},
+		RequirePrice: true,
+	})
+	th.AssertNoErr(t, err)
+
+	best, ok := result.Best()
+	th.AssertEquals(t, true, ok)
+	th.CheckEquals(t, "g1.large", best.Flavor.Name)
+	th.CheckEquals(t, 2, len(result.Candidates))
+	th.CheckEquals(t, 4, len(result.Excluded))
+	th.CheckEquals(t, "c1.large", result.Excluded[3].Flavor.Name)
+	th.CheckDeepEquals(t, []string{"no price", "1 PCI devices a100, 2 required"}, result.Excluded[3].Reasons)
+}
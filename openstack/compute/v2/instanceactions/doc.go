@@ -23,4 +23,27 @@ Example to List and Get actions:
 
 		fmt.Println(action)
 	}
+
+Example to Watch the Actions of Servers
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := instanceactions.Watch(ctx, client, instanceactions.WatchOpts{
+		ServerIDs: []string{"server-id-1", "server-id-2"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for event := range events {
+		switch event.Type {
+		case instanceactions.WatchError:
+			fmt.Printf("%s: %v\n", event.ServerID, event.Err)
+		case instanceactions.WatchEventFinished:
+			fmt.Printf("%s: %s %s: %s\n", event.ServerID, event.Action.Action, event.Event.Event, event.Event.Result)
+		default:
+			fmt.Printf("%s: %s %s\n", event.ServerID, event.Action.Action, event.Type)
+		}
+	}
 */
@@ -30,6 +30,10 @@ type InstanceAction struct {
 
 	// UserID is the ID of the user which initiated the action.
 	UserID string `json:"user_id"`
+
+	// UpdatedAt last update date of the action.
+	// This requires microversion 2.58 or later.
+	UpdatedAt *time.Time `json:"-"`
 }
 
 // UnmarshalJSON converts our JSON API response into our instance action struct
@@ -37,7 +41,8 @@ func (i *InstanceAction) UnmarshalJSON(b []byte) error {
 	type tmp InstanceAction
 	var s struct {
 		tmp
-		StartTime gophercloud.JSONRFC3339MilliNoZ `json:"start_time"`
+		UpdatedAt *gophercloud.JSONRFC3339MilliNoZ `json:"updated_at"`
+		StartTime gophercloud.JSONRFC3339MilliNoZ  `json:"start_time"`
 	}
 	err := json.Unmarshal(b, &s)
 	if err != nil {
@@ -45,6 +50,7 @@ func (i *InstanceAction) UnmarshalJSON(b []byte) error {
 	}
 	*i = InstanceAction(s.tmp)
 
+	i.UpdatedAt = (*time.Time)(s.UpdatedAt)
 	i.StartTime = time.Time(s.StartTime)
 
 	return err
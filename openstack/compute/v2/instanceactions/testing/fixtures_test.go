@@ -3,6 +3,9 @@ package testing
 import (
 	"fmt"
 	"net/http"
+	"path"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -126,3 +129,265 @@ func HandleInstanceActionGetSuccessfully(t *testing.T) {
 		}`)
 	})
 }
+
+// ActionOutput is a sample instance action, with a single event.
+const ActionOutput = `
+{
+	"action": "%s",
+	"instance_uuid": "7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e",
+	"message": %s,
+	"project_id": "6f70656e737461636b20342065766572",
+	"request_id": "%s",
+	"start_time": "%s",
+	"updated_at": "%s",
+	"user_id": "admin"%s
+}
+`
+
+// EventOutput is a sample event of an instance action.
+const EventOutput = `
+	"events": [
+		{
+			"event": "compute_%s_instance",
+			"host": "compute",
+			"hostId": "2091634baaccdc4c5a1d57069c833e402921df696b7f970791b12ec6",
+			"result": %s,
+			"start_time": "%s",
+			"finish_time": %s,
+			"traceback": %s
+		}
+	]
+`
+
+// WatchedAction is an action of the server watched by HandleWatch.
+type WatchedAction struct {
+	Name      string
+	RequestID string
+	StartTime string
+	UpdatedAt string
+	Message   string
+	Result    string
+	Finish    string
+	Traceback string
+}
+
+func jsonOrNull(s string) string {
+	if s == "" {
+		return "null"
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+func (a WatchedAction) output(withEvents bool) string {
+	events := ""
+	if withEvents {
+		events = "," + fmt.Sprintf(EventOutput, a.Name, jsonOrNull(a.Result), a.StartTime, jsonOrNull(a.Finish), jsonOrNull(a.Traceback))
+	}
+	return fmt.Sprintf(ActionOutput, a.Name, jsonOrNull(a.Message), a.RequestID, a.StartTime, a.UpdatedAt, events)
+}
+
+var (
+	rebootStarted = WatchedAction{
+		Name:      "reboot",
+		RequestID: "req-2b5e1c8e-6f0a-4d1e-8a3b-0c6f6f3e9a01",
+		StartTime: "2024-10-17T10:00:05.000000",
+		UpdatedAt: "2024-10-17T10:00:05.500000",
+	}
+	rebootFinished = WatchedAction{
+		Name:      "reboot",
+		RequestID: "req-2b5e1c8e-6f0a-4d1e-8a3b-0c6f6f3e9a01",
+		StartTime: "2024-10-17T10:00:05.000000",
+		UpdatedAt: "2024-10-17T10:00:09.000000",
+		Result:    "Success",
+		Finish:    "2024-10-17T10:00:09.000000",
+	}
+	stopFailed = WatchedAction{
+		Name:      "stop",
+		RequestID: "req-9c1d7f3a-5e2b-4c8d-9f0e-1a2b3c4d5e6f",
+		StartTime: "2024-10-17T10:00:11.000000",
+		UpdatedAt: "2024-10-17T10:00:12.000000",
+		Message:   "Error",
+		Result:    "Error",
+		Finish:    "2024-10-17T10:00:12.000000",
+		Traceback: "Traceback (most recent call last):\n  File \"nova/compute/manager.py\"",
+	}
+)
+
+// HandleWatch sets up the test server to report the actions of a server as
+// they progress, depending on the changes-since filter, and to respond 404
+// for the actions of another server. It returns the number of Get requests
+// per request ID.
+func HandleWatch(t *testing.T) (gets func(string) int) {
+	var mu sync.Mutex
+	getCount := make(map[string]int)
+	// details are the details of the actions, as of the last List.
+	details := make(map[string]WatchedAction)
+
+	th.Mux.HandleFunc("/servers/7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e/os-instance-actions", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		var listed []WatchedAction
+		switch since := r.URL.Query().Get("changes-since"); since {
+		case "2024-10-17T10:00:00Z":
+			listed = []WatchedAction{rebootStarted}
+		case "2024-10-17T10:00:05Z":
+			listed = []WatchedAction{rebootFinished}
+		case "2024-10-17T10:00:09Z":
+			listed = []WatchedAction{stopFailed, rebootFinished}
+		case "2024-10-17T10:00:12Z":
+			listed = []WatchedAction{stopFailed}
+		default:
+			t.Errorf("unexpected changes-since %s", since)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		actions := make([]string, 0, len(listed))
+		for _, a := range listed {
+			details[a.RequestID] = a
+			actions = append(actions, a.output(false))
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"instanceActions": [%s]}`, strings.Join(actions, ","))
+	})
+
+	th.Mux.HandleFunc("/servers/7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e/os-instance-actions/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		requestID := path.Base(r.URL.Path)
+		getCount[requestID]++
+		a, ok := details[requestID]
+		if !ok {
+			t.Errorf("unexpected action %s", requestID)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"instanceAction": %s}`, a.output(true))
+	})
+
+	th.Mux.HandleFunc("/servers/7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"server": {"id": "7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e", "status": "ACTIVE", "OS-EXT-STS:task_state": null}}`)
+	})
+
+	th.Mux.HandleFunc("/servers/deleted/os-instance-actions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return func(requestID string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return getCount[requestID]
+	}
+}
+
+// MigrationOutput is a live migration action, with the events of a stage of
+// HandleWatchMigration.
+const MigrationOutput = `
+{
+	"action": "live-migration",
+	"instance_uuid": "0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a",
+	"message": null,
+	"project_id": "6f70656e737461636b20342065766572",
+	"request_id": "req-4f1e2d3c-0000-4b5a-8c7d-6e5f4a3b2c1d",
+	"start_time": "2024-10-17T10:00:01.000000",
+	"updated_at": "%s",
+	"user_id": "admin",
+	"events": [%s]
+}
+`
+
+// migrationStage is the state of the live migration at a poll.
+type migrationStage struct {
+	updatedAt string
+	events    []string
+	taskState string
+}
+
+// migrationStages are the states of the live migration, by changes-since
+// filter. The second event is added after the first one finished.
+var migrationStages = map[string]migrationStage{
+	"2024-10-17T10:00:00Z": {
+		updatedAt: "2024-10-17T10:00:01.000000",
+		events:    []string{migrationEvent("compute_check_can_live_migrate_destination", "2024-10-17T10:00:01.000000", "")},
+		taskState: "migrating",
+	},
+	"2024-10-17T10:00:01Z": {
+		updatedAt: "2024-10-17T10:00:03.000000",
+		events:    []string{migrationEvent("compute_check_can_live_migrate_destination", "2024-10-17T10:00:01.000000", "2024-10-17T10:00:03.000000")},
+		taskState: "migrating",
+	},
+	"2024-10-17T10:00:03Z": {
+		updatedAt: "2024-10-17T10:00:05.000000",
+		events: []string{
+			migrationEvent("compute_check_can_live_migrate_destination", "2024-10-17T10:00:01.000000", "2024-10-17T10:00:03.000000"),
+			migrationEvent("compute_live_migration", "2024-10-17T10:00:05.000000", ""),
+		},
+		taskState: "migrating",
+	},
+	"2024-10-17T10:00:05Z": {
+		updatedAt: "2024-10-17T10:00:08.000000",
+		events: []string{
+			migrationEvent("compute_check_can_live_migrate_destination", "2024-10-17T10:00:01.000000", "2024-10-17T10:00:03.000000"),
+			migrationEvent("compute_live_migration", "2024-10-17T10:00:05.000000", "2024-10-17T10:00:08.000000"),
+		},
+	},
+}
+
+func migrationEvent(name, start, finish string) string {
+	result := ""
+	if finish != "" {
+		result = "Success"
+	}
+	return fmt.Sprintf(`{"event": %q, "host": "compute", "result": %s, "start_time": %q, "finish_time": %s, "traceback": null}`, name, jsonOrNull(result), start, jsonOrNull(finish))
+}
+
+// HandleWatchMigration sets up the test server to report a live migration
+// whose events are added over time, while the server is migrating.
+func HandleWatchMigration(t *testing.T) {
+	var mu sync.Mutex
+	// stage is the state of the migration, as of the last List.
+	stage := migrationStages["2024-10-17T10:00:00Z"]
+
+	th.Mux.HandleFunc("/servers/0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a/os-instance-actions", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		mu.Lock()
+		defer mu.Unlock()
+		since := r.URL.Query().Get("changes-since")
+		if s, ok := migrationStages[since]; ok {
+			stage = s
+		} else if since != "2024-10-17T10:00:08Z" {
+			t.Errorf("unexpected changes-since %s", since)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"instanceActions": [%s]}`, fmt.Sprintf(MigrationOutput, stage.updatedAt, ""))
+	})
+
+	th.Mux.HandleFunc("/servers/0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a/os-instance-actions/req-4f1e2d3c-0000-4b5a-8c7d-6e5f4a3b2c1d", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"instanceAction": %s}`, fmt.Sprintf(MigrationOutput, stage.updatedAt, strings.Join(stage.events, ",")))
+	})
+
+	th.Mux.HandleFunc("/servers/0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"server": {"id": "0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a", "status": "MIGRATING", "OS-EXT-STS:task_state": %s}}`, jsonOrNull(stage.taskState))
+	})
+}
@@ -2,8 +2,11 @@ package testing
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/vnpaycloud-console/gophercloud/v2"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/instanceactions"
 	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
 	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
@@ -47,3 +50,118 @@ func TestGet(t *testing.T) {
 
 	th.CheckDeepEquals(t, GetExpected, actual)
 }
+
+func TestWatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	gets := HandleWatch(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	since := time.Date(2024, 10, 17, 10, 0, 0, 0, time.UTC)
+	events, err := instanceactions.Watch(ctx, client.ServiceClient(), instanceactions.WatchOpts{
+		ServerIDs: []string{"7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e", "deleted"},
+		Since:     &since,
+		Interval:  time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+
+	// Errors are sent at every poll of the deleted server.
+	var actual []instanceactions.WatchEvent
+	for e := range events {
+		if e.Type == instanceactions.WatchError {
+			th.CheckEquals(t, "deleted", e.ServerID)
+			th.AssertEquals(t, true, gophercloud.ResponseCodeIs(e.Err, http.StatusNotFound))
+			continue
+		}
+		actual = append(actual, e)
+		if len(actual) == 7 {
+			cancel()
+		}
+	}
+
+	th.AssertEquals(t, 7, len(actual))
+	for i, expected := range []struct {
+		eventType instanceactions.WatchEventType
+		action    string
+		failed    bool
+	}{
+		{instanceactions.WatchActionStarted, "reboot", false},
+		{instanceactions.WatchEventStarted, "reboot", false},
+		{instanceactions.WatchEventFinished, "reboot", false},
+		{instanceactions.WatchActionFinished, "reboot", false},
+		{instanceactions.WatchActionStarted, "stop", true},
+		{instanceactions.WatchEventFinished, "stop", true},
+		{instanceactions.WatchActionFinished, "stop", true},
+	} {
+		th.CheckEquals(t, expected.eventType, actual[i].Type)
+		th.CheckEquals(t, "7d5a4a1c-6a0c-4d3e-b4a8-1d5d0b2a2f7e", actual[i].ServerID)
+		th.CheckEquals(t, expected.action, actual[i].Action.Action)
+		th.CheckEquals(t, expected.failed, actual[i].Failed())
+	}
+
+	th.CheckEquals(t, "compute_reboot_instance", actual[2].Event.Event)
+	th.CheckEquals(t, "Success", actual[2].Event.Result)
+	th.CheckEquals(t, time.Date(2024, 10, 17, 10, 0, 9, 0, time.UTC), actual[2].Event.FinishTime)
+	th.CheckEquals(t, "Traceback (most recent call last):\n  File \"nova/compute/manager.py\"", actual[5].Event.Traceback)
+
+	// The finished reboot is not fetched again when listed.
+	th.CheckEquals(t, 2, gets("req-2b5e1c8e-6f0a-4d1e-8a3b-0c6f6f3e9a01"))
+	th.CheckEquals(t, 1, gets("req-9c1d7f3a-5e2b-4c8d-9f0e-1a2b3c4d5e6f"))
+}
+
+func TestWatchEventsAddedLater(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleWatchMigration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	since := time.Date(2024, 10, 17, 10, 0, 0, 0, time.UTC)
+	events, err := instanceactions.Watch(ctx, client.ServiceClient(), instanceactions.WatchOpts{
+		ServerIDs: []string{"0b6e3c2a-9d4f-4e1b-8c7a-5f2e1d3c4b6a"},
+		Since:     &since,
+		Interval:  time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+
+	var actual []instanceactions.WatchEvent
+	for e := range events {
+		th.AssertNoErr(t, e.Err)
+		actual = append(actual, e)
+		if e.Type == instanceactions.WatchActionFinished {
+			cancel()
+		}
+	}
+
+	// The action is not finished when its first event is, since the server
+	// is still migrating.
+	th.AssertEquals(t, 6, len(actual))
+	for i, expected := range []struct {
+		eventType instanceactions.WatchEventType
+		event     string
+	}{
+		{instanceactions.WatchActionStarted, ""},
+		{instanceactions.WatchEventStarted, "compute_check_can_live_migrate_destination"},
+		{instanceactions.WatchEventFinished, "compute_check_can_live_migrate_destination"},
+		{instanceactions.WatchEventStarted, "compute_live_migration"},
+		{instanceactions.WatchEventFinished, "compute_live_migration"},
+		{instanceactions.WatchActionFinished, ""},
+	} {
+		th.CheckEquals(t, expected.eventType, actual[i].Type)
+		th.CheckEquals(t, "live-migration", actual[i].Action.Action)
+		if expected.event != "" {
+			th.CheckEquals(t, expected.event, actual[i].Event.Event)
+		}
+	}
+	th.CheckEquals(t, 2, len(*actual[5].Action.Events))
+}
+
+func TestWatchMissingServers(t *testing.T) {
+	_, err := instanceactions.Watch(context.TODO(), client.ServiceClient(), instanceactions.WatchOpts{})
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("expected ErrMissingInput, got %v", err)
+	}
+}
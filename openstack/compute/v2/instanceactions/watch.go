@@ -0,0 +1,316 @@
+package instanceactions
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+)
+
+// WatchEventType is the type of a WatchEvent.
+type WatchEventType string
+
+const (
+	// WatchActionStarted is sent when an action is first seen.
+	WatchActionStarted WatchEventType = "action-started"
+
+	// WatchEventStarted is sent when an event of an action is seen in
+	// progress. Events which start and finish between two polls are only
+	// reported by WatchEventFinished.
+	WatchEventStarted WatchEventType = "event-started"
+
+	// WatchEventFinished is sent when an event of an action finishes, with
+	// its result.
+	WatchEventFinished WatchEventType = "event-finished"
+
+	// WatchActionFinished is sent when all the events of an action are
+	// finished and the server has no task state anymore. It is sent again
+	// if events are added to the action later.
+	WatchActionFinished WatchEventType = "action-finished"
+
+	// WatchError is sent when the actions of a server can't be polled. The
+	// server is polled again after the interval.
+	WatchError WatchEventType = "error"
+)
+
+// WatchEvent is sent by Watch.
+type WatchEvent struct {
+	Type WatchEventType
+
+	// ServerID is the ID of the server of the action.
+	ServerID string
+
+	// Action is the action, as last polled. It is unset for WatchError.
+	Action InstanceActionDetail
+
+	// Event is the event of WatchEventStarted and WatchEventFinished. Its
+	// traceback is only set when the policy of the cloud permits it, usually
+	// to administrators.
+	Event *Event
+
+	// Err is the error of WatchError.
+	Err error
+}
+
+// Failed tells whether the event, or the action, failed.
+func (e WatchEvent) Failed() bool {
+	if e.Event != nil {
+		return e.Event.Result == "Error"
+	}
+	if e.Action.Message != "" {
+		return true
+	}
+	if e.Action.Events != nil {
+		for _, event := range *e.Action.Events {
+			if event.Result == "Error" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WatchOpts configures Watch.
+type WatchOpts struct {
+	// ServerIDs are the IDs of the servers to watch.
+	ServerIDs []string
+
+	// Since reports the actions updated since then. Defaults to the time Watch
+	// is called, as given by the local clock.
+	Since *time.Time
+
+	// Interval is the time between two polls of a server. Defaults to 5
+	// seconds.
+	Interval time.Duration
+
+	// Buffer is the capacity of the returned channel.
+	Buffer int
+}
+
+// Watch polls the actions of servers and sends their progress on the
+// returned channel, until ctx is done, then closes the channel.
+//
+// Each poll lists the actions updated since the last one, with the
+// changes-since filter, and gets the details of the ones updated since they
+// were last fetched. An action is finished when all its events are finished
+// and the server has no task state, which is read from the server. The
+// filter and the update time of actions require microversion 2.58 or later;
+// with earlier microversions, all the actions are listed at every poll, only
+// the ones started since WatchOpts.Since are reported, and the events added
+// to an action after it finished are missed. Events require microversion
+// 2.50 or later.
+func Watch(ctx context.Context, client *gophercloud.ServiceClient, opts WatchOpts) (<-chan WatchEvent, error) {
+	if len(opts.ServerIDs) == 0 {
+		return nil, gophercloud.ErrMissingInput{Argument: "ServerIDs"}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	since := time.Now()
+	if opts.Since != nil {
+		since = *opts.Since
+	}
+
+	w := &watcher{
+		client:  client,
+		since:   since,
+		ch:      make(chan WatchEvent, opts.Buffer),
+		servers: make(map[string]*watchedServer, len(opts.ServerIDs)),
+	}
+	for _, id := range opts.ServerIDs {
+		w.servers[id] = &watchedServer{actions: make(map[string]*watchedAction)}
+	}
+
+	go func() {
+		defer close(w.ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			for _, id := range opts.ServerIDs {
+				if !w.poll(ctx, id) {
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return w.ch, nil
+}
+
+type watcher struct {
+	client  *gophercloud.ServiceClient
+	since   time.Time
+	ch      chan WatchEvent
+	servers map[string]*watchedServer
+}
+
+type watchedServer struct {
+	// cursor is the last update time of the actions of the server.
+	cursor  *time.Time
+	actions map[string]*watchedAction
+}
+
+type watchedAction struct {
+	updatedAt *time.Time
+	finished  bool
+
+	// settling tells whether all the events of the action are finished, but
+	// the server still has a task state, so that more events may come.
+	settling bool
+
+	// detail is the action, as last fetched.
+	detail InstanceActionDetail
+
+	// events tells, for each event already reported, whether it finished.
+	events map[string]bool
+}
+
+// send sends an event, and returns false if ctx is done.
+func (w *watcher) send(ctx context.Context, e WatchEvent) bool {
+	select {
+	case w.ch <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// poll polls the actions of a server, and returns false if ctx is done.
+func (w *watcher) poll(ctx context.Context, serverID string) bool {
+	server := w.servers[serverID]
+
+	cursor := server.cursor
+	if cursor == nil {
+		cursor = &w.since
+	}
+	allPages, err := List(w.client, serverID, ListOpts{ChangesSince: cursor}).AllPages(ctx)
+	if err != nil {
+		return ctx.Err() == nil && w.send(ctx, WatchEvent{Type: WatchError, ServerID: serverID, Err: err})
+	}
+	actions, err := ExtractInstanceActions(allPages)
+	if err != nil {
+		return w.send(ctx, WatchEvent{Type: WatchError, ServerID: serverID, Err: err})
+	}
+
+	// Actions are listed from the most recent.
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].StartTime.Before(actions[j].StartTime)
+	})
+
+	for _, action := range actions {
+		if action.UpdatedAt == nil && action.StartTime.Before(w.since) {
+			continue
+		}
+		if action.UpdatedAt != nil && (server.cursor == nil || action.UpdatedAt.After(*server.cursor)) {
+			server.cursor = action.UpdatedAt
+		}
+
+		watched, ok := server.actions[action.RequestID]
+		if ok {
+			if action.UpdatedAt != nil && watched.updatedAt != nil && action.UpdatedAt.Equal(*watched.updatedAt) {
+				continue
+			}
+			// Without update time, changes of finished actions can't be
+			// detected.
+			if action.UpdatedAt == nil && watched.finished {
+				continue
+			}
+		}
+
+		detail, err := Get(ctx, w.client, serverID, action.RequestID).Extract()
+		if err != nil {
+			if !w.send(ctx, WatchEvent{Type: WatchError, ServerID: serverID, Err: err}) {
+				return false
+			}
+			continue
+		}
+
+		if !ok {
+			watched = &watchedAction{events: make(map[string]bool)}
+			server.actions[action.RequestID] = watched
+			if !w.send(ctx, WatchEvent{Type: WatchActionStarted, ServerID: serverID, Action: detail}) {
+				return false
+			}
+		}
+		watched.updatedAt = action.UpdatedAt
+		watched.detail = detail
+
+		var events []Event
+		if detail.Events != nil {
+			events = append(events, *detail.Events...)
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].StartTime.Before(events[j].StartTime)
+		})
+
+		allFinished, reportedEvents := len(events) > 0, false
+		for i := range events {
+			event := &events[i]
+			key := event.Event + "@" + event.StartTime.String()
+			finished := !event.FinishTime.IsZero()
+			allFinished = allFinished && finished
+
+			reported, seen := watched.events[key]
+			switch {
+			case finished && !reported:
+				reportedEvents = true
+				if !w.send(ctx, WatchEvent{Type: WatchEventFinished, ServerID: serverID, Action: detail, Event: event}) {
+					return false
+				}
+			case !finished && !seen:
+				reportedEvents = true
+				if !w.send(ctx, WatchEvent{Type: WatchEventStarted, ServerID: serverID, Action: detail, Event: event}) {
+					return false
+				}
+			}
+			watched.events[key] = finished
+		}
+
+		// Actions such as resizes and live migrations add events over time,
+		// so that an action is only finished once the server has no task
+		// state anymore.
+		watched.finished = watched.finished && allFinished && !reportedEvents
+		watched.settling = allFinished && !watched.finished
+	}
+
+	// Settling actions are checked at every poll, even when they are not
+	// listed anymore.
+	var settling []*watchedAction
+	for _, watched := range server.actions {
+		if watched.settling {
+			settling = append(settling, watched)
+		}
+	}
+	if len(settling) == 0 {
+		return true
+	}
+	current, err := servers.Get(ctx, w.client, serverID).Extract()
+	if err != nil {
+		return ctx.Err() == nil && w.send(ctx, WatchEvent{Type: WatchError, ServerID: serverID, Err: err})
+	}
+	if current.TaskState != "" {
+		return true
+	}
+	sort.Slice(settling, func(i, j int) bool {
+		return settling[i].detail.StartTime.Before(settling[j].detail.StartTime)
+	})
+	for _, watched := range settling {
+		watched.settling = false
+		watched.finished = true
+		if !w.send(ctx, WatchEvent{Type: WatchActionFinished, ServerID: serverID, Action: watched.detail}) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+/*
+Package migrations lists the migrations of the compute service, and manages
+the in-progress live migrations of servers.
+
+Example to List the Live Migrations of a Host
+
+	listOpts := migrations.ListOpts{
+		Host:          "compute-1",
+		MigrationType: migrations.TypeLiveMigration,
+	}
+
+	allPages, err := migrations.List(computeClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allMigrations, err := migrations.ExtractMigrations(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, migration := range allMigrations {
+		fmt.Printf("%+v\n", migration)
+	}
+
+Example to Force a Live Migration to Complete
+
+	serverID := "d9072956-1560-487c-97f2-18bdf65ec749"
+	migrationID := 1234
+
+	err := migrations.ForceComplete(context.TODO(), computeClient, serverID, migrationID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Abort a Live Migration
+
+	serverID := "d9072956-1560-487c-97f2-18bdf65ec749"
+	migrationID := 1234
+
+	err := migrations.Abort(context.TODO(), computeClient, serverID, migrationID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Live Migrate a Server and Wait for the Migration
+
+	serverID := "d9072956-1560-487c-97f2-18bdf65ec749"
+
+	err := servers.LiveMigrate(context.TODO(), computeClient, serverID, servers.LiveMigrateOpts{}).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	migration, err := migrations.WaitForLiveMigration(context.TODO(), computeClient, serverID, migrations.WaitOpts{
+		OnProgress: func(m migrations.ServerMigration) {
+			fmt.Printf("%d bytes of memory remaining\n", m.MemoryRemainingBytes)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("migrated to %s\n", migration.DestCompute)
+*/
+package migrations
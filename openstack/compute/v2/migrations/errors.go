@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrMigrationFailed is returned by WaitForLiveMigration when the migration
+// ends in the error, failed or cancelled status.
+type ErrMigrationFailed struct {
+	gophercloud.BaseError
+
+	Migration Migration
+}
+
+func (e ErrMigrationFailed) Error() string {
+	return fmt.Sprintf("migration %d of server %s ended with status %s", e.Migration.ID, e.Migration.InstanceUUID, e.Migration.Status)
+}
+
+// ErrMigrationNotFound is returned by WaitForLiveMigration when the server
+// has no live migration with the requested ID, or no live migration at all.
+type ErrMigrationNotFound struct {
+	gophercloud.BaseError
+
+	ServerID string
+
+	// MigrationID is the requested migration, or 0 for any.
+	MigrationID int
+}
+
+func (e ErrMigrationNotFound) Error() string {
+	if e.MigrationID == 0 {
+		return fmt.Sprintf("server %s has no live migration", e.ServerID)
+	}
+	return fmt.Sprintf("server %s has no live migration %d", e.ServerID, e.MigrationID)
+}
@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// MigrationType is the type of a migration.
+type MigrationType string
+
+const (
+	TypeLiveMigration MigrationType = "live-migration"
+	TypeMigration     MigrationType = "migration"
+	TypeResize        MigrationType = "resize"
+	TypeEvacuation    MigrationType = "evacuation"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToMigrationListQuery() (string, error)
+}
+
+// ListOpts represents options used to filter the migrations returned by
+// List.
+type ListOpts struct {
+	// Hidden filters the migrations by their hidden flag.
+	Hidden *bool `q:"hidden"`
+
+	// Host filters the migrations by source or destination compute host.
+	Host string `q:"host"`
+
+	// InstanceUUID filters the migrations of a server.
+	InstanceUUID string `q:"instance_uuid"`
+
+	// MigrationType filters the migrations by type.
+	MigrationType MigrationType `q:"migration_type"`
+
+	// SourceCompute filters the migrations by source compute host.
+	SourceCompute string `q:"source_compute"`
+
+	// Status filters the migrations by status.
+	Status string `q:"status"`
+
+	// Limit and Marker control paging.
+	// This requires microversion 2.59 or later.
+	Limit  int    `q:"limit"`
+	Marker string `q:"marker"`
+
+	// ChangesSince filters the migrations updated after the given time.
+	// This requires microversion 2.59 or later.
+	ChangesSince *time.Time `q:"changes-since"`
+
+	// ChangesBefore filters the migrations updated before the given time.
+	// This requires microversion 2.66 or later.
+	ChangesBefore *time.Time `q:"changes-before"`
+
+	// UserID and ProjectID filter the migrations by owner of the server.
+	// This requires microversion 2.80 or later.
+	UserID    string `q:"user_id"`
+	ProjectID string `q:"project_id"`
+}
+
+// ToMigrationListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToMigrationListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+
+	params := q.Query()
+
+	if opts.ChangesSince != nil {
+		params.Add("changes-since", opts.ChangesSince.Format(time.RFC3339))
+	}
+
+	if opts.ChangesBefore != nil {
+		params.Add("changes-before", opts.ChangesBefore.Format(time.RFC3339))
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+	return q.String(), nil
+}
+
+// List returns the migrations of the cloud. It requires administrative
+// privileges.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToMigrationListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return MigrationPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListServerMigrations returns the in-progress live migrations of a server.
+// This requires microversion 2.23 or later.
+func ListServerMigrations(client *gophercloud.ServiceClient, serverID string) pagination.Pager {
+	return pagination.NewPager(client, serverMigrationsURL(client, serverID), func(r pagination.PageResult) pagination.Page {
+		return ServerMigrationPage{pagination.SinglePageBase(r)}
+	})
+}
+
+// GetServerMigration returns an in-progress live migration of a server.
+// This requires microversion 2.23 or later.
+func GetServerMigration(ctx context.Context, client *gophercloud.ServiceClient, serverID string, id int) (r GetServerMigrationResult) {
+	resp, err := client.Get(ctx, serverMigrationURL(client, serverID, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ForceComplete forces an in-progress live migration of a server to
+// complete, by pausing the server or switching to post-copy, depending on the
+// configuration of the compute host.
+// This requires microversion 2.22 or later.
+func ForceComplete(ctx context.Context, client *gophercloud.ServiceClient, serverID string, id int) (r ForceCompleteResult) {
+	resp, err := client.Post(ctx, actionURL(client, serverID, id), map[string]any{"force_complete": nil}, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Abort aborts an in-progress live migration of a server.
+// This requires microversion 2.24 or later. Migrations in the queued or
+// preparing status can be aborted since microversion 2.65.
+func Abort(ctx context.Context, client *gophercloud.ServiceClient, serverID string, id int) (r AbortResult) {
+	resp, err := client.Delete(ctx, serverMigrationURL(client, serverID, id), &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
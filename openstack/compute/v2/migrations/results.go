@@ -0,0 +1,230 @@
+package migrations
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// Migration represents a migration, as listed by List.
+type Migration struct {
+	// ID is the ID of the migration.
+	ID int `json:"id"`
+
+	// UUID is the UUID of the migration.
+	// This requires microversion 2.59 or later.
+	UUID string `json:"uuid"`
+
+	// InstanceUUID is the ID of the migrated server.
+	InstanceUUID string `json:"instance_uuid"`
+
+	// Status is the status of the migration, for example "queued",
+	// "running", "completed", "error" or "cancelled".
+	Status string `json:"status"`
+
+	// MigrationType is the type of the migration.
+	// This requires microversion 2.23 or later.
+	MigrationType MigrationType `json:"migration_type"`
+
+	// SourceCompute and SourceNode are the source compute host and node.
+	SourceCompute string `json:"source_compute"`
+	SourceNode    string `json:"source_node"`
+
+	// DestCompute, DestNode and DestHost are the destination compute host,
+	// node and IP address.
+	DestCompute string `json:"dest_compute"`
+	DestNode    string `json:"dest_node"`
+	DestHost    string `json:"dest_host"`
+
+	// OldInstanceTypeID and NewInstanceTypeID are the flavors of the server
+	// before and after the migration.
+	OldInstanceTypeID int `json:"old_instance_type_id"`
+	NewInstanceTypeID int `json:"new_instance_type_id"`
+
+	// UserID and ProjectID are the owner of the server.
+	// This requires microversion 2.80 or later.
+	UserID    string `json:"user_id"`
+	ProjectID string `json:"project_id"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// UnmarshalJSON converts our JSON API response into our migration struct.
+func (r *Migration) UnmarshalJSON(b []byte) error {
+	type tmp Migration
+	var s struct {
+		tmp
+		CreatedAt gophercloud.JSONRFC3339MilliNoZ `json:"created_at"`
+		UpdatedAt gophercloud.JSONRFC3339MilliNoZ `json:"updated_at"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = Migration(s.tmp)
+
+	r.CreatedAt = time.Time(s.CreatedAt)
+	r.UpdatedAt = time.Time(s.UpdatedAt)
+
+	return nil
+}
+
+// MigrationPage contains a page of migrations from a List call.
+type MigrationPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines if a MigrationPage contains any results.
+func (page MigrationPage) IsEmpty() (bool, error) {
+	if page.StatusCode == 204 {
+		return true, nil
+	}
+
+	migrations, err := ExtractMigrations(page)
+	return len(migrations) == 0, err
+}
+
+// NextPageURL uses the response's embedded link reference to navigate to the
+// next page of results. Links are returned since microversion 2.59.
+func (page MigrationPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"migrations_links"`
+	}
+	err := page.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// ExtractMigrations interprets a page of results as a slice of Migration.
+func ExtractMigrations(r pagination.Page) ([]Migration, error) {
+	var s struct {
+		Migrations []Migration `json:"migrations"`
+	}
+	err := (r.(MigrationPage)).ExtractInto(&s)
+	return s.Migrations, err
+}
+
+// ServerMigration represents an in-progress live migration of a server.
+type ServerMigration struct {
+	// ID is the ID of the migration.
+	ID int `json:"id"`
+
+	// UUID is the UUID of the migration.
+	// This requires microversion 2.59 or later.
+	UUID string `json:"uuid"`
+
+	// ServerUUID is the ID of the migrated server.
+	ServerUUID string `json:"server_uuid"`
+
+	// Status is the status of the migration.
+	Status string `json:"status"`
+
+	// SourceCompute and SourceNode are the source compute host and node.
+	SourceCompute string `json:"source_compute"`
+	SourceNode    string `json:"source_node"`
+
+	// DestCompute, DestNode and DestHost are the destination compute host,
+	// node and IP address.
+	DestCompute string `json:"dest_compute"`
+	DestNode    string `json:"dest_node"`
+	DestHost    string `json:"dest_host"`
+
+	// MemoryTotalBytes, MemoryProcessedBytes and MemoryRemainingBytes are
+	// the progress of the copy of the memory of the server. They are zero
+	// until the hypervisor reports them.
+	MemoryTotalBytes     int64 `json:"memory_total_bytes"`
+	MemoryProcessedBytes int64 `json:"memory_processed_bytes"`
+	MemoryRemainingBytes int64 `json:"memory_remaining_bytes"`
+
+	// DiskTotalBytes, DiskProcessedBytes and DiskRemainingBytes are the
+	// progress of the copy of the local disks, for block migrations.
+	DiskTotalBytes     int64 `json:"disk_total_bytes"`
+	DiskProcessedBytes int64 `json:"disk_processed_bytes"`
+	DiskRemainingBytes int64 `json:"disk_remaining_bytes"`
+
+	// UserID and ProjectID are the owner of the server.
+	// This requires microversion 2.80 or later.
+	UserID    string `json:"user_id"`
+	ProjectID string `json:"project_id"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// UnmarshalJSON converts our JSON API response into our server migration
+// struct.
+func (r *ServerMigration) UnmarshalJSON(b []byte) error {
+	type tmp ServerMigration
+	var s struct {
+		tmp
+		CreatedAt gophercloud.JSONRFC3339MilliNoZ `json:"created_at"`
+		UpdatedAt gophercloud.JSONRFC3339MilliNoZ `json:"updated_at"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = ServerMigration(s.tmp)
+
+	r.CreatedAt = time.Time(s.CreatedAt)
+	r.UpdatedAt = time.Time(s.UpdatedAt)
+
+	return nil
+}
+
+// ServerMigrationPage contains the in-progress migrations of a server.
+type ServerMigrationPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty determines if a ServerMigrationPage contains any results.
+func (page ServerMigrationPage) IsEmpty() (bool, error) {
+	if page.StatusCode == 204 {
+		return true, nil
+	}
+
+	migrations, err := ExtractServerMigrations(page)
+	return len(migrations) == 0, err
+}
+
+// ExtractServerMigrations interprets a page of results as a slice of
+// ServerMigration.
+func ExtractServerMigrations(r pagination.Page) ([]ServerMigration, error) {
+	var s struct {
+		Migrations []ServerMigration `json:"migrations"`
+	}
+	err := (r.(ServerMigrationPage)).ExtractInto(&s)
+	return s.Migrations, err
+}
+
+// GetServerMigrationResult is the response of a GetServerMigration
+// operation. Call its Extract method to interpret it as a ServerMigration.
+type GetServerMigrationResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetServerMigrationResult as a ServerMigration.
+func (r GetServerMigrationResult) Extract() (*ServerMigration, error) {
+	var s struct {
+		Migration *ServerMigration `json:"migration"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Migration, err
+}
+
+// ForceCompleteResult is the response of a ForceComplete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type ForceCompleteResult struct {
+	gophercloud.ErrResult
+}
+
+// AbortResult is the response of an Abort operation. Call its ExtractErr
+// method to determine if the request succeeded or failed.
+type AbortResult struct {
+	gophercloud.ErrResult
+}
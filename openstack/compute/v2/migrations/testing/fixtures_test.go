@@ -0,0 +1,293 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/migrations"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+const ServerID = "f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f"
+
+// ListOutput is a sample response to a List call.
+const ListOutput = `
+{
+	"migrations": [
+		{
+			"id": 1234,
+			"uuid": "42341d4b-346a-40d0-83c6-5f4f6892b650",
+			"instance_uuid": "f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f",
+			"status": "%s",
+			"migration_type": "live-migration",
+			"source_compute": "compute-1",
+			"source_node": "compute-1.example.com",
+			"dest_compute": "compute-2",
+			"dest_node": "compute-2.example.com",
+			"dest_host": "192.168.0.12",
+			"old_instance_type_id": 1,
+			"new_instance_type_id": 1,
+			"created_at": "2024-10-17T10:12:14.000000",
+			"updated_at": "2024-10-17T10:14:02.000000",
+			"user_id": "ef9d34b4-45d0-4530-871b-3fb535988394",
+			"project_id": "011ee9f4-8f16-4c38-8633-a254d420fd54",
+			"links": [
+				{"href": "http://openstack.example.com/v2.1/servers/f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f/migrations/1234", "rel": "self"}
+			]
+		},
+		{
+			"id": 1200,
+			"uuid": "12341d4b-346a-40d0-83c6-5f4f6892b650",
+			"instance_uuid": "f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f",
+			"status": "completed",
+			"migration_type": "live-migration",
+			"source_compute": "compute-3",
+			"source_node": "compute-3.example.com",
+			"dest_compute": "compute-1",
+			"dest_node": "compute-1.example.com",
+			"dest_host": "192.168.0.11",
+			"old_instance_type_id": 1,
+			"new_instance_type_id": 1,
+			"created_at": "2024-10-10T10:12:14.000000",
+			"updated_at": "2024-10-10T10:14:02.000000",
+			"user_id": "ef9d34b4-45d0-4530-871b-3fb535988394",
+			"project_id": "011ee9f4-8f16-4c38-8633-a254d420fd54"
+		}
+	],
+	"migrations_links": [
+		{"href": "%s/os-migrations?limit=2&marker=12341d4b-346a-40d0-83c6-5f4f6892b650", "rel": "next"}
+	]
+}
+`
+
+// ServerMigrationOutput is a sample in-progress live migration.
+const ServerMigrationOutput = `
+{
+	"id": 1234,
+	"uuid": "42341d4b-346a-40d0-83c6-5f4f6892b650",
+	"server_uuid": "f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f",
+	"status": "running",
+	"source_compute": "compute-1",
+	"source_node": "compute-1.example.com",
+	"dest_compute": "compute-2",
+	"dest_node": "compute-2.example.com",
+	"dest_host": "192.168.0.12",
+	"memory_total_bytes": 4294967296,
+	"memory_processed_bytes": %d,
+	"memory_remaining_bytes": %d,
+	"disk_total_bytes": 0,
+	"disk_processed_bytes": 0,
+	"disk_remaining_bytes": 0,
+	"created_at": "2024-10-17T10:12:14.000000",
+	"updated_at": "2024-10-17T10:13:02.000000",
+	"user_id": "ef9d34b4-45d0-4530-871b-3fb535988394",
+	"project_id": "011ee9f4-8f16-4c38-8633-a254d420fd54"
+}
+`
+
+// ExpectedMigration is the migration of ListOutput, completed.
+var ExpectedMigration = migrations.Migration{
+	ID:                1234,
+	UUID:              "42341d4b-346a-40d0-83c6-5f4f6892b650",
+	InstanceUUID:      ServerID,
+	Status:            "completed",
+	MigrationType:     migrations.TypeLiveMigration,
+	SourceCompute:     "compute-1",
+	SourceNode:        "compute-1.example.com",
+	DestCompute:       "compute-2",
+	DestNode:          "compute-2.example.com",
+	DestHost:          "192.168.0.12",
+	OldInstanceTypeID: 1,
+	NewInstanceTypeID: 1,
+	UserID:            "ef9d34b4-45d0-4530-871b-3fb535988394",
+	ProjectID:         "011ee9f4-8f16-4c38-8633-a254d420fd54",
+	CreatedAt:         time.Date(2024, 10, 17, 10, 12, 14, 0, time.UTC),
+	UpdatedAt:         time.Date(2024, 10, 17, 10, 14, 2, 0, time.UTC),
+}
+
+// ExpectedServerMigration is the migration of ServerMigrationOutput, half
+// way through.
+var ExpectedServerMigration = migrations.ServerMigration{
+	ID:                   1234,
+	UUID:                 "42341d4b-346a-40d0-83c6-5f4f6892b650",
+	ServerUUID:           ServerID,
+	Status:               "running",
+	SourceCompute:        "compute-1",
+	SourceNode:           "compute-1.example.com",
+	DestCompute:          "compute-2",
+	DestNode:             "compute-2.example.com",
+	DestHost:             "192.168.0.12",
+	MemoryTotalBytes:     4294967296,
+	MemoryProcessedBytes: 2147483648,
+	MemoryRemainingBytes: 2147483648,
+	UserID:               "ef9d34b4-45d0-4530-871b-3fb535988394",
+	ProjectID:            "011ee9f4-8f16-4c38-8633-a254d420fd54",
+	CreatedAt:            time.Date(2024, 10, 17, 10, 12, 14, 0, time.UTC),
+	UpdatedAt:            time.Date(2024, 10, 17, 10, 13, 2, 0, time.UTC),
+}
+
+// HandleListSuccessfully sets up the test server to respond to a List
+// request, in two pages.
+func HandleListSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/os-migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Query().Get("marker") {
+		case "":
+			th.TestFormValues(t, r, map[string]string{
+				"host":           "compute-1",
+				"migration_type": "live-migration",
+				"limit":          "2",
+				"changes-since":  "2024-10-01T00:00:00Z",
+			})
+			fmt.Fprintf(w, ListOutput, "completed", th.Server.URL)
+		case "12341d4b-346a-40d0-83c6-5f4f6892b650":
+			fmt.Fprint(w, `{"migrations": [], "migrations_links": []}`)
+		default:
+			t.Errorf("unexpected marker %s", r.URL.Query().Get("marker"))
+		}
+	})
+}
+
+// HandleServerMigrationSuccessfully sets up the test server to respond to
+// the requests about a single server migration.
+func HandleServerMigrationSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/"+ServerID+"/migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"migrations": [`+ServerMigrationOutput+`]}`, 2147483648, 2147483648)
+	})
+
+	th.Mux.HandleFunc("/servers/"+ServerID+"/migrations/1234", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"migration": `+ServerMigrationOutput+`}`, 2147483648, 2147483648)
+		case "DELETE":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/servers/"+ServerID+"/migrations/1234/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, `{"force_complete": null}`)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// HandleLiveMigration sets up the test server to report a live migration
+// in progress for the given number of polls, then ended with the given
+// status.
+func HandleLiveMigration(t *testing.T, polls int, status string) {
+	var mu sync.Mutex
+	processed := int64(0)
+
+	th.Mux.HandleFunc("/servers/"+ServerID+"/migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		if polls == 0 {
+			fmt.Fprint(w, `{"migrations": []}`)
+			return
+		}
+		polls--
+		processed += 1073741824
+		fmt.Fprintf(w, `{"migrations": [`+ServerMigrationOutput+`]}`, processed, 4294967296-processed)
+	})
+
+	th.Mux.HandleFunc("/os-migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		if r.URL.Query().Get("marker") != "" {
+			fmt.Fprint(w, `{"migrations": [], "migrations_links": []}`)
+			return
+		}
+		th.TestFormValues(t, r, map[string]string{"instance_uuid": ServerID, "migration_type": "live-migration"})
+		if polls > 0 {
+			fmt.Fprintf(w, ListOutput, "running", th.Server.URL)
+			return
+		}
+		fmt.Fprintf(w, ListOutput, status, th.Server.URL)
+	})
+}
+
+// PreviousMigrationOutput lists the live migration of the server which
+// ended before the one of ListOutput was requested.
+const PreviousMigrationOutput = `
+{
+	"migrations": [
+		{
+			"id": 1200,
+			"uuid": "12341d4b-346a-40d0-83c6-5f4f6892b650",
+			"instance_uuid": "f6e7b4b4-0ee6-4d3b-bc5c-7c0d1d3d8f0f",
+			"status": "completed",
+			"migration_type": "live-migration",
+			"source_compute": "compute-3",
+			"dest_compute": "compute-1"
+		}
+	],
+	"migrations_links": []
+}
+`
+
+// HandleNextLiveMigration sets up the test server for a server which was
+// live migrated before: the new live migration is not recorded for the
+// first polls, then is in progress once, then is completed.
+func HandleNextLiveMigration(t *testing.T) {
+	var mu sync.Mutex
+	serverPolls := 0
+
+	th.Mux.HandleFunc("/servers/"+ServerID+"/migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		serverPolls++
+		w.Header().Add("Content-Type", "application/json")
+		if serverPolls == 3 {
+			fmt.Fprintf(w, `{"migrations": [`+ServerMigrationOutput+`]}`, 2147483648, 2147483648)
+			return
+		}
+		fmt.Fprint(w, `{"migrations": []}`)
+	})
+
+	th.Mux.HandleFunc("/os-migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		if r.URL.Query().Get("marker") != "" {
+			fmt.Fprint(w, `{"migrations": [], "migrations_links": []}`)
+			return
+		}
+		if serverPolls < 3 {
+			fmt.Fprint(w, PreviousMigrationOutput)
+			return
+		}
+		fmt.Fprintf(w, ListOutput, "completed", th.Server.URL)
+	})
+}
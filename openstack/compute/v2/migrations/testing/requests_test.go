@@ -0,0 +1,149 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/migrations"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListSuccessfully(t)
+
+	changesSince := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	opts := migrations.ListOpts{
+		Host:          "compute-1",
+		MigrationType: migrations.TypeLiveMigration,
+		Limit:         2,
+		ChangesSince:  &changesSince,
+	}
+
+	pages := 0
+	err := migrations.List(client.ServiceClient(), opts).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		pages++
+		actual, err := migrations.ExtractMigrations(page)
+		th.AssertNoErr(t, err)
+
+		if pages == 1 {
+			th.AssertEquals(t, 2, len(actual))
+			th.CheckDeepEquals(t, ExpectedMigration, actual[0])
+			th.CheckEquals(t, 1200, actual[1].ID)
+		} else {
+			th.AssertEquals(t, 0, len(actual))
+		}
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, pages)
+}
+
+func TestListServerMigrations(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	allPages, err := migrations.ListServerMigrations(client.ServiceClient(), ServerID).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := migrations.ExtractServerMigrations(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []migrations.ServerMigration{ExpectedServerMigration}, actual)
+}
+
+func TestGetServerMigration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	actual, err := migrations.GetServerMigration(context.TODO(), client.ServiceClient(), ServerID, 1234).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedServerMigration, *actual)
+}
+
+func TestForceComplete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	err := migrations.ForceComplete(context.TODO(), client.ServiceClient(), ServerID, 1234).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestAbort(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	err := migrations.Abort(context.TODO(), client.ServiceClient(), ServerID, 1234).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitForLiveMigration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleLiveMigration(t, 3, "completed")
+
+	var remaining []int64
+	actual, err := migrations.WaitForLiveMigration(context.TODO(), client.ServiceClient(), ServerID, migrations.WaitOpts{
+		OnProgress: func(m migrations.ServerMigration) {
+			remaining = append(remaining, m.MemoryRemainingBytes)
+		},
+		Interval: time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedMigration, *actual)
+	th.CheckDeepEquals(t, []int64{3221225472, 2147483648, 1073741824}, remaining)
+}
+
+func TestWaitForNextLiveMigration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNextLiveMigration(t)
+
+	polled := 0
+	actual, err := migrations.WaitForLiveMigration(context.TODO(), client.ServiceClient(), ServerID, migrations.WaitOpts{
+		OnProgress: func(migrations.ServerMigration) {
+			polled++
+		},
+		Interval: time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1234, actual.ID)
+	th.CheckEquals(t, "completed", actual.Status)
+	th.CheckEquals(t, 1, polled)
+}
+
+func TestWaitForLiveMigrationFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleLiveMigration(t, 1, "error")
+
+	_, err := migrations.WaitForLiveMigration(context.TODO(), client.ServiceClient(), ServerID, migrations.WaitOpts{
+		MigrationID: 1234,
+		Interval:    time.Millisecond,
+	})
+	var failed migrations.ErrMigrationFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.CheckEquals(t, 1234, failed.Migration.ID)
+	th.CheckEquals(t, "error", failed.Migration.Status)
+}
+
+func TestWaitForLiveMigrationNotFound(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleLiveMigration(t, 0, "completed")
+
+	_, err := migrations.WaitForLiveMigration(context.TODO(), client.ServiceClient(), ServerID, migrations.WaitOpts{
+		MigrationID: 4321,
+		Interval:    time.Millisecond,
+	})
+	var notFound migrations.ErrMigrationNotFound
+	th.AssertEquals(t, true, errors.As(err, &notFound))
+	th.CheckEquals(t, 4321, notFound.MigrationID)
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"strconv"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+func listURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL("os-migrations")
+}
+
+func serverMigrationsURL(client *gophercloud.ServiceClient, serverID string) string {
+	return client.ServiceURL("servers", serverID, "migrations")
+}
+
+func serverMigrationURL(client *gophercloud.ServiceClient, serverID string, id int) string {
+	return client.ServiceURL("servers", serverID, "migrations", strconv.Itoa(id))
+}
+
+func actionURL(client *gophercloud.ServiceClient, serverID string, id int) string {
+	return client.ServiceURL("servers", serverID, "migrations", strconv.Itoa(id), "action")
+}
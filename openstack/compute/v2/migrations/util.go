@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// WaitOpts configures WaitForLiveMigration.
+type WaitOpts struct {
+	// MigrationID is the ID of the migration to wait for. By default, the
+	// live migration started after the last one which already ended when
+	// WaitForLiveMigration is called is waited for.
+	MigrationID int
+
+	// OnProgress, if set, is called with the migration every time it is
+	// polled while in progress.
+	OnProgress func(ServerMigration)
+
+	// Interval is the time between two polls. Defaults to 1 second.
+	Interval time.Duration
+}
+
+// WaitForLiveMigration polls a live migration of a server until it ends, and
+// returns it. It returns an ErrMigrationFailed if the migration did not
+// complete, and an ErrMigrationNotFound if the server has no migration with
+// the requested MigrationID.
+//
+// Nova records a requested live migration asynchronously. Without a
+// MigrationID, the live migrations which already ended when
+// WaitForLiveMigration is called are ignored, and the server is polled until
+// a new one appears, so that it must be called right after the migration was
+// requested and with a deadline on ctx. A new migration which ended before
+// that is missed.
+//
+// The in-progress migrations are polled with ListServerMigrations, which
+// requires microversion 2.23 or later, and the final status is read with
+// List, which requires administrative privileges.
+func WaitForLiveMigration(ctx context.Context, client *gophercloud.ServiceClient, serverID string, opts WaitOpts) (*Migration, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	// When waiting for the next migration, only migrations with an ID
+	// greater than the last ended one are considered.
+	id, after := opts.MigrationID, 0
+	if id == 0 {
+		last, err := liveMigration(ctx, client, serverID, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil && ended(last.Status) {
+			after = last.ID
+		}
+	}
+
+	for {
+		allPages, err := ListServerMigrations(client, serverID).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		inProgress, err := ExtractServerMigrations(allPages)
+		if err != nil {
+			return nil, err
+		}
+
+		running := slices.IndexFunc(inProgress, func(m ServerMigration) bool {
+			return m.ID == id || (id == 0 && m.ID > after)
+		})
+		if running >= 0 {
+			id = inProgress[running].ID
+			if opts.OnProgress != nil {
+				opts.OnProgress(inProgress[running])
+			}
+		} else {
+			migration, err := liveMigration(ctx, client, serverID, id, after)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case migration == nil && opts.MigrationID != 0:
+				return nil, ErrMigrationNotFound{ServerID: serverID, MigrationID: opts.MigrationID}
+			case migration == nil:
+				// The migration was not recorded yet.
+			case migration.Status == "completed" || migration.Status == "done":
+				return migration, nil
+			case ended(migration.Status):
+				return migration, ErrMigrationFailed{Migration: *migration}
+			default:
+				id = migration.ID
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// ended tells whether a migration status is final.
+func ended(status string) bool {
+	switch status {
+	case "completed", "done", "error", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+// liveMigration returns the live migration of a server with the given ID or,
+// if id is 0, the last one with an ID greater than after.
+func liveMigration(ctx context.Context, client *gophercloud.ServiceClient, serverID string, id, after int) (*Migration, error) {
+	allPages, err := List(client, ListOpts{InstanceUUID: serverID, MigrationType: TypeLiveMigration}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := ExtractMigrations(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Migration
+	for i, m := range all {
+		if (id != 0 && m.ID == id) || (id == 0 && m.ID > after && (found == nil || m.ID > found.ID)) {
+			found = &all[i]
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,48 @@
+/*
+Package provision boots a server along with the resources it needs: an
+imported key pair, ports with their security groups, a boot volume created
+from an image, and floating IPs.
+
+The resources are described by a single Spec. Provision creates them in
+order and tracks every one of them; if a step fails, or if the context
+expires, they are deleted in reverse order. The returned Report lists the
+steps, including the rollback, so that they can be shown to the user.
+
+Example to Provision a Server
+
+	report, err := provision.Provision(context.TODO(), provision.Clients{
+		Compute:      computeClient,
+		Network:      networkClient,
+		BlockStorage: blockStorageClient,
+	}, provision.Spec{
+		Name:      "web-1",
+		FlavorRef: "1",
+		ImageRef:  "f90f6034-2570-4974-8351-6b49732ef2eb",
+		Ports: []provision.PortSpec{
+			{
+				NetworkID:         "a87cc70a-3e15-4acf-8205-9b711a3531b7",
+				SubnetID:          "a0304c3a-4f08-4c43-88af-d796509c97d2",
+				SecurityGroups:    []string{"f0ac4394-7e4a-4409-9701-ba8be283dbc3"},
+				FloatingNetworkID: "376da547-b977-4cfe-9cba-275c80debf57",
+			},
+		},
+		BootVolume: &provision.VolumeSpec{
+			Size:                20,
+			DeleteOnTermination: true,
+		},
+		KeyPair: &provision.KeyPairSpec{
+			Name: "my-key",
+		},
+	})
+
+	for _, step := range report.Steps {
+		fmt.Printf("%s %s %s: %v\n", step.Action, step.Resource.Type, step.Resource.ID, step.Err)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s is active\n", report.Server.ID)
+*/
+package provision
@@ -0,0 +1,48 @@
+package provision
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrProvisionFailed is returned by Provision when a step failed. The created
+// resources have been deleted, except for the Leaked ones.
+type ErrProvisionFailed struct {
+	gophercloud.BaseError
+
+	// Step is the action that failed.
+	Step Action
+
+	// Err is the error the step failed with.
+	Err error
+
+	// Leaked are the resources the rollback could not delete.
+	Leaked []Resource
+}
+
+func (e ErrProvisionFailed) Error() string {
+	msg := fmt.Sprintf("provisioning failed at step %s: %v", e.Step, e.Err)
+	if len(e.Leaked) > 0 {
+		msg += fmt.Sprintf("; %d resources could not be deleted", len(e.Leaked))
+	}
+	return msg
+}
+
+func (e ErrProvisionFailed) Unwrap() error {
+	return e.Err
+}
+
+// ErrResourceFailed is returned when the boot volume goes to an error status
+// while Provision waits for it. Servers going to ERROR are reported with a
+// servers.ErrServerFailed.
+type ErrResourceFailed struct {
+	gophercloud.BaseError
+
+	Resource Resource
+	Status   string
+}
+
+func (e ErrResourceFailed) Error() string {
+	return fmt.Sprintf("%s %s is in status %s", e.Resource.Type, e.Resource.ID, e.Status)
+}
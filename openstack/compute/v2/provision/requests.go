@@ -0,0 +1,540 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/keypairs"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+)
+
+// DefaultRollbackTimeout is the time given to the rollback when
+// Spec.RollbackTimeout is not set.
+const DefaultRollbackTimeout = 5 * time.Minute
+
+// Clients are the service clients used to provision a server.
+type Clients struct {
+	// Compute is used for key pairs and servers.
+	Compute *gophercloud.ServiceClient
+
+	// Network is used for ports and floating IPs.
+	Network *gophercloud.ServiceClient
+
+	// BlockStorage is used for the boot volume. It is only required if
+	// Spec.BootVolume is set.
+	BlockStorage *gophercloud.ServiceClient
+}
+
+// Spec describes a server and the resources it needs.
+type Spec struct {
+	// Name is the name of the server. The ports and the boot volume are named
+	// after it.
+	Name string
+
+	// FlavorRef is the ID of the flavor of the server.
+	FlavorRef string
+
+	// ImageRef is the ID of the image the server, or its boot volume if
+	// BootVolume is set, is created from.
+	ImageRef string
+
+	// AvailabilityZone is the availability zone of the server, and of its
+	// boot volume unless BootVolume.AvailabilityZone is set.
+	AvailabilityZone string
+
+	// UserData is passed as is to servers.CreateOpts.
+	UserData []byte
+
+	// Metadata is the metadata of the server.
+	Metadata map[string]string
+
+	// Tags are the tags of the server. They require microversion 2.52 or
+	// later.
+	Tags []string
+
+	// ConfigDrive enables the configuration drive of the server.
+	ConfigDrive *bool
+
+	// SchedulerHints are passed as is to servers.Create.
+	SchedulerHints servers.SchedulerHintOptsBuilder
+
+	// Ports are created, in order, and attached to the server. At least one
+	// port is required.
+	Ports []PortSpec
+
+	// BootVolume, if set, makes the server boot from a volume created from
+	// ImageRef.
+	BootVolume *VolumeSpec
+
+	// KeyPair, if set, is injected in the server.
+	KeyPair *KeyPairSpec
+
+	// RollbackTimeout bounds the time spent deleting the created resources
+	// after a failure. The rollback does not use the context of Provision,
+	// which may have expired already. Defaults to DefaultRollbackTimeout.
+	RollbackTimeout time.Duration
+}
+
+// PortSpec describes a port of the server.
+type PortSpec struct {
+	// NetworkID is the network of the port.
+	NetworkID string
+
+	// SubnetID is the subnet the fixed IP of the port is allocated from.
+	SubnetID string
+
+	// FixedIP is the fixed IP of the port.
+	FixedIP string
+
+	// SecurityGroups are the IDs of the security groups of the port. If nil,
+	// the default security group of the project is applied.
+	SecurityGroups []string
+
+	// FloatingNetworkID, if set, is the external network a floating IP is
+	// allocated from, and associated with the port once the server is
+	// active.
+	FloatingNetworkID string
+}
+
+// VolumeSpec describes the boot volume of the server.
+type VolumeSpec struct {
+	// Size is the size of the volume in GB.
+	Size int
+
+	// VolumeType is the type of the volume.
+	VolumeType string
+
+	// AvailabilityZone is the availability zone of the volume. Defaults to
+	// Spec.AvailabilityZone.
+	AvailabilityZone string
+
+	// DeleteOnTermination deletes the volume along with the server.
+	DeleteOnTermination bool
+}
+
+// KeyPairSpec describes the key pair injected in the server.
+type KeyPairSpec struct {
+	// Name is the name of the key pair.
+	Name string
+
+	// PublicKey, if set, is imported as a new key pair called Name, which is
+	// deleted on rollback. Otherwise, the existing key pair called Name is
+	// used.
+	PublicKey string
+}
+
+// validate checks that the spec is complete.
+func (spec Spec) validate(clients Clients) error {
+	if spec.Name == "" {
+		return gophercloud.ErrMissingInput{Argument: "Name"}
+	}
+	if spec.FlavorRef == "" {
+		return gophercloud.ErrMissingInput{Argument: "FlavorRef"}
+	}
+	if spec.ImageRef == "" {
+		return gophercloud.ErrMissingInput{Argument: "ImageRef"}
+	}
+	if len(spec.Ports) == 0 {
+		return gophercloud.ErrMissingInput{Argument: "Ports"}
+	}
+	for i, port := range spec.Ports {
+		if port.NetworkID == "" {
+			return gophercloud.ErrMissingInput{Argument: fmt.Sprintf("Ports[%d].NetworkID", i)}
+		}
+	}
+	if spec.BootVolume != nil {
+		if spec.BootVolume.Size <= 0 {
+			return gophercloud.ErrMissingInput{Argument: "BootVolume.Size"}
+		}
+		if clients.BlockStorage == nil {
+			return gophercloud.ErrMissingInput{Argument: "BlockStorage"}
+		}
+	}
+	if spec.KeyPair != nil && spec.KeyPair.Name == "" {
+		return gophercloud.ErrMissingInput{Argument: "KeyPair.Name"}
+	}
+	if clients.Compute == nil {
+		return gophercloud.ErrMissingInput{Argument: "Compute"}
+	}
+	if clients.Network == nil {
+		return gophercloud.ErrMissingInput{Argument: "Network"}
+	}
+	return nil
+}
+
+// Provision creates the key pair, the ports, the boot volume, the server and
+// the floating IPs described by the spec, and waits for the server to be
+// active.
+//
+// Every created resource is recorded. If a step fails, or if the context
+// expires, the created resources are deleted in reverse order and an
+// ErrProvisionFailed is returned. The report lists every step, including the
+// rollback, and is returned in all cases.
+func Provision(ctx context.Context, clients Clients, spec Spec) (*Report, error) {
+	report := new(Report)
+	if err := spec.validate(clients); err != nil {
+		return report, err
+	}
+
+	p := &provisioner{clients: clients, spec: spec, report: report}
+	step, err := p.provision(ctx)
+	if err == nil {
+		return report, nil
+	}
+
+	timeout := spec.RollbackTimeout
+	if timeout == 0 {
+		timeout = DefaultRollbackTimeout
+	}
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	p.rollback(rollbackCtx)
+
+	return report, ErrProvisionFailed{
+		Step:   step,
+		Err:    err,
+		Leaked: report.Leaked,
+	}
+}
+
+// undo deletes a created resource.
+type undo struct {
+	resource Resource
+	delete   func(context.Context) error
+}
+
+type provisioner struct {
+	clients Clients
+	spec    Spec
+	report  *Report
+	undos   []undo
+}
+
+// run runs a step and records it in the report.
+func (p *provisioner) run(ctx context.Context, action Action, resource Resource, fn func(context.Context) (Resource, error)) error {
+	step := Step{
+		Action:    action,
+		Resource:  resource,
+		StartedAt: time.Now(),
+	}
+	created, err := fn(ctx)
+	if created.ID != "" {
+		step.Resource = created
+	}
+	step.FinishedAt = time.Now()
+	step.Err = err
+	p.report.Steps = append(p.report.Steps, step)
+	return err
+}
+
+// track records a created resource, to be deleted on rollback.
+func (p *provisioner) track(resource Resource, delete func(context.Context) error) {
+	p.undos = append(p.undos, undo{resource: resource, delete: delete})
+}
+
+// provision runs the steps in order. It returns the action that failed.
+func (p *provisioner) provision(ctx context.Context) (Action, error) {
+	steps := []struct {
+		action Action
+		fn     func(context.Context) error
+	}{
+		{ActionCreateKeyPair, p.createKeyPair},
+		{ActionCreatePort, p.createPorts},
+		{ActionCreateVolume, p.createVolume},
+		{ActionCreateServer, p.createServer},
+		{ActionCreateFloatingIP, p.createFloatingIPs},
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return step.action, err
+		}
+		if err := step.fn(ctx); err != nil {
+			action := step.action
+			if n := len(p.report.Steps); n > 0 {
+				action = p.report.Steps[n-1].Action
+			}
+			return action, err
+		}
+	}
+	return "", nil
+}
+
+func (p *provisioner) createKeyPair(ctx context.Context) error {
+	kp := p.spec.KeyPair
+	if kp == nil || kp.PublicKey == "" {
+		return nil
+	}
+
+	resource := Resource{Type: ResourceKeyPair, Name: kp.Name}
+	return p.run(ctx, ActionCreateKeyPair, resource, func(ctx context.Context) (Resource, error) {
+		keyPair, err := keypairs.Create(ctx, p.clients.Compute, keypairs.CreateOpts{
+			Name:      kp.Name,
+			PublicKey: kp.PublicKey,
+		}).Extract()
+		if err != nil {
+			return resource, err
+		}
+		// Key pairs are identified by their name.
+		resource.ID = keyPair.Name
+		p.report.KeyPair = keyPair
+		p.track(resource, func(ctx context.Context) error {
+			return keypairs.Delete(ctx, p.clients.Compute, keyPair.Name, nil).ExtractErr()
+		})
+		return resource, nil
+	})
+}
+
+func (p *provisioner) createPorts(ctx context.Context) error {
+	for i, spec := range p.spec.Ports {
+		resource := Resource{Type: ResourcePort, Name: fmt.Sprintf("%s-port-%d", p.spec.Name, i)}
+		err := p.run(ctx, ActionCreatePort, resource, func(ctx context.Context) (Resource, error) {
+			opts := ports.CreateOpts{
+				NetworkID: spec.NetworkID,
+				Name:      resource.Name,
+			}
+			if spec.SubnetID != "" || spec.FixedIP != "" {
+				opts.FixedIPs = []ports.IP{{SubnetID: spec.SubnetID, IPAddress: spec.FixedIP}}
+			}
+			if spec.SecurityGroups != nil {
+				opts.SecurityGroups = &spec.SecurityGroups
+			}
+
+			port, err := ports.Create(ctx, p.clients.Network, opts).Extract()
+			if err != nil {
+				return resource, err
+			}
+			resource.ID = port.ID
+			p.report.Ports = append(p.report.Ports, *port)
+			p.track(resource, func(ctx context.Context) error {
+				return ignoreNotFound(ports.Delete(ctx, p.clients.Network, port.ID).ExtractErr())
+			})
+			return resource, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *provisioner) createVolume(ctx context.Context) error {
+	spec := p.spec.BootVolume
+	if spec == nil {
+		return nil
+	}
+
+	resource := Resource{Type: ResourceVolume, Name: p.spec.Name + "-boot"}
+	err := p.run(ctx, ActionCreateVolume, resource, func(ctx context.Context) (Resource, error) {
+		az := spec.AvailabilityZone
+		if az == "" {
+			az = p.spec.AvailabilityZone
+		}
+		volume, err := volumes.Create(ctx, p.clients.BlockStorage, volumes.CreateOpts{
+			Name:             resource.Name,
+			Size:             spec.Size,
+			VolumeType:       spec.VolumeType,
+			AvailabilityZone: az,
+			ImageID:          p.spec.ImageRef,
+		}, nil).Extract()
+		if err != nil {
+			return resource, err
+		}
+		resource.ID = volume.ID
+		p.report.Volume = volume
+		p.track(resource, func(ctx context.Context) error {
+			return p.deleteVolume(ctx, volume.ID)
+		})
+		return resource, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	resource = p.report.Steps[len(p.report.Steps)-1].Resource
+	return p.run(ctx, ActionWaitVolume, resource, func(ctx context.Context) (Resource, error) {
+		volume, err := waitForVolume(ctx, p.clients.BlockStorage, resource.ID)
+		if volume != nil {
+			p.report.Volume = volume
+		}
+		return resource, err
+	})
+}
+
+func (p *provisioner) createServer(ctx context.Context) error {
+	networks := make([]servers.Network, 0, len(p.report.Ports))
+	for _, port := range p.report.Ports {
+		networks = append(networks, servers.Network{Port: port.ID})
+	}
+
+	serverOpts := servers.CreateOpts{
+		Name:             p.spec.Name,
+		FlavorRef:        p.spec.FlavorRef,
+		AvailabilityZone: p.spec.AvailabilityZone,
+		UserData:         p.spec.UserData,
+		Metadata:         p.spec.Metadata,
+		Tags:             p.spec.Tags,
+		ConfigDrive:      p.spec.ConfigDrive,
+		Networks:         networks,
+	}
+	if p.report.Volume != nil {
+		serverOpts.BlockDevice = []servers.BlockDevice{
+			{
+				SourceType:          servers.SourceVolume,
+				DestinationType:     servers.DestinationVolume,
+				UUID:                p.report.Volume.ID,
+				BootIndex:           0,
+				DeleteOnTermination: p.spec.BootVolume.DeleteOnTermination,
+			},
+		}
+	} else {
+		serverOpts.ImageRef = p.spec.ImageRef
+	}
+
+	var opts servers.CreateOptsBuilder = serverOpts
+	if p.spec.KeyPair != nil {
+		opts = keypairs.CreateOptsExt{
+			CreateOptsBuilder: serverOpts,
+			KeyName:           p.spec.KeyPair.Name,
+		}
+	}
+
+	resource := Resource{Type: ResourceServer, Name: p.spec.Name}
+	err := p.run(ctx, ActionCreateServer, resource, func(ctx context.Context) (Resource, error) {
+		server, err := servers.Create(ctx, p.clients.Compute, opts, p.spec.SchedulerHints).Extract()
+		if err != nil {
+			return resource, err
+		}
+		resource.ID = server.ID
+		p.report.Server = server
+		p.track(resource, func(ctx context.Context) error {
+			return p.deleteServer(ctx, server.ID)
+		})
+		return resource, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	resource = p.report.Steps[len(p.report.Steps)-1].Resource
+	return p.run(ctx, ActionWaitServer, resource, func(ctx context.Context) (Resource, error) {
+		server, err := servers.WaitForState(ctx, p.clients.Compute, resource.ID, servers.WaitOpts{
+			Target: []string{"ACTIVE"},
+		})
+		if server != nil {
+			p.report.Server = server
+		}
+		return resource, err
+	})
+}
+
+func (p *provisioner) createFloatingIPs(ctx context.Context) error {
+	for i, spec := range p.spec.Ports {
+		if spec.FloatingNetworkID == "" {
+			continue
+		}
+		port := p.report.Ports[i]
+
+		resource := Resource{Type: ResourceFloatingIP}
+		err := p.run(ctx, ActionCreateFloatingIP, resource, func(ctx context.Context) (Resource, error) {
+			fip, err := floatingips.Create(ctx, p.clients.Network, floatingips.CreateOpts{
+				FloatingNetworkID: spec.FloatingNetworkID,
+				PortID:            port.ID,
+				FixedIP:           spec.FixedIP,
+			}).Extract()
+			if err != nil {
+				return resource, err
+			}
+			resource.ID = fip.ID
+			resource.Name = fip.FloatingIP
+			p.report.FloatingIPs = append(p.report.FloatingIPs, *fip)
+			p.track(resource, func(ctx context.Context) error {
+				return ignoreNotFound(floatingips.Delete(ctx, p.clients.Network, fip.ID).ExtractErr())
+			})
+			return resource, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback deletes the created resources in reverse order. Resources that
+// could not be deleted are reported as leaked.
+func (p *provisioner) rollback(ctx context.Context) {
+	p.report.RolledBack = true
+	for i := len(p.undos) - 1; i >= 0; i-- {
+		u := p.undos[i]
+		err := p.run(ctx, ActionDelete, u.resource, func(ctx context.Context) (Resource, error) {
+			return u.resource, u.delete(ctx)
+		})
+		if err != nil {
+			p.report.Leaked = append(p.report.Leaked, u.resource)
+		}
+	}
+}
+
+// deleteServer deletes a server and waits for it to be gone, so that its
+// ports and volumes are released.
+func (p *provisioner) deleteServer(ctx context.Context, id string) error {
+	err := servers.Delete(ctx, p.clients.Compute, id).ExtractErr()
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	_, err = servers.WaitForState(ctx, p.clients.Compute, id, servers.WaitOpts{
+		NotFoundIsTarget: true,
+	})
+	return err
+}
+
+// deleteVolume waits for a volume to be detached, and deletes it.
+func (p *provisioner) deleteVolume(ctx context.Context, id string) error {
+	err := gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		volume, err := volumes.Get(ctx, p.clients.BlockStorage, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		switch volume.Status {
+		case "available", "error", "error_restoring", "error_extending":
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+	return ignoreNotFound(volumes.Delete(ctx, p.clients.BlockStorage, id, nil).ExtractErr())
+}
+
+// waitForVolume waits for a volume to be available.
+func waitForVolume(ctx context.Context, client *gophercloud.ServiceClient, id string) (*volumes.Volume, error) {
+	var volume *volumes.Volume
+	err := gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		current, err := volumes.Get(ctx, client, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		volume = current
+		switch volume.Status {
+		case "available":
+			return true, nil
+		case "error":
+			return false, ErrResourceFailed{Resource: Resource{Type: ResourceVolume, ID: id, Name: volume.Name}, Status: volume.Status}
+		}
+		return false, nil
+	})
+	return volume, err
+}
+
+func ignoreNotFound(err error) error {
+	if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
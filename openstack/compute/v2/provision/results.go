@@ -0,0 +1,112 @@
+package provision
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/keypairs"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+)
+
+// ResourceType is the type of a resource created by Provision.
+type ResourceType string
+
+const (
+	ResourceKeyPair    ResourceType = "keypair"
+	ResourcePort       ResourceType = "port"
+	ResourceVolume     ResourceType = "volume"
+	ResourceServer     ResourceType = "server"
+	ResourceFloatingIP ResourceType = "floatingip"
+)
+
+// Action is what a step of Provision does.
+type Action string
+
+const (
+	ActionCreateKeyPair    Action = "create_keypair"
+	ActionCreatePort       Action = "create_port"
+	ActionCreateVolume     Action = "create_volume"
+	ActionWaitVolume       Action = "wait_volume"
+	ActionCreateServer     Action = "create_server"
+	ActionWaitServer       Action = "wait_server"
+	ActionCreateFloatingIP Action = "create_floatingip"
+
+	// ActionDelete is the action of the rollback steps.
+	ActionDelete Action = "delete"
+)
+
+// Resource identifies a resource created by Provision. Key pairs are
+// identified by their name, which is also their ID.
+type Resource struct {
+	Type ResourceType `json:"type"`
+	ID   string       `json:"id"`
+	Name string       `json:"name,omitempty"`
+}
+
+// Step is a step of Provision.
+type Step struct {
+	// Action is what the step does.
+	Action Action `json:"action"`
+
+	// Resource is the resource the step applies to. Its ID is empty if the
+	// step failed to create it.
+	Resource Resource `json:"resource"`
+
+	// StartedAt and FinishedAt are the times the step started and finished
+	// at.
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	// Err is the error the step failed with, if any.
+	Err error `json:"-"`
+}
+
+// Succeeded reports whether the step succeeded.
+func (s Step) Succeeded() bool {
+	return s.Err == nil
+}
+
+// Report is the outcome of Provision.
+type Report struct {
+	// Steps are all the steps run, in order, including the rollback.
+	Steps []Step
+
+	// KeyPair is the imported key pair, if any.
+	KeyPair *keypairs.KeyPair
+
+	// Ports are the created ports, in the order of Spec.Ports.
+	Ports []ports.Port
+
+	// Volume is the boot volume, if any.
+	Volume *volumes.Volume
+
+	// Server is the server, as last seen.
+	Server *servers.Server
+
+	// FloatingIPs are the created floating IPs.
+	FloatingIPs []floatingips.FloatingIP
+
+	// RolledBack is true if provisioning failed and the created resources
+	// were deleted.
+	RolledBack bool
+
+	// Leaked are the resources the rollback could not delete.
+	Leaked []Resource
+}
+
+// Resources returns the resources created by Provision, in order of
+// creation.
+func (r Report) Resources() []Resource {
+	var resources []Resource
+	for _, step := range r.Steps {
+		switch step.Action {
+		case ActionCreateKeyPair, ActionCreatePort, ActionCreateVolume, ActionCreateServer, ActionCreateFloatingIP:
+			if step.Succeeded() {
+				resources = append(resources, step.Resource)
+			}
+		}
+	}
+	return resources
+}
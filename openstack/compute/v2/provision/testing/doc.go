@@ -0,0 +1,2 @@
+// provision unit tests
+package testing
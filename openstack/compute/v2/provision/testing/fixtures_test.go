@@ -0,0 +1,230 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+const KeyPairOutput = `
+{
+	"keypair": {
+		"name": "web-1-key",
+		"fingerprint": "1e:2c:9b:56:79:4b:45:77:f9:ca:7a:98:2c:b0:d5:3c",
+		"public_key": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDx8nkQv/zgGgB4rMYmIf+6A4l6Rr+o/6lHBQdW5aYd44bd8JttDCE/F/pNRr0lRE+PiqSPO8nDPHw0010JeMH9gYgnnFlyY3/OcJ02RhIPyyxYpv9FhY+2YiUkpwFOcLImyrxEsYXpD/0d3ac30bNH6Sw9JD9UZHYcpSxsIbECHw== Generated-by-Nova",
+		"user_id": "fake"
+	}
+}
+`
+
+const PortOutput = `
+{
+	"port": {
+		"id": "65c0ee9f-d634-4522-8954-51021b570b0d",
+		"name": "web-1-port-0",
+		"network_id": "a87cc70a-3e15-4acf-8205-9b711a3531b7",
+		"status": "DOWN",
+		"fixed_ips": [
+			{
+				"subnet_id": "a0304c3a-4f08-4c43-88af-d796509c97d2",
+				"ip_address": "10.0.0.2"
+			}
+		],
+		"security_groups": ["f0ac4394-7e4a-4409-9701-ba8be283dbc3"]
+	}
+}
+`
+
+const VolumeOutput = `
+{
+	"volume": {
+		"id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+		"name": "web-1-boot",
+		"size": 20,
+		"status": "%s"
+	}
+}
+`
+
+const ServerOutput = `
+{
+	"server": {
+		"id": "9e5476bd-a4ec-4653-93d6-72c93aa682ba",
+		"name": "web-1",
+		"status": "%s",
+		"fault": {
+			"code": 500,
+			"created": "2024-01-01T00:00:00Z",
+			"message": "No valid host was found. There are not enough hosts available."
+		}
+	}
+}
+`
+
+const FloatingIPOutput = `
+{
+	"floatingip": {
+		"id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+		"floating_network_id": "376da547-b977-4cfe-9cba-275c80debf57",
+		"floating_ip_address": "172.24.4.228",
+		"port_id": "65c0ee9f-d634-4522-8954-51021b570b0d",
+		"fixed_ip_address": "10.0.0.2",
+		"status": "DOWN"
+	}
+}
+`
+
+// Clients returns service clients whose endpoints share the test server.
+func Clients() (compute, network, blockStorage *gophercloud.ServiceClient) {
+	compute = client.ServiceClient()
+	compute.Endpoint = th.Endpoint() + "compute/"
+	network = client.ServiceClient()
+	network.Endpoint = th.Endpoint() + "network/"
+	blockStorage = client.ServiceClient()
+	blockStorage.Endpoint = th.Endpoint() + "volume/"
+	return compute, network, blockStorage
+}
+
+// Cloud fakes the resources created by Provision. The server goes to
+// ServerStatus once created. Deletions are recorded in order.
+type Cloud struct {
+	ServerStatus string
+
+	mu            sync.Mutex
+	Deleted       []string
+	serverDeleted bool
+}
+
+func (c *Cloud) deleted(resource string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Deleted = append(c.Deleted, resource)
+	if resource == "server" {
+		c.serverDeleted = true
+	}
+}
+
+// HandleCloud registers the handlers of the fake cloud.
+func HandleCloud(t *testing.T, c *Cloud) {
+	th.Mux.HandleFunc("/compute/os-keypairs", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{"keypair": {"name": "web-1-key", "public_key": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDx8nkQv/zgGgB4rMYmIf+6A4l6Rr+o/6lHBQdW5aYd44bd8JttDCE/F/pNRr0lRE+PiqSPO8nDPHw0010JeMH9gYgnnFlyY3/OcJ02RhIPyyxYpv9FhY+2YiUkpwFOcLImyrxEsYXpD/0d3ac30bNH6Sw9JD9UZHYcpSxsIbECHw== Generated-by-Nova"}}`)
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, KeyPairOutput)
+	})
+	th.Mux.HandleFunc("/compute/os-keypairs/web-1-key", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		c.deleted("keypair")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	th.Mux.HandleFunc("/network/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"port": {
+				"name": "web-1-port-0",
+				"network_id": "a87cc70a-3e15-4acf-8205-9b711a3531b7",
+				"fixed_ips": [{"subnet_id": "a0304c3a-4f08-4c43-88af-d796509c97d2"}],
+				"security_groups": ["f0ac4394-7e4a-4409-9701-ba8be283dbc3"]
+			}
+		}`)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, PortOutput)
+	})
+	th.Mux.HandleFunc("/network/ports/65c0ee9f-d634-4522-8954-51021b570b0d", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		c.deleted("port")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	th.Mux.HandleFunc("/volume/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"volume": {
+				"name": "web-1-boot",
+				"size": 20,
+				"availability_zone": "az-1",
+				"imageRef": "f90f6034-2570-4974-8351-6b49732ef2eb"
+			}
+		}`)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, VolumeOutput, "creating")
+	})
+	th.Mux.HandleFunc("/volume/volumes/289da7f8-6440-407c-9fb4-7db01ec49164", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, VolumeOutput, "available")
+		case "DELETE":
+			c.deleted("volume")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/compute/servers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"server": {
+				"name": "web-1",
+				"imageRef": "",
+				"flavorRef": "1",
+				"availability_zone": "az-1",
+				"key_name": "web-1-key",
+				"networks": [{"port": "65c0ee9f-d634-4522-8954-51021b570b0d"}],
+				"block_device_mapping_v2": [
+					{
+						"source_type": "volume",
+						"destination_type": "volume",
+						"uuid": "289da7f8-6440-407c-9fb4-7db01ec49164",
+						"boot_index": 0,
+						"delete_on_termination": true
+					}
+				]
+			}
+		}`)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, ServerOutput, "BUILD")
+	})
+	th.Mux.HandleFunc("/compute/servers/9e5476bd-a4ec-4653-93d6-72c93aa682ba", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			c.mu.Lock()
+			deleted := c.serverDeleted
+			c.mu.Unlock()
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, ServerOutput, c.ServerStatus)
+		case "DELETE":
+			c.deleted("server")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/network/floatingips", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"floatingip": {
+				"floating_network_id": "376da547-b977-4cfe-9cba-275c80debf57",
+				"port_id": "65c0ee9f-d634-4522-8954-51021b570b0d"
+			}
+		}`)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, FloatingIPOutput)
+	})
+}
@@ -0,0 +1,125 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/provision"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+var spec = provision.Spec{
+	Name:             "web-1",
+	FlavorRef:        "1",
+	ImageRef:         "f90f6034-2570-4974-8351-6b49732ef2eb",
+	AvailabilityZone: "az-1",
+	Ports: []provision.PortSpec{
+		{
+			NetworkID:         "a87cc70a-3e15-4acf-8205-9b711a3531b7",
+			SubnetID:          "a0304c3a-4f08-4c43-88af-d796509c97d2",
+			SecurityGroups:    []string{"f0ac4394-7e4a-4409-9701-ba8be283dbc3"},
+			FloatingNetworkID: "376da547-b977-4cfe-9cba-275c80debf57",
+		},
+	},
+	BootVolume: &provision.VolumeSpec{
+		Size:                20,
+		DeleteOnTermination: true,
+	},
+	KeyPair: &provision.KeyPairSpec{
+		Name:      "web-1-key",
+		PublicKey: "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQDx8nkQv/zgGgB4rMYmIf+6A4l6Rr+o/6lHBQdW5aYd44bd8JttDCE/F/pNRr0lRE+PiqSPO8nDPHw0010JeMH9gYgnnFlyY3/OcJ02RhIPyyxYpv9FhY+2YiUkpwFOcLImyrxEsYXpD/0d3ac30bNH6Sw9JD9UZHYcpSxsIbECHw== Generated-by-Nova",
+	},
+}
+
+func TestProvision(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	cloud := &Cloud{ServerStatus: "ACTIVE"}
+	HandleCloud(t, cloud)
+
+	compute, network, blockStorage := Clients()
+	report, err := provision.Provision(context.TODO(), provision.Clients{
+		Compute:      compute,
+		Network:      network,
+		BlockStorage: blockStorage,
+	}, spec)
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, "ACTIVE", report.Server.Status)
+	th.CheckEquals(t, "available", report.Volume.Status)
+	th.CheckEquals(t, "web-1-key", report.KeyPair.Name)
+	th.CheckEquals(t, 1, len(report.Ports))
+	th.CheckEquals(t, "172.24.4.228", report.FloatingIPs[0].FloatingIP)
+	th.CheckEquals(t, false, report.RolledBack)
+	th.CheckEquals(t, 0, len(cloud.Deleted))
+
+	var actions []provision.Action
+	for _, step := range report.Steps {
+		th.CheckEquals(t, true, step.Succeeded())
+		actions = append(actions, step.Action)
+	}
+	th.CheckDeepEquals(t, []provision.Action{
+		provision.ActionCreateKeyPair,
+		provision.ActionCreatePort,
+		provision.ActionCreateVolume,
+		provision.ActionWaitVolume,
+		provision.ActionCreateServer,
+		provision.ActionWaitServer,
+		provision.ActionCreateFloatingIP,
+	}, actions)
+
+	th.CheckDeepEquals(t, []provision.Resource{
+		{Type: provision.ResourceKeyPair, ID: "web-1-key", Name: "web-1-key"},
+		{Type: provision.ResourcePort, ID: "65c0ee9f-d634-4522-8954-51021b570b0d", Name: "web-1-port-0"},
+		{Type: provision.ResourceVolume, ID: "289da7f8-6440-407c-9fb4-7db01ec49164", Name: "web-1-boot"},
+		{Type: provision.ResourceServer, ID: "9e5476bd-a4ec-4653-93d6-72c93aa682ba", Name: "web-1"},
+		{Type: provision.ResourceFloatingIP, ID: "2f245a7b-796b-4f26-9cf9-9e82d248fda7", Name: "172.24.4.228"},
+	}, report.Resources())
+}
+
+func TestProvisionRollback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	cloud := &Cloud{ServerStatus: "ERROR"}
+	HandleCloud(t, cloud)
+
+	compute, network, blockStorage := Clients()
+	report, err := provision.Provision(context.TODO(), provision.Clients{
+		Compute:      compute,
+		Network:      network,
+		BlockStorage: blockStorage,
+	}, spec)
+
+	var provisionErr provision.ErrProvisionFailed
+	th.AssertEquals(t, true, errors.As(err, &provisionErr))
+	th.CheckEquals(t, provision.ActionWaitServer, provisionErr.Step)
+	th.CheckEquals(t, 0, len(provisionErr.Leaked))
+
+	var serverErr servers.ErrServerFailed
+	th.AssertEquals(t, true, errors.As(err, &serverErr))
+	th.CheckEquals(t, "ERROR", serverErr.Status)
+	th.CheckEquals(t, 500, serverErr.Fault.Code)
+	th.CheckEquals(t, "No valid host was found. There are not enough hosts available.", serverErr.Fault.Message)
+
+	th.CheckEquals(t, true, report.RolledBack)
+	th.CheckDeepEquals(t, []string{"server", "volume", "port", "keypair"}, cloud.Deleted)
+
+	last := report.Steps[len(report.Steps)-1]
+	th.CheckEquals(t, provision.ActionDelete, last.Action)
+	th.CheckEquals(t, provision.ResourceKeyPair, last.Resource.Type)
+}
+
+func TestProvisionMissingInput(t *testing.T) {
+	invalid := spec
+	invalid.Ports = nil
+
+	_, err := provision.Provision(context.TODO(), provision.Clients{}, invalid)
+	var missing gophercloud.ErrMissingInput
+	th.AssertEquals(t, true, errors.As(err, &missing))
+	th.CheckEquals(t, "Ports", missing.Argument)
+}
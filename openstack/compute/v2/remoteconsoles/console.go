@@ -0,0 +1,214 @@
+package remoteconsoles
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// DefaultKeepAlive is the interval between keepalive pings when
+// DialOpts.KeepAlive is not set.
+const DefaultKeepAlive = 30 * time.Second
+
+// DialOpts configures the connection to a console.
+type DialOpts struct {
+	// TLSConfig is used for wss:// URLs.
+	TLSConfig *tls.Config
+
+	// Header holds additional headers of the WebSocket handshake. The Origin
+	// header defaults to the origin of the console URL.
+	Header http.Header
+
+	// KeepAlive is the interval between pings sent to the console proxy, so
+	// that idle sessions are not closed. Defaults to DefaultKeepAlive; a
+	// negative value disables pings.
+	KeepAlive time.Duration
+}
+
+// Console is a session to the serial console of a server, relayed by the
+// serial console proxy of the Compute service. Reads return the output of
+// the console, and writes are sent as input.
+//
+// Read must not be called concurrently; Write and SendSttyResize may be called
+// concurrently with Read.
+type Console struct {
+	ws  *websocketConn
+	buf []byte
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Dial connects to the serial console at the given URL, as returned by
+// Create. noVNC URLs are accepted too, see WebSocketURL.
+//
+// If the proxy rejects the token of the URL, for example because it has
+// expired, an ErrTokenRejected is returned. Tokens are only checked when
+// connecting: an established session is not affected by their expiry.
+func Dial(ctx context.Context, consoleURL string, opts DialOpts) (*Console, error) {
+	wsURL, err := WebSocketURL(consoleURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := dialWebSocket(ctx, wsURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Console{ws: ws, done: make(chan struct{})}
+	keepAlive := opts.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = DefaultKeepAlive
+	}
+	if keepAlive > 0 {
+		go c.keepAlive(keepAlive)
+	}
+	return c, nil
+}
+
+// DialSerial creates a serial console for the server and connects to it. If
+// the token of the console is rejected, a new console is created once.
+func DialSerial(ctx context.Context, client *gophercloud.ServiceClient, serverID string, opts DialOpts) (*Console, error) {
+	createOpts := CreateOpts{
+		Protocol: ConsoleProtocolSerial,
+		Type:     ConsoleTypeSerial,
+	}
+
+	var err error
+	var rejected ErrTokenRejected
+	for attempt := 0; attempt < 2; attempt++ {
+		var remoteConsole *RemoteConsole
+		remoteConsole, err = Create(ctx, client, serverID, createOpts).Extract()
+		if err != nil {
+			return nil, err
+		}
+
+		var console *Console
+		console, err = Dial(ctx, remoteConsole.URL, opts)
+		if err == nil {
+			return console, nil
+		}
+		if !errors.As(err, &rejected) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// Read reads the output of the console. It returns io.EOF once the proxy has
+// closed the session.
+func (c *Console) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		opcode, payload, err := c.ws.readFrame()
+		if err != nil {
+			if c.isClosed() {
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+
+		switch opcode {
+		case opBinary, opText, opContinuation:
+			c.buf = payload
+		case opPing:
+			if err := c.ws.writeFrame(opPong, payload); err != nil {
+				return 0, err
+			}
+		case opClose:
+			code := 1005
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			c.close(code)
+			if code != 1000 && code != 1001 && code != 1005 {
+				return 0, ErrConsoleClosed{Code: code, Reason: string(payload[min(2, len(payload)):])}
+			}
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// Write sends input to the console.
+func (c *Console) Write(p []byte) (int, error) {
+	if c.isClosed() {
+		return 0, io.ErrClosedPipe
+	}
+	if err := c.ws.writeFrame(opBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SendSttyResize types the command `stty cols N rows M` followed by a
+// carriage return into the console, to set the size of the terminal of the
+// guest.
+//
+// A serial line carries no window size, so that the command is sent as
+// input to whatever reads the console: it only has the intended effect when
+// the prompt of a POSIX shell is waiting for input, and is read as
+// keystrokes by any other program, such as an editor or a password prompt.
+// Only call it when the state of the console is known.
+func (c *Console) SendSttyResize(cols, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "cols and rows"
+		err.Value = fmt.Sprintf("%dx%d", cols, rows)
+		return err
+	}
+	_, err := fmt.Fprintf(c, "stty cols %d rows %d\r", cols, rows)
+	return err
+}
+
+// Close closes the session.
+func (c *Console) Close() error {
+	c.close(1000)
+	return nil
+}
+
+func (c *Console) close(code int) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+		_ = c.ws.writeFrame(opClose, payload)
+		c.ws.conn.Close()
+	})
+}
+
+func (c *Console) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// keepAlive pings the proxy until the session is closed.
+func (c *Console) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.ws.writeFrame(opPing, nil); err != nil {
+				c.ws.conn.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
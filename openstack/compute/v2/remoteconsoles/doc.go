@@ -21,5 +21,45 @@ Example of Creating a new RemoteConsole
 	}
 
 	fmt.Printf("Console URL: %s\n", remtoteConsole.URL)
+
+Example of Attaching to the Serial Console of a Server
+
+	// The console is an io.ReadWriteCloser carrying the bytes of the serial port.
+	console, err := remoteconsoles.DialSerial(context.TODO(), computeClient, serverID, remoteconsoles.DialOpts{})
+	if err != nil {
+	  panic(err)
+	}
+	defer console.Close()
+
+	go io.Copy(os.Stdout, console)
+	io.Copy(console, os.Stdin)
+
+Example of Setting the Terminal Size from a Shell Prompt
+
+	// The stty command is typed into the console, so that it must only be
+	// sent when a shell prompt is known to be waiting for input.
+	if err := console.SendSttyResize(120, 40); err != nil {
+	  panic(err)
+	}
+
+Example of Relaying Consoles to a Web Front End
+
+	// Proxy relays the WebSocket of a browser to the console proxy of the
+	// Compute service, so that the console URLs don't have to be exposed.
+	proxy := &remoteconsoles.Proxy{
+	  Target: func(r *http.Request) (string, error) {
+	    serverID := strings.TrimPrefix(r.URL.Path, "/consoles/")
+	    createOpts := remoteconsoles.CreateOpts{
+	      Protocol: remoteconsoles.ConsoleProtocolVNC,
+	      Type:     remoteconsoles.ConsoleTypeNoVNC,
+	    }
+	    console, err := remoteconsoles.Create(r.Context(), computeClient, serverID, createOpts).Extract()
+	    if err != nil {
+	      return "", err
+	    }
+	    return console.URL, nil
+	  },
+	}
+	http.Handle("/consoles/", proxy)
 */
 package remoteconsoles
@@ -0,0 +1,35 @@
+package remoteconsoles
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrTokenRejected is returned by Dial when the console proxy refuses the
+// WebSocket handshake, which it does when the token of the console URL is
+// invalid or has expired. A new console must be created.
+type ErrTokenRejected struct {
+	gophercloud.ErrUnexpectedResponseCode
+}
+
+func (e ErrTokenRejected) Error() string {
+	return fmt.Sprintf("The console proxy at %s rejected the console token: %d", e.URL, e.Actual)
+}
+
+// ErrConsoleClosed is returned by Console.Read when the console proxy closes
+// the session abnormally.
+type ErrConsoleClosed struct {
+	gophercloud.BaseError
+
+	// Code and Reason are the WebSocket close status code and reason.
+	Code   int
+	Reason string
+}
+
+func (e ErrConsoleClosed) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("The console proxy closed the session: %d", e.Code)
+	}
+	return fmt.Sprintf("The console proxy closed the session: %d %s", e.Code, e.Reason)
+}
@@ -0,0 +1,76 @@
+package remoteconsoles
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// Proxy is an http.Handler relaying WebSocket console sessions from browsers,
+// for example noVNC or a terminal emulator, to the console proxies of the
+// Compute service. The URLs and tokens of the consoles are never sent to the
+// browsers.
+//
+// Only WebSocket upgrade requests are accepted; the web pages of noVNC must
+// be served separately.
+type Proxy struct {
+	// Target returns the URL of the console to relay the request to, as
+	// returned by Create. It is called for every session, typically to create
+	// a console for the server named in the request, once the user has been
+	// authorized. An error is reported to the browser as a 502, without
+	// details.
+	Target func(r *http.Request) (string, error)
+
+	// Transport connects to the console proxies. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// ServeHTTP relays a WebSocket session.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		http.Error(w, "a WebSocket upgrade is required", http.StatusBadRequest)
+		return
+	}
+
+	consoleURL, err := p.Target(r)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+	wsURL, err := WebSocketURL(consoleURL)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+	target, err := url.Parse(wsURL)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+	if target.Scheme == "wss" {
+		target.Scheme = "https"
+	} else {
+		target.Scheme = "http"
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			u := *target
+			pr.Out.URL = &u
+			pr.Out.Host = target.Host
+
+			// The console proxies only accept their own origin, and must
+			// not see the credentials of the browser.
+			pr.Out.Header.Set("Origin", target.Scheme+"://"+target.Host)
+			pr.Out.Header.Del("Cookie")
+			pr.Out.Header.Del("Authorization")
+		},
+		Transport: p.Transport,
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
@@ -0,0 +1,167 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/remoteconsoles"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+func wsURL(server *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + path
+}
+
+// expect reads the next event of the console proxy, skipping the keepalive
+// pings unless one is expected.
+func expect(t *testing.T, p *ConsoleProxy, event string) {
+	t.Helper()
+	for {
+		select {
+		case actual := <-p.Events:
+			if actual == "ping" && event != "ping" {
+				continue
+			}
+			th.CheckEquals(t, event, actual)
+			return
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", event)
+		}
+	}
+}
+
+func TestWebSocketURL(t *testing.T) {
+	for consoleURL, expected := range map[string]string{
+		"ws://192.168.0.4:6083/?token=f9906a48":                                     "ws://192.168.0.4:6083/?token=f9906a48",
+		"https://console.example.com/vnc_auto.html?path=%3Ftoken%3Dabc":             "wss://console.example.com/?token=abc",
+		"http://192.168.0.4:6080/vnc_lite.html?token=9a2372b9":                      "ws://192.168.0.4:6080/websockify?token=9a2372b9",
+		"https://console.example.com/spice_auto.html?path=websockify%3Ftoken%3Dabc": "wss://console.example.com/websockify?token=abc",
+	} {
+		actual, err := remoteconsoles.WebSocketURL(consoleURL)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, expected, actual)
+	}
+
+	_, err := remoteconsoles.WebSocketURL("http://192.168.0.4:6080/vnc_auto.html")
+	th.AssertErr(t, err)
+}
+
+func TestDial(t *testing.T) {
+	proxy, server := NewConsoleProxy(t, "f9906a48")
+	defer server.Close()
+
+	console, err := remoteconsoles.Dial(context.TODO(), wsURL(server, "/?token=f9906a48"), remoteconsoles.DialOpts{
+		KeepAlive: 10 * time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+	expect(t, proxy, "connect /")
+	expect(t, proxy, "ping")
+
+	_, err = console.Write([]byte("uname -a\r"))
+	th.AssertNoErr(t, err)
+	expect(t, proxy, "input uname -a\r")
+
+	output := make([]byte, 64)
+	n, err := console.Read(output)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "uname -a\r", string(output[:n]))
+
+	th.AssertNoErr(t, console.SendSttyResize(120, 40))
+	expect(t, proxy, "input stty cols 120 rows 40\r")
+
+	// Reading answers the ping of the proxy.
+	n, err = console.Read(output)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "stty cols 120 rows 40\r", string(output[:n]))
+	expect(t, proxy, "pong are you there")
+
+	th.AssertNoErr(t, console.Close())
+	expect(t, proxy, "close 1000")
+}
+
+func TestDialClosedByProxy(t *testing.T) {
+	proxy, server := NewConsoleProxy(t, "f9906a48")
+	proxy.CloseCode = 1011
+	defer server.Close()
+
+	console, err := remoteconsoles.Dial(context.TODO(), wsURL(server, "/?token=f9906a48"), remoteconsoles.DialOpts{
+		KeepAlive: -1,
+	})
+	th.AssertNoErr(t, err)
+	defer console.Close()
+
+	_, err = console.Write([]byte("reboot\r"))
+	th.AssertNoErr(t, err)
+
+	output, err := io.ReadAll(console)
+	th.CheckEquals(t, "reboot\r", string(output))
+	var closed remoteconsoles.ErrConsoleClosed
+	th.AssertEquals(t, true, errors.As(err, &closed))
+	th.CheckEquals(t, 1011, closed.Code)
+	th.CheckEquals(t, "host unreachable", closed.Reason)
+}
+
+func TestDialSerialTokenExpired(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	proxy, server := NewConsoleProxy(t, "f9906a48")
+	defer server.Close()
+	HandleSerialConsoleCreate(t, wsURL(server, "/?token=expired"), wsURL(server, "/?token=f9906a48"))
+
+	_, err := remoteconsoles.Dial(context.TODO(), wsURL(server, "/?token=expired"), remoteconsoles.DialOpts{})
+	var rejected remoteconsoles.ErrTokenRejected
+	th.AssertEquals(t, true, errors.As(err, &rejected))
+	th.CheckEquals(t, http.StatusUnauthorized, rejected.Actual)
+
+	console, err := remoteconsoles.DialSerial(context.TODO(), client.ServiceClient(), "b16ba811-199d-4ffd-8839-ba96c1185a67", remoteconsoles.DialOpts{
+		KeepAlive: -1,
+	})
+	th.AssertNoErr(t, err)
+	defer console.Close()
+	expect(t, proxy, "connect /")
+}
+
+func TestProxy(t *testing.T) {
+	proxy, server := NewConsoleProxy(t, "abc")
+	defer server.Close()
+
+	relay := httptest.NewServer(&remoteconsoles.Proxy{
+		Target: func(r *http.Request) (string, error) {
+			th.CheckEquals(t, "/consoles/web-1", r.URL.Path)
+			return server.URL + "/vnc_auto.html?path=%3Ftoken%3Dabc", nil
+		},
+	})
+	defer relay.Close()
+
+	header := http.Header{}
+	header.Set("Cookie", "session=secret")
+	console, err := remoteconsoles.Dial(context.TODO(), wsURL(relay, "/consoles/web-1"), remoteconsoles.DialOpts{
+		Header:    header,
+		KeepAlive: -1,
+	})
+	th.AssertNoErr(t, err)
+	defer console.Close()
+	expect(t, proxy, "connect /")
+
+	_, err = console.Write([]byte("RFB 003.008\n"))
+	th.AssertNoErr(t, err)
+	expect(t, proxy, "input RFB 003.008\n")
+
+	output := make([]byte, 64)
+	n, err := console.Read(output)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "RFB 003.008\n", string(output[:n]))
+
+	resp, err := http.Get(relay.URL + "/consoles/web-1")
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.CheckEquals(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -1,5 +1,19 @@
 package testing
 
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
 // RemoteConsoleCreateRequest represents a request to create a remote console.
 const RemoteConsoleCreateRequest = `
 {
@@ -20,3 +34,140 @@ const RemoteConsoleCreateResult = `
     }
 }
 `
+
+// ConsoleProxy is a local stand-in for the serial and noVNC console proxies
+// of the Compute service. It accepts a single token, and echoes the input of
+// the sessions, along with the requests it receives, to the Events channel.
+type ConsoleProxy struct {
+	Token  string
+	Events chan string
+
+	// CloseCode, if set, closes the sessions with this code after the first
+	// message.
+	CloseCode int
+
+	t *testing.T
+}
+
+// NewConsoleProxy starts a console proxy stand-in.
+func NewConsoleProxy(t *testing.T, token string) (*ConsoleProxy, *httptest.Server) {
+	p := &ConsoleProxy{Token: token, Events: make(chan string, 100), t: t}
+	return p, httptest.NewServer(p)
+}
+
+func (p *ConsoleProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("token") != p.Token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	th.CheckEquals(p.t, "websocket", r.Header.Get("Upgrade"))
+	th.CheckEquals(p.t, "13", r.Header.Get("Sec-WebSocket-Version"))
+	th.CheckEquals(p.t, "binary", r.Header.Get("Sec-WebSocket-Protocol"))
+	th.CheckEquals(p.t, "http://"+r.Host, r.Header.Get("Origin"))
+	th.CheckEquals(p.t, "", r.Header.Get("Cookie"))
+	p.Events <- "connect " + r.URL.Path
+
+	sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	w.Header().Set("Upgrade", "websocket")
+	w.Header().Set("Connection", "Upgrade")
+	w.Header().Set("Sec-WebSocket-Accept", base64.StdEncoding.EncodeToString(sum[:]))
+	w.Header().Set("Sec-WebSocket-Protocol", "binary")
+	w.WriteHeader(http.StatusSwitchingProtocols)
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	th.AssertNoErr(p.t, err)
+	defer conn.Close()
+
+	for {
+		opcode, payload, err := readFrame(rw.Reader)
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case 0x2:
+			p.Events <- "input " + string(payload)
+			writeFrame(conn, 0x2, payload)
+			// Ping the client, which must answer before the next message.
+			writeFrame(conn, 0x9, []byte("are you there"))
+			if p.CloseCode != 0 {
+				reason := append(binary.BigEndian.AppendUint16(nil, uint16(p.CloseCode)), "host unreachable"...)
+				writeFrame(conn, 0x8, reason)
+			}
+		case 0x8:
+			p.Events <- "close " + fmt.Sprint(binary.BigEndian.Uint16(payload))
+			return
+		case 0x9:
+			p.Events <- "ping"
+			writeFrame(conn, 0xA, payload)
+		case 0xA:
+			p.Events <- "pong " + string(payload)
+		}
+	}
+}
+
+// readFrame reads a masked frame sent by a client.
+func readFrame(r *bufio.Reader) (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	if header[1]&0x80 == 0 {
+		return 0, nil, fmt.Errorf("unmasked client frame")
+	}
+	length := int(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint64(ext[:]))
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(r, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return header[0] & 0x0F, payload, nil
+}
+
+// writeFrame writes an unmasked frame, as servers do.
+func writeFrame(w io.Writer, opcode byte, payload []byte) {
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	_, _ = w.Write(append(frame, payload...))
+}
+
+// HandleSerialConsoleCreate sets up the test server to respond to serial
+// console creation requests with the given URLs, in order.
+func HandleSerialConsoleCreate(t *testing.T, urls ...string) {
+	th.Mux.HandleFunc("/servers/b16ba811-199d-4ffd-8839-ba96c1185a67/remote-consoles", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{"remote_console": {"protocol": "serial", "type": "serial"}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"remote_console": {"protocol": "serial", "type": "serial", "url": "%s"}}`, urls[0])
+		urls = urls[1:]
+	})
+}
@@ -0,0 +1,291 @@
+package remoteconsoles
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// WebSocket opcodes, as defined by RFC 6455.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// maxFramePayload bounds the size of the frames accepted from the proxy.
+const maxFramePayload = 1 << 20
+
+// websocketGUID is the GUID used to compute Sec-WebSocket-Accept.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocketConn is a minimal client side WebSocket connection, enough to
+// carry the byte stream of a console.
+type websocketConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	// wmu serializes the frames written by Write and by the keepalive.
+	wmu sync.Mutex
+}
+
+// WebSocketURL returns the WebSocket URL of a remote console. Serial console
+// URLs are WebSocket URLs already. noVNC and SPICE HTML5 URLs point to a web
+// page, whose `path` or `token` query parameter locates the WebSocket.
+func WebSocketURL(consoleURL string) (string, error) {
+	u, err := url.Parse(consoleURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "ws", "wss":
+		return u.String(), nil
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported console URL scheme %q", u.Scheme)
+	}
+
+	query := u.Query()
+	if path := query.Get("path"); path != "" {
+		ref, err := url.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(ref.Path, "/") {
+			ref.Path = "/" + ref.Path
+		}
+		u.Path, u.RawQuery = ref.Path, ref.RawQuery
+		return u.String(), nil
+	}
+	if token := query.Get("token"); token != "" {
+		u.Path = "/websockify"
+		u.RawQuery = url.Values{"token": {token}}.Encode()
+		return u.String(), nil
+	}
+	return "", fmt.Errorf("no WebSocket path nor token in console URL %s", consoleURL)
+}
+
+// dialWebSocket opens a WebSocket connection with the `binary` subprotocol.
+func dialWebSocket(ctx context.Context, wsURL string, opts DialOpts) (*websocketConn, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "wss" {
+		config := opts.TLSConfig.Clone()
+		if config == nil {
+			config = new(tls.Config)
+		}
+		if config.ServerName == "" {
+			config.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	// The handshake must not outlive the context.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	ws, err := handshake(conn, u, opts.Header)
+	if !stop() {
+		err = ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// handshake upgrades an HTTP connection to a WebSocket.
+func handshake(conn net.Conn, u *url.URL, header http.Header) (*websocketConn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	httpURL := *u
+	if u.Scheme == "wss" {
+		httpURL.Scheme = "https"
+	} else {
+		httpURL.Scheme = "http"
+	}
+
+	req := &http.Request{
+		Method:     "GET",
+		URL:        &httpURL,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       u.Host,
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if req.Header.Get("Origin") == "" {
+		// The console proxies check that the origin is their own host.
+		req.Header.Set("Origin", httpURL.Scheme+"://"+u.Host)
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Protocol", "binary")
+
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		respErr := gophercloud.ErrUnexpectedResponseCode{
+			URL:            u.String(),
+			Method:         "GET",
+			Expected:       []int{http.StatusSwitchingProtocols},
+			Actual:         resp.StatusCode,
+			Body:           body,
+			ResponseHeader: resp.Header,
+		}
+		switch resp.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			return nil, ErrTokenRejected{ErrUnexpectedResponseCode: respErr}
+		}
+		return nil, respErr
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return nil, fmt.Errorf("invalid Sec-WebSocket-Accept from %s", u.Host)
+	}
+	if protocol := resp.Header.Get("Sec-WebSocket-Protocol"); protocol != "" && protocol != "binary" {
+		return nil, fmt.Errorf("unexpected WebSocket subprotocol %q from %s", protocol, u.Host)
+	}
+
+	return &websocketConn{conn: conn, br: br}, nil
+}
+
+// writeFrame writes a single, masked, frame.
+func (c *websocketConn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 14)
+	header[0] = 0x80 | opcode
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = 0x80 | byte(n)
+	case n <= 0xFFFF:
+		header[1] = 0x80 | 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 0x80 | 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+	header = append(header, mask...)
+
+	frame := make([]byte, len(header)+len(payload))
+	copy(frame, header)
+	for i, b := range payload {
+		frame[len(header)+i] = b ^ mask[i%4]
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// readFrame reads a single frame.
+func (c *websocketConn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxFramePayload {
+		return 0, nil, fmt.Errorf("WebSocket frame of %d bytes exceeds %d bytes", length, maxFramePayload)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return opcode, payload, nil
+}
@@ -58,6 +58,23 @@ Example to Create a Server
 		panic(err)
 	}
 
+Example to Wait for a Server to be Active
+
+	server, err := servers.WaitForState(context.TODO(), computeClient, server.ID, servers.WaitOpts{
+		Target:      []string{"ACTIVE"},
+		MaxInterval: 10 * time.Second,
+		OnProgress: func(p servers.Progress) {
+			fmt.Printf("%s (%s) %d%%\n", p.Server.Status, p.Server.TaskState, p.Server.Progress)
+		},
+	})
+	if err != nil {
+		var failed servers.ErrServerFailed
+		if errors.As(err, &failed) {
+			fmt.Printf("build failed: %s\n", failed.Fault.Message)
+		}
+		panic(err)
+	}
+
 Example to Add a Server to a Server Group
 
 	schedulerHintOpts := servers.SchedulerHintOpts{
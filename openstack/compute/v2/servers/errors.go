@@ -69,3 +69,24 @@ type ErrServerNotFound struct {
 func (e ErrServerNotFound) Error() string {
 	return fmt.Sprintf("I couldn't find server [%s]", e.ID)
 }
+
+// ErrServerFailed is returned by WaitForState when a server reaches one of
+// the failure statuses.
+type ErrServerFailed struct {
+	gophercloud.BaseError
+
+	ID        string
+	Status    string
+	TaskState string
+
+	// Fault is the fault reported by the Compute service, if any.
+	Fault Fault
+}
+
+func (e ErrServerFailed) Error() string {
+	msg := fmt.Sprintf("Server [%s] is in status %s", e.ID, e.Status)
+	if e.Fault.Message != "" {
+		msg += fmt.Sprintf(": %s (code %d)", e.Fault.Message, e.Fault.Code)
+	}
+	return msg
+}
@@ -1,10 +1,12 @@
 package servers
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
 	"net"
 	"regexp"
@@ -513,6 +515,17 @@ type CreateOpts struct {
 	HypervisorHostname string `json:"hypervisor_hostname,omitempty"`
 }
 
+// encodeUserData base64-encodes user data, unless it already is.
+func encodeUserData(userData []byte) *string {
+	var encoded string
+	if _, err := base64.StdEncoding.DecodeString(string(userData)); err != nil {
+		encoded = base64.StdEncoding.EncodeToString(userData)
+	} else {
+		encoded = string(userData)
+	}
+	return &encoded
+}
+
 // ToServerCreateMap assembles a request body based on the contents of a
 // CreateOpts.
 func (opts CreateOpts) ToServerCreateMap() (map[string]any, error) {
@@ -524,13 +537,7 @@ func (opts CreateOpts) ToServerCreateMap() (map[string]any, error) {
 	}
 
 	if opts.UserData != nil {
-		var userData string
-		if _, err := base64.StdEncoding.DecodeString(string(opts.UserData)); err != nil {
-			userData = base64.StdEncoding.EncodeToString(opts.UserData)
-		} else {
-			userData = string(opts.UserData)
-		}
-		b["user_data"] = &userData
+		b["user_data"] = encodeUserData(opts.UserData)
 	}
 
 	if len(opts.SecurityGroups) > 0 {
@@ -774,6 +781,11 @@ type RebuildOpts struct {
 
 	// DiskConfig controls how the rebuilt server's disk is partitioned.
 	DiskConfig DiskConfig `json:"OS-DCF:diskConfig,omitempty"`
+
+	// UserData [optional] replaces the user data of the server. It is
+	// base64-encoded for you, unless it already is.
+	// This requires microversion 2.57 or later.
+	UserData []byte `json:"-"`
 }
 
 // ToServerRebuildMap formats a RebuildOpts struct into a map for use in JSON
@@ -790,6 +802,10 @@ func (opts RebuildOpts) ToServerRebuildMap() (map[string]any, error) {
 		return nil, err
 	}
 
+	if opts.UserData != nil {
+		b["user_data"] = encodeUserData(opts.UserData)
+	}
+
 	return map[string]any{"rebuild": b}, nil
 }
 
@@ -1045,9 +1061,24 @@ func CreateImage(ctx context.Context, client *gophercloud.ServiceClient, id stri
 		return
 	}
 	resp, err := client.Post(ctx, actionURL(client, id), b, nil, &gophercloud.RequestOpts{
-		OkCodes: []int{202},
+		OkCodes:          []int{202},
+		KeepResponseBody: true,
 	})
 	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if r.Err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	// Since microversion 2.45, the ID of the image is in the body instead of
+	// the Location header.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		r.Err = json.Unmarshal(body, &r.Body)
+	}
 	return
 }
 
@@ -127,13 +127,25 @@ func decryptPassword(encryptedPassword string, privateKey *rsa.PrivateKey) (stri
 	return string(password), nil
 }
 
-// ExtractImageID gets the ID of the newly created server image from the header.
+// ExtractImageID gets the ID of the newly created server image from the
+// header or, since microversion 2.45, from the body.
 func (r CreateImageResult) ExtractImageID() (string, error) {
 	if r.Err != nil {
 		return "", r.Err
 	}
+	location := r.Header.Get("Location")
+	if location == "" {
+		var s struct {
+			ImageID string `json:"image_id"`
+		}
+		err := r.ExtractInto(&s)
+		if err == nil && s.ImageID == "" {
+			err = fmt.Errorf("Failed to find the ID of newly created image")
+		}
+		return s.ImageID, err
+	}
 	// Get the image id from the header
-	u, err := url.ParseRequestURI(r.Header.Get("Location"))
+	u, err := url.ParseRequestURI(location)
 	if err != nil {
 		return "", err
 	}
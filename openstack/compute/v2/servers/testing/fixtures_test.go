@@ -1313,6 +1313,19 @@ func HandleCreateServerImageSuccessfully(t *testing.T) {
 	})
 }
 
+// HandleCreateServerImageInBodySuccessfully sets up the test server to respond
+// to a CreateImage request with the image ID in the body, as done since
+// microversion 2.45.
+func HandleCreateServerImageInBodySuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/serverimage/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"image_id": "0e7761dd-ee98-41f0-ba35-05994e446431"}`)
+	})
+}
+
 // HandlePasswordGetSuccessfully sets up the test server to respond to a password Get request.
 func HandlePasswordGetSuccessfully(t *testing.T) {
 	th.Mux.HandleFunc("/servers/1234asdf/os-server-password", func(w http.ResponseWriter, r *http.Request) {
@@ -1338,3 +1351,36 @@ func HandleServerWithTagsCreationSuccessfully(t *testing.T) {
 		fmt.Fprint(w, SingleServerWithTagsBody)
 	})
 }
+
+// HandleServerStatesSuccessfully sets up the test server to respond to
+// successive Get requests with the given status, task state and progress of
+// a server. Once the states are exhausted, it responds with a 404.
+func HandleServerStatesSuccessfully(t *testing.T, states [][3]string) {
+	th.Mux.HandleFunc("/servers/1234asdf", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		if len(states) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		state := states[0]
+		states = states[1:]
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"server": {
+				"id": "1234asdf",
+				"status": "%s",
+				"OS-EXT-STS:task_state": "%s",
+				"progress": %s,
+				"fault": {
+					"code": 500,
+					"created": "2024-01-01T00:00:00Z",
+					"details": "Traceback",
+					"message": "No valid host was found."
+				}
+			}
+		}`, state[0], state[1], state[2])
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
 	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
@@ -1096,8 +1099,19 @@ func TestCreateServerImage(t *testing.T) {
 	defer th.TeardownHTTP()
 	HandleCreateServerImageSuccessfully(t)
 
-	_, err := servers.CreateImage(context.TODO(), client.ServiceClient(), "serverimage", servers.CreateImageOpts{Name: "test"}).ExtractImageID()
+	imageID, err := servers.CreateImage(context.TODO(), client.ServiceClient(), "serverimage", servers.CreateImageOpts{Name: "test"}).ExtractImageID()
 	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "xxxx-xxxxx-xxxxx-xxxx", imageID)
+}
+
+func TestCreateServerImageInBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateServerImageInBodySuccessfully(t)
+
+	imageID, err := servers.CreateImage(context.TODO(), client.ServiceClient(), "serverimage", servers.CreateImageOpts{Name: "test"}).ExtractImageID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "0e7761dd-ee98-41f0-ba35-05994e446431", imageID)
 }
 
 func TestMarshalPersonality(t *testing.T) {
@@ -1182,3 +1196,67 @@ func TestCreateServerWithHypervisorHostname(t *testing.T) {
 	th.AssertNoErr(t, err)
 	th.CheckJSONEquals(t, expected, actual)
 }
+
+func TestWaitForState(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerStatesSuccessfully(t, [][3]string{
+		{"BUILD", "scheduling", "0"},
+		{"BUILD", "scheduling", "0"},
+		{"BUILD", "spawning", "0"},
+		{"BUILD", "spawning", "50"},
+		{"ACTIVE", "", "100"},
+	})
+
+	var transitions []string
+	server, err := servers.WaitForState(context.TODO(), client.ServiceClient(), "1234asdf", servers.WaitOpts{
+		Target:      []string{"ACTIVE"},
+		Interval:    time.Millisecond,
+		MaxInterval: 10 * time.Millisecond,
+		OnProgress: func(p servers.Progress) {
+			transitions = append(transitions, fmt.Sprintf("%s/%s->%s/%s %d", p.PreviousStatus, p.PreviousTaskState, p.Server.Status, p.Server.TaskState, p.Server.Progress))
+		},
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ACTIVE", server.Status)
+	th.CheckDeepEquals(t, []string{
+		"/->BUILD/scheduling 0",
+		"BUILD/scheduling->BUILD/spawning 0",
+		"BUILD/spawning->BUILD/spawning 50",
+		"BUILD/spawning->ACTIVE/ 100",
+	}, transitions)
+}
+
+func TestWaitForStateFailure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerStatesSuccessfully(t, [][3]string{
+		{"BUILD", "scheduling", "0"},
+		{"ERROR", "", "0"},
+		{"ACTIVE", "", "100"},
+	})
+
+	err := servers.WaitForStatus(context.TODO(), client.ServiceClient(), "1234asdf", "ACTIVE")
+
+	var failed servers.ErrServerFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.CheckEquals(t, "ERROR", failed.Status)
+	th.CheckEquals(t, 500, failed.Fault.Code)
+	th.CheckEquals(t, "No valid host was found.", failed.Fault.Message)
+	th.CheckEquals(t, "Traceback", failed.Fault.Details)
+}
+
+func TestWaitForStateDeleted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerStatesSuccessfully(t, [][3]string{
+		{"ACTIVE", "deleting", "100"},
+	})
+
+	server, err := servers.WaitForState(context.TODO(), client.ServiceClient(), "1234asdf", servers.WaitOpts{
+		NotFoundIsTarget: true,
+		Interval:         time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "deleting", server.TaskState)
+}
@@ -2,23 +2,137 @@ package servers
 
 import (
 	"context"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/vnpaycloud-console/gophercloud/v2"
 )
 
 // WaitForStatus will continually poll a server until it successfully
-// transitions to a specified status.
+// transitions to a specified status. It returns an ErrServerFailed as soon as
+// the server goes to ERROR, unless ERROR is the expected status.
 func WaitForStatus(ctx context.Context, c *gophercloud.ServiceClient, id, status string) error {
-	return gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+	opts := WaitOpts{Target: []string{status}}
+	if status == "ERROR" {
+		opts.Failure = []string{}
+	}
+	_, err := WaitForState(ctx, c, id, opts)
+	return err
+}
+
+// WaitOpts configures WaitForState.
+type WaitOpts struct {
+	// Target are the statuses that end the wait successfully.
+	Target []string
+
+	// Failure are the statuses that end the wait with an ErrServerFailed.
+	// Defaults to ERROR. Set it to an empty, non-nil slice to disable it.
+	Failure []string
+
+	// NotFoundIsTarget ends the wait successfully when the server does not
+	// exist anymore. It is meant for waiting for deletions.
+	NotFoundIsTarget bool
+
+	// OnProgress, if set, is called with the server every time its status,
+	// task state or progress changes.
+	OnProgress func(Progress)
+
+	// Interval is the time between the first polls. Defaults to 1 second.
+	Interval time.Duration
+
+	// MaxInterval is the upper bound of the time between polls. Defaults to
+	// Interval.
+	MaxInterval time.Duration
+
+	// Factor multiplies the time between polls after each poll which saw no
+	// change. The time is reset to Interval when the server changes.
+	// Defaults to 2 when MaxInterval is greater than Interval.
+	Factor float64
+}
+
+// Progress describes a change of a server observed by WaitForState.
+type Progress struct {
+	// Server is the server as last seen.
+	Server *Server
+
+	// PreviousStatus and PreviousTaskState are the status and the task state
+	// the server was previously seen with. They are empty on the first poll.
+	PreviousStatus    string
+	PreviousTaskState string
+}
+
+// WaitForState will continually poll a server until its status is one of the
+// target statuses, and returns the server. If the status becomes one of the
+// failure statuses, it returns an ErrServerFailed carrying the fault of the
+// server.
+func WaitForState(ctx context.Context, c *gophercloud.ServiceClient, id string, opts WaitOpts) (*Server, error) {
+	failure := opts.Failure
+	if failure == nil {
+		failure = []string{"ERROR"}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	factor := opts.Factor
+	if factor < 1 {
+		factor = 1
+		if maxInterval > interval {
+			factor = 2
+		}
+	}
+
+	var last *Server
+	delay := interval
+	for {
 		current, err := Get(ctx, c, id).Extract()
 		if err != nil {
-			return false, err
+			if opts.NotFoundIsTarget && gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return last, nil
+			}
+			return last, err
 		}
 
-		if current.Status == status {
-			return true, nil
+		changed := last == nil || current.Status != last.Status || current.TaskState != last.TaskState || current.Progress != last.Progress
+		if changed {
+			if opts.OnProgress != nil {
+				progress := Progress{Server: current}
+				if last != nil {
+					progress.PreviousStatus = last.Status
+					progress.PreviousTaskState = last.TaskState
+				}
+				opts.OnProgress(progress)
+			}
+			delay = interval
+		} else {
+			delay = min(time.Duration(float64(delay)*factor), maxInterval)
 		}
+		last = current
 
-		return false, nil
-	})
+		if slices.Contains(opts.Target, current.Status) {
+			return current, nil
+		}
+		if slices.Contains(failure, current.Status) {
+			return current, ErrServerFailed{
+				ID:        current.ID,
+				Status:    current.Status,
+				TaskState: current.TaskState,
+				Fault:     current.Fault,
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		}
+	}
 }
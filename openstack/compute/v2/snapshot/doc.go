@@ -0,0 +1,52 @@
+/*
+Package snapshot snapshots servers, whether they boot from an image or from a
+volume, and restores the snapshots to new servers.
+
+The compute service snapshots the root disk of an image-backed server into an
+image. For a volume-backed server, it snapshots the volumes of the server, and
+creates an image without data recording the volume snapshots in its
+block_device_mapping property. Create waits for all of them, and returns
+every artefact.
+
+Example to Snapshot a Server
+
+	clients := snapshot.Clients{
+		Compute:      computeClient,
+		Image:        imageClient,
+		BlockStorage: blockStorageClient,
+	}
+
+	snap, err := snapshot.Create(context.TODO(), clients, "server-id", snapshot.CreateOpts{
+		Name:    "web-1-snapshot",
+		Quiesce: snapshot.QuiesceStop,
+	})
+	if err != nil {
+		// Delete the artefacts created before the failure.
+		snapshot.Delete(context.TODO(), clients, *snap)
+		panic(err)
+	}
+
+	for _, resource := range snap.Resources() {
+		fmt.Printf("%s %s\n", resource.Type, resource.ID)
+	}
+
+Example to Restore a Snapshot to a New Server
+
+	server, err := snapshot.Restore(context.TODO(), computeClient, *snap, snapshot.RestoreOpts{
+		Name:      "web-2",
+		FlavorRef: "flavor-id",
+		Networks:  []servers.Network{{UUID: "network-id"}},
+		Wait:      true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Snapshot
+
+	err := snapshot.Delete(context.TODO(), clients, *snap)
+	if err != nil {
+		panic(err)
+	}
+*/
+package snapshot
@@ -0,0 +1,40 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrResourceFailed is returned when an artefact goes to an error status
+// while Create waits for it.
+type ErrResourceFailed struct {
+	gophercloud.BaseError
+
+	Resource Resource
+	Status   string
+}
+
+func (e ErrResourceFailed) Error() string {
+	return fmt.Sprintf("%s %s is in status %s", e.Resource.Type, e.Resource.ID, e.Status)
+}
+
+// ErrDeleteFailed is returned by Delete when some artefacts could not be
+// deleted.
+type ErrDeleteFailed struct {
+	gophercloud.BaseError
+
+	// Leaked are the artefacts which could not be deleted.
+	Leaked []Resource
+
+	// Err is the error the first deletion failed with.
+	Err error
+}
+
+func (e ErrDeleteFailed) Error() string {
+	return fmt.Sprintf("%d snapshot artefacts could not be deleted: %v", len(e.Leaked), e.Err)
+}
+
+func (e ErrDeleteFailed) Unwrap() error {
+	return e.Err
+}
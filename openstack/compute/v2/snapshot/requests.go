@@ -0,0 +1,384 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/snapshots"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/keypairs"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/image/v2/images"
+)
+
+// DefaultResumeTimeout is the time given to resume a quiesced server when
+// CreateOpts.ResumeTimeout is not set.
+const DefaultResumeTimeout = 5 * time.Minute
+
+// Clients are the service clients used to snapshot a server.
+type Clients struct {
+	// Compute is used for the server.
+	Compute *gophercloud.ServiceClient
+
+	// Image is used for the image of the snapshot.
+	Image *gophercloud.ServiceClient
+
+	// BlockStorage is used for the volume snapshots. It is only required for
+	// volume-backed servers.
+	BlockStorage *gophercloud.ServiceClient
+}
+
+// Quiesce is the way a server is quiesced while it is snapshotted.
+type Quiesce string
+
+const (
+	// QuiesceNone snapshots the server as it runs. The compute service
+	// freezes the file systems of volume-backed servers running the QEMU
+	// guest agent.
+	QuiesceNone Quiesce = ""
+
+	// QuiesceStop stops the server, and starts it once snapshotted.
+	QuiesceStop Quiesce = "stop"
+
+	// QuiescePause pauses the server, and unpauses it once snapshotted.
+	QuiescePause Quiesce = "pause"
+)
+
+// CreateOpts configures Create.
+type CreateOpts struct {
+	// Name is the name of the image.
+	Name string
+
+	// Metadata is set on the image.
+	Metadata map[string]string
+
+	// Quiesce is the way the server is quiesced. Only active servers are
+	// quiesced.
+	Quiesce Quiesce
+
+	// Interval is the time between two polls. Defaults to 2 seconds.
+	Interval time.Duration
+
+	// ResumeTimeout bounds the time spent resuming a quiesced server. Resuming
+	// does not use the context of Create, which may have expired already.
+	// Defaults to DefaultResumeTimeout.
+	ResumeTimeout time.Duration
+}
+
+// Create snapshots a server, waits for the image to be active and, for
+// volume-backed servers, for the volume snapshots to be available.
+//
+// The snapshot is returned in all cases, with the artefacts created so far,
+// so that they can be deleted with Delete after a failure.
+func Create(ctx context.Context, clients Clients, serverID string, opts CreateOpts) (snap *Snapshot, err error) {
+	snap = &Snapshot{ServerID: serverID}
+	if opts.Name == "" {
+		return snap, gophercloud.ErrMissingInput{Argument: "Name"}
+	}
+	if clients.Compute == nil {
+		return snap, gophercloud.ErrMissingInput{Argument: "Compute"}
+	}
+	if clients.Image == nil {
+		return snap, gophercloud.ErrMissingInput{Argument: "Image"}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	server, err := servers.Get(ctx, clients.Compute, serverID).Extract()
+	if err != nil {
+		return snap, err
+	}
+	// The image of volume-backed servers is empty.
+	snap.VolumeBacked = len(server.Image) == 0
+	if snap.VolumeBacked && clients.BlockStorage == nil {
+		return snap, gophercloud.ErrMissingInput{Argument: "BlockStorage"}
+	}
+
+	if opts.Quiesce != QuiesceNone && server.Status == "ACTIVE" {
+		if err := quiesce(ctx, clients.Compute, serverID, opts.Quiesce); err != nil {
+			return snap, err
+		}
+		defer func() {
+			timeout := opts.ResumeTimeout
+			if timeout == 0 {
+				timeout = DefaultResumeTimeout
+			}
+			resumeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+			defer cancel()
+			if resumeErr := resume(resumeCtx, clients.Compute, serverID, opts.Quiesce, interval); err == nil {
+				err = resumeErr
+			}
+		}()
+	}
+
+	err = snap.create(ctx, clients, opts, interval)
+	return snap, err
+}
+
+func (snap *Snapshot) create(ctx context.Context, clients Clients, opts CreateOpts, interval time.Duration) error {
+	imageID, err := servers.CreateImage(ctx, clients.Compute, snap.ServerID, servers.CreateImageOpts{
+		Name:     opts.Name,
+		Metadata: opts.Metadata,
+	}).ExtractImageID()
+	if err != nil {
+		return err
+	}
+	snap.ImageID = imageID
+
+	err = poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		image, err := images.Get(ctx, clients.Image, imageID).Extract()
+		if err != nil {
+			return false, err
+		}
+		snap.Image = image
+		switch image.Status {
+		case images.ImageStatusActive:
+			return true, nil
+		case images.ImageStatusKilled, images.ImageStatusDeleted:
+			return false, ErrResourceFailed{Resource: Resource{Type: ResourceImage, ID: imageID}, Status: string(image.Status)}
+		}
+		return false, nil
+	})
+	if err != nil || !snap.VolumeBacked {
+		return err
+	}
+
+	snap.BlockDevices, err = parseBlockDeviceMapping(snap.Image.Properties)
+	if err != nil {
+		return err
+	}
+	for _, device := range snap.BlockDevices {
+		if device.SnapshotID == "" {
+			continue
+		}
+		var last *snapshots.Snapshot
+		err := poll(ctx, interval, func(ctx context.Context) (bool, error) {
+			current, err := snapshots.Get(ctx, clients.BlockStorage, device.SnapshotID).Extract()
+			if err != nil {
+				return false, err
+			}
+			last = current
+			switch current.Status {
+			case "available":
+				return true, nil
+			case "error", "error_deleting":
+				return false, ErrResourceFailed{Resource: Resource{Type: ResourceVolumeSnapshot, ID: device.SnapshotID}, Status: current.Status}
+			}
+			return false, nil
+		})
+		if last != nil {
+			snap.VolumeSnapshots = append(snap.VolumeSnapshots, *last)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quiesce stops or pauses a server, and waits for it.
+func quiesce(ctx context.Context, client *gophercloud.ServiceClient, id string, mode Quiesce) error {
+	var err error
+	var status string
+	switch mode {
+	case QuiesceStop:
+		err = servers.Stop(ctx, client, id).ExtractErr()
+		status = "SHUTOFF"
+	case QuiescePause:
+		err = servers.Pause(ctx, client, id).ExtractErr()
+		status = "PAUSED"
+	default:
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "Quiesce"
+		invalid.Value = mode
+		return invalid
+	}
+	if err != nil {
+		return err
+	}
+	_, err = servers.WaitForState(ctx, client, id, servers.WaitOpts{Target: []string{status}})
+	return err
+}
+
+// resume starts or unpauses a quiesced server once the compute service is
+// done with the snapshot, and waits for it to be active.
+func resume(ctx context.Context, client *gophercloud.ServiceClient, id string, mode Quiesce, interval time.Duration) error {
+	err := poll(ctx, interval, func(ctx context.Context) (bool, error) {
+		server, err := servers.Get(ctx, client, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		return server.TaskState == "", nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if mode == QuiesceStop {
+		err = servers.Start(ctx, client, id).ExtractErr()
+	} else {
+		err = servers.Unpause(ctx, client, id).ExtractErr()
+	}
+	if err != nil {
+		return err
+	}
+	_, err = servers.WaitForState(ctx, client, id, servers.WaitOpts{Target: []string{"ACTIVE"}})
+	return err
+}
+
+// poll calls fn until it is done or fails.
+func poll(ctx context.Context, interval time.Duration, fn func(context.Context) (bool, error)) error {
+	for {
+		if done, err := fn(ctx); done || err != nil {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Delete deletes the artefacts of a snapshot: the image, then the volume
+// snapshots. The artefacts already gone are ignored. If some artefacts can't
+// be deleted, the others are still deleted and an ErrDeleteFailed is
+// returned.
+func Delete(ctx context.Context, clients Clients, snap Snapshot) error {
+	var failed ErrDeleteFailed
+	for _, resource := range snap.Resources() {
+		var err error
+		switch resource.Type {
+		case ResourceImage:
+			if clients.Image == nil {
+				err = gophercloud.ErrMissingInput{Argument: "Image"}
+			} else {
+				err = images.Delete(ctx, clients.Image, resource.ID).ExtractErr()
+			}
+		case ResourceVolumeSnapshot:
+			if clients.BlockStorage == nil {
+				err = gophercloud.ErrMissingInput{Argument: "BlockStorage"}
+			} else {
+				err = snapshots.Delete(ctx, clients.BlockStorage, resource.ID).ExtractErr()
+			}
+		}
+		if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			if failed.Err == nil {
+				failed.Err = err
+			}
+			failed.Leaked = append(failed.Leaked, resource)
+		}
+	}
+	if len(failed.Leaked) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// RestoreOpts configures Restore.
+type RestoreOpts struct {
+	// Name is the name of the new server.
+	Name string
+
+	// FlavorRef is the ID of the flavor of the new server.
+	FlavorRef string
+
+	// Networks is passed as is to servers.CreateOpts.
+	Networks any
+
+	// AvailabilityZone is the availability zone of the new server. The
+	// volumes of a volume-backed server are created in the same availability
+	// zone.
+	AvailabilityZone string
+
+	// KeyName is the name of the key pair injected in the new server.
+	KeyName string
+
+	// Metadata is the metadata of the new server.
+	Metadata map[string]string
+
+	// DeleteOnTermination deletes the volumes restored from the snapshot of a
+	// volume-backed server along with the new server.
+	DeleteOnTermination bool
+
+	// SchedulerHints are passed as is to servers.Create.
+	SchedulerHints servers.SchedulerHintOptsBuilder
+
+	// Wait waits for the new server to be active.
+	Wait bool
+}
+
+// Restore creates a new server from a snapshot.
+//
+// The new server boots from the image of an image-backed server. For a
+// volume-backed server, the volumes are created from the volume snapshots,
+// with the same sizes, boot indexes and buses.
+func Restore(ctx context.Context, client *gophercloud.ServiceClient, snap Snapshot, opts RestoreOpts) (*servers.Server, error) {
+	if opts.Name == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "Name"}
+	}
+	if opts.FlavorRef == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "FlavorRef"}
+	}
+	if snap.ImageID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "Snapshot.ImageID"}
+	}
+
+	serverOpts := servers.CreateOpts{
+		Name:             opts.Name,
+		FlavorRef:        opts.FlavorRef,
+		Networks:         opts.Networks,
+		AvailabilityZone: opts.AvailabilityZone,
+		Metadata:         opts.Metadata,
+	}
+	if snap.VolumeBacked {
+		for _, device := range snap.BlockDevices {
+			if device.SnapshotID == "" {
+				continue
+			}
+			bootIndex := -1
+			if device.BootIndex != nil {
+				bootIndex = *device.BootIndex
+			}
+			serverOpts.BlockDevice = append(serverOpts.BlockDevice, servers.BlockDevice{
+				SourceType:          servers.SourceSnapshot,
+				DestinationType:     servers.DestinationVolume,
+				UUID:                device.SnapshotID,
+				BootIndex:           bootIndex,
+				VolumeSize:          device.VolumeSize,
+				DeviceType:          device.DeviceType,
+				DiskBus:             device.DiskBus,
+				DeleteOnTermination: opts.DeleteOnTermination,
+			})
+		}
+		if len(serverOpts.BlockDevice) == 0 {
+			return nil, fmt.Errorf("image %s has no volume snapshot", snap.ImageID)
+		}
+	} else {
+		serverOpts.ImageRef = snap.ImageID
+	}
+
+	var createOpts servers.CreateOptsBuilder = serverOpts
+	if opts.KeyName != "" {
+		createOpts = keypairs.CreateOptsExt{
+			CreateOptsBuilder: serverOpts,
+			KeyName:           opts.KeyName,
+		}
+	}
+
+	server, err := servers.Create(ctx, client, createOpts, opts.SchedulerHints).Extract()
+	if err != nil || !opts.Wait {
+		return server, err
+	}
+	active, err := servers.WaitForState(ctx, client, server.ID, servers.WaitOpts{Target: []string{"ACTIVE"}})
+	if active != nil {
+		server = active
+	}
+	return server, err
+}
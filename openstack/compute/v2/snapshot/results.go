@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/snapshots"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/image/v2/images"
+)
+
+// ResourceType is the type of an artefact of a snapshot.
+type ResourceType string
+
+const (
+	ResourceImage          ResourceType = "image"
+	ResourceVolumeSnapshot ResourceType = "volume_snapshot"
+)
+
+// Resource identifies an artefact of a snapshot.
+type Resource struct {
+	Type ResourceType `json:"type"`
+	ID   string       `json:"id"`
+}
+
+// BlockDevice is a block device recorded by the compute service in the image
+// of a volume-backed server, in its block_device_mapping property.
+type BlockDevice struct {
+	// SourceType is "snapshot" for the volumes of the server, which were
+	// snapshotted. Other block devices, such as blank ephemeral disks, have
+	// no artefact.
+	SourceType string `json:"source_type"`
+
+	// DestinationType is "volume" or "local".
+	DestinationType string `json:"destination_type"`
+
+	// SnapshotID is the ID of the volume snapshot.
+	SnapshotID string `json:"snapshot_id,omitempty"`
+
+	// VolumeSize is the size of the volume, in GB.
+	VolumeSize int `json:"volume_size,omitempty"`
+
+	// BootIndex is the boot index of the device, nil for non-bootable
+	// devices.
+	BootIndex *int `json:"boot_index"`
+
+	DeviceName          string `json:"device_name,omitempty"`
+	DeviceType          string `json:"device_type,omitempty"`
+	DiskBus             string `json:"disk_bus,omitempty"`
+	GuestFormat         string `json:"guest_format,omitempty"`
+	VolumeType          string `json:"volume_type,omitempty"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+}
+
+// parseBlockDeviceMapping reads the block devices recorded in the properties
+// of an image. Glance stores the mapping as a JSON string.
+func parseBlockDeviceMapping(properties map[string]any) ([]BlockDevice, error) {
+	var raw []byte
+	switch v := properties["block_device_mapping"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = []byte(v)
+	default:
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var devices []BlockDevice
+	if err := json.Unmarshal(raw, &devices); err != nil {
+		return nil, fmt.Errorf("invalid block_device_mapping: %w", err)
+	}
+	return devices, nil
+}
+
+// Snapshot is the outcome of Create.
+type Snapshot struct {
+	// ServerID is the ID of the snapshotted server.
+	ServerID string `json:"server_id"`
+
+	// ImageID is the ID of the image created by the compute service.
+	ImageID string `json:"image_id"`
+
+	// Image is the image, as last seen.
+	Image *images.Image `json:"-"`
+
+	// VolumeBacked is true if the server boots from a volume. The image of a
+	// volume-backed server holds no data: it refers to the snapshots of the
+	// volumes of the server in BlockDevices.
+	VolumeBacked bool `json:"volume_backed"`
+
+	// BlockDevices are the block devices recorded in the image of a
+	// volume-backed server.
+	BlockDevices []BlockDevice `json:"block_devices,omitempty"`
+
+	// VolumeSnapshots are the snapshots of the volumes, as last seen.
+	VolumeSnapshots []snapshots.Snapshot `json:"-"`
+}
+
+// Resources returns every artefact of the snapshot: the image, then the
+// volume snapshots.
+func (s Snapshot) Resources() []Resource {
+	var resources []Resource
+	if s.ImageID != "" {
+		resources = append(resources, Resource{Type: ResourceImage, ID: s.ImageID})
+	}
+	for _, device := range s.BlockDevices {
+		if device.SnapshotID != "" {
+			resources = append(resources, Resource{Type: ResourceVolumeSnapshot, ID: device.SnapshotID})
+		}
+	}
+	return resources
+}
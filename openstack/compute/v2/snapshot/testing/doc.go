@@ -0,0 +1,2 @@
+// snapshot unit tests
+package testing
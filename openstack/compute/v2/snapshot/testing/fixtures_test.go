@@ -0,0 +1,247 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/snapshot"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+const (
+	ServerID         = "9e5476bd-a4ec-4653-93d6-72c93aa682ba"
+	ImageID          = "0e7761dd-ee98-41f0-ba35-05994e446431"
+	VolumeSnapshotID = "3b4a2c1d-6e5f-4a8b-9c0d-1e2f3a4b5c6d"
+	RestoredServerID = "5d7e8f90-1a2b-4c3d-8e9f-0a1b2c3d4e5f"
+)
+
+const ServerOutput = `
+{
+	"server": {
+		"id": "%s",
+		"name": "web-1",
+		"status": "%s",
+		"OS-EXT-STS:task_state": null,
+		"image": %s,
+		"flavor": {"id": "1"}
+	}
+}
+`
+
+const ImageOutput = `
+{
+	"id": "0e7761dd-ee98-41f0-ba35-05994e446431",
+	"name": "web-1-snapshot",
+	"status": "%s",
+	"visibility": "private",
+	"container_format": "bare",
+	"disk_format": "qcow2",
+	"min_disk": 20,
+	"min_ram": 0,
+	"size": %d,
+	"created_at": "2024-10-17T10:00:00Z",
+	"updated_at": "2024-10-17T10:00:05Z"%s
+}
+`
+
+// BlockDeviceMapping is the block_device_mapping property of the image of a
+// volume-backed server: the root volume, and a blank ephemeral disk.
+const BlockDeviceMapping = `[{"guest_format": null, "boot_index": 0, "no_device": null, "image_id": null, "volume_id": null, "device_name": "/dev/vda", "disk_bus": "virtio", "volume_size": 20, "source_type": "snapshot", "device_type": "disk", "snapshot_id": "3b4a2c1d-6e5f-4a8b-9c0d-1e2f3a4b5c6d", "destination_type": "volume", "delete_on_termination": false, "tag": null, "volume_type": null}, {"guest_format": null, "boot_index": null, "no_device": null, "image_id": null, "volume_id": null, "device_name": "/dev/vdb", "disk_bus": null, "volume_size": 1, "source_type": "blank", "device_type": "disk", "snapshot_id": null, "destination_type": "local", "delete_on_termination": true, "tag": null, "volume_type": null}]`
+
+const SnapshotOutput = `
+{
+	"snapshot": {
+		"id": "3b4a2c1d-6e5f-4a8b-9c0d-1e2f3a4b5c6d",
+		"name": "snapshot for web-1-snapshot",
+		"volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+		"status": "%s",
+		"size": 20,
+		"created_at": "2024-10-17T10:00:01.000000",
+		"metadata": {}
+	}
+}
+`
+
+var bootIndex = 0
+
+// ExpectedBlockDevices are the block devices of BlockDeviceMapping.
+var ExpectedBlockDevices = []snapshot.BlockDevice{
+	{
+		SourceType:      "snapshot",
+		DestinationType: "volume",
+		SnapshotID:      VolumeSnapshotID,
+		VolumeSize:      20,
+		BootIndex:       &bootIndex,
+		DeviceName:      "/dev/vda",
+		DeviceType:      "disk",
+		DiskBus:         "virtio",
+	},
+	{
+		SourceType:          "blank",
+		DestinationType:     "local",
+		VolumeSize:          1,
+		DeviceName:          "/dev/vdb",
+		DeviceType:          "disk",
+		DeleteOnTermination: true,
+	},
+}
+
+// Clients returns service clients whose endpoints share the test server.
+func Clients() snapshot.Clients {
+	compute := client.ServiceClient()
+	compute.Endpoint = th.Endpoint() + "compute/"
+	image := client.ServiceClient()
+	image.Endpoint = th.Endpoint() + "image/"
+	blockStorage := client.ServiceClient()
+	blockStorage.Endpoint = th.Endpoint() + "volume/"
+	return snapshot.Clients{Compute: compute, Image: image, BlockStorage: blockStorage}
+}
+
+// Cloud fakes a server being snapshotted. The image and the volume snapshot
+// are in progress when first polled. Server actions and deletions are
+// recorded in order.
+type Cloud struct {
+	VolumeBacked bool
+
+	mu         sync.Mutex
+	status     string
+	imagePolls int
+	snapPolls  int
+	Actions    []string
+	Deleted    []string
+}
+
+// HandleCloud registers the handlers of the fake cloud.
+func HandleCloud(t *testing.T, c *Cloud) {
+	c.status = "ACTIVE"
+
+	th.Mux.HandleFunc("/compute/servers/"+ServerID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		image := `{"id": "f90f6034-2570-4974-8351-6b49732ef2eb"}`
+		if c.VolumeBacked {
+			image = `""`
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, ServerOutput, ServerID, c.status, image)
+	})
+
+	th.Mux.HandleFunc("/compute/servers/"+ServerID+"/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		var body map[string]json.RawMessage
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for action := range body {
+			c.Actions = append(c.Actions, action)
+			switch action {
+			case "os-stop":
+				c.status = "SHUTOFF"
+			case "pause":
+				c.status = "PAUSED"
+			case "os-start", "unpause":
+				c.status = "ACTIVE"
+			case "createImage":
+				th.CheckJSONEquals(t, `{"name": "web-1-snapshot", "metadata": {"app": "web"}}`, body[action])
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprintf(w, `{"image_id": "%s"}`, ImageID)
+				return
+			default:
+				t.Errorf("unexpected action %s", action)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	th.Mux.HandleFunc("/image/images/"+ImageID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		switch r.Method {
+		case "GET":
+			c.imagePolls++
+			status := "active"
+			if c.imagePolls == 1 {
+				status = "queued"
+			}
+			size, properties := 1073741824, ""
+			if c.VolumeBacked {
+				size = 0
+				mapping, _ := json.Marshal(BlockDeviceMapping)
+				properties = fmt.Sprintf(`, "bdm_v2": "True", "root_device_name": "/dev/vda", "block_device_mapping": %s`, mapping)
+			}
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, ImageOutput, status, size, properties)
+		case "DELETE":
+			c.Deleted = append(c.Deleted, "image")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/volume/snapshots/"+VolumeSnapshotID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		switch r.Method {
+		case "GET":
+			c.snapPolls++
+			status := "available"
+			if c.snapPolls == 1 {
+				status = "creating"
+			}
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, SnapshotOutput, status)
+		case "DELETE":
+			// The snapshot was deleted already.
+			c.Deleted = append(c.Deleted, "volume_snapshot")
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleRestore registers the handlers creating the restored server, whose
+// request must match the given JSON.
+func HandleRestore(t *testing.T, request string) {
+	th.Mux.HandleFunc("/compute/servers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, request)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, ServerOutput, RestoredServerID, "BUILD", `""`)
+	})
+
+	th.Mux.HandleFunc("/compute/servers/"+RestoredServerID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, ServerOutput, RestoredServerID, "ACTIVE", `""`)
+	})
+}
+
+// restoredFrom returns a snapshot as returned by Create.
+func restoredFrom(volumeBacked bool) snapshot.Snapshot {
+	snap := snapshot.Snapshot{ServerID: ServerID, ImageID: ImageID, VolumeBacked: volumeBacked}
+	if volumeBacked {
+		snap.BlockDevices = ExpectedBlockDevices
+	}
+	return snap
+}
@@ -0,0 +1,149 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/snapshot"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestCreateImageBacked(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := &Cloud{}
+	HandleCloud(t, cloud)
+
+	snap, err := snapshot.Create(context.TODO(), Clients(), ServerID, snapshot.CreateOpts{
+		Name:     "web-1-snapshot",
+		Metadata: map[string]string{"app": "web"},
+		Quiesce:  snapshot.QuiesceStop,
+		Interval: time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, ImageID, snap.ImageID)
+	th.CheckEquals(t, false, snap.VolumeBacked)
+	th.CheckEquals(t, "active", string(snap.Image.Status))
+	th.CheckEquals(t, 0, len(snap.BlockDevices))
+	th.CheckDeepEquals(t, []snapshot.Resource{{Type: snapshot.ResourceImage, ID: ImageID}}, snap.Resources())
+	th.CheckDeepEquals(t, []string{"os-stop", "createImage", "os-start"}, cloud.Actions)
+}
+
+func TestCreateVolumeBacked(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := &Cloud{VolumeBacked: true}
+	HandleCloud(t, cloud)
+
+	snap, err := snapshot.Create(context.TODO(), Clients(), ServerID, snapshot.CreateOpts{
+		Name:     "web-1-snapshot",
+		Metadata: map[string]string{"app": "web"},
+		Quiesce:  snapshot.QuiescePause,
+		Interval: time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, true, snap.VolumeBacked)
+	th.CheckDeepEquals(t, ExpectedBlockDevices, snap.BlockDevices)
+	th.AssertEquals(t, 1, len(snap.VolumeSnapshots))
+	th.CheckEquals(t, "available", snap.VolumeSnapshots[0].Status)
+	th.CheckDeepEquals(t, []snapshot.Resource{
+		{Type: snapshot.ResourceImage, ID: ImageID},
+		{Type: snapshot.ResourceVolumeSnapshot, ID: VolumeSnapshotID},
+	}, snap.Resources())
+	th.CheckDeepEquals(t, []string{"pause", "createImage", "unpause"}, cloud.Actions)
+}
+
+func TestCreateVolumeBackedMissingClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := &Cloud{VolumeBacked: true}
+	HandleCloud(t, cloud)
+
+	clients := Clients()
+	clients.BlockStorage = nil
+	_, err := snapshot.Create(context.TODO(), clients, ServerID, snapshot.CreateOpts{Name: "web-1-snapshot"})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, 0, len(cloud.Actions))
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	cloud := &Cloud{VolumeBacked: true}
+	HandleCloud(t, cloud)
+
+	err := snapshot.Delete(context.TODO(), Clients(), restoredFrom(true))
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"image", "volume_snapshot"}, cloud.Deleted)
+
+	clients := Clients()
+	clients.BlockStorage = nil
+	err = snapshot.Delete(context.TODO(), clients, restoredFrom(true))
+	var failed snapshot.ErrDeleteFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.CheckDeepEquals(t, []snapshot.Resource{{Type: snapshot.ResourceVolumeSnapshot, ID: VolumeSnapshotID}}, failed.Leaked)
+}
+
+func TestRestoreImageBacked(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRestore(t, `{
+		"server": {
+			"name": "web-2",
+			"imageRef": "0e7761dd-ee98-41f0-ba35-05994e446431",
+			"flavorRef": "2",
+			"key_name": "web-key",
+			"networks": [{"uuid": "a87cc70a-3e15-4acf-8205-9b711a3531b7"}]
+		}
+	}`)
+
+	server, err := snapshot.Restore(context.TODO(), Clients().Compute, restoredFrom(false), snapshot.RestoreOpts{
+		Name:      "web-2",
+		FlavorRef: "2",
+		KeyName:   "web-key",
+		Networks:  []servers.Network{{UUID: "a87cc70a-3e15-4acf-8205-9b711a3531b7"}},
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, RestoredServerID, server.ID)
+	th.CheckEquals(t, "BUILD", server.Status)
+}
+
+func TestRestoreVolumeBacked(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRestore(t, `{
+		"server": {
+			"name": "web-2",
+			"imageRef": "",
+			"flavorRef": "2",
+			"networks": "auto",
+			"block_device_mapping_v2": [
+				{
+					"source_type": "snapshot",
+					"destination_type": "volume",
+					"uuid": "3b4a2c1d-6e5f-4a8b-9c0d-1e2f3a4b5c6d",
+					"boot_index": 0,
+					"volume_size": 20,
+					"device_type": "disk",
+					"disk_bus": "virtio",
+					"delete_on_termination": true
+				}
+			]
+		}
+	}`)
+
+	server, err := snapshot.Restore(context.TODO(), Clients().Compute, restoredFrom(true), snapshot.RestoreOpts{
+		Name:                "web-2",
+		FlavorRef:           "2",
+		Networks:            "auto",
+		DeleteOnTermination: true,
+		Wait:                true,
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ACTIVE", server.Status)
+}
@@ -8,7 +8,7 @@
 //		panic(err)
 //	}
 //
-//	providerClient, err := config.NewProviderClient(ctx, ao, config.WithTLSConfig(tlsConfig))
+//	providerClient, err := config.NewProviderClient(ctx, ao, config.WithTLSConfig(tlsConfig), config.WithEndpointOpts(eo))
 //	if err != nil {
 //		panic(err)
 //	}
@@ -20,6 +20,7 @@
 package clouds
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -46,6 +47,19 @@ import (
 //
 // Once `clouds.yaml` is found in a search location, the same location is used to search for `secure.yaml`.
 //
+// If the cloud entry names a vendor profile with its `profile` key, the
+// profile is looked up in a `clouds-public.yaml` file found in the search
+// locations, and its values are used as defaults for the cloud entry. Like in
+// python-openstackclient, which also knows the profiles of its bundled vendor
+// files, a missing `clouds-public.yaml` or profile is not an error: the cloud
+// entry is used as is.
+//
+// Per-service keys of the cloud entry (`<service>_endpoint_override`,
+// `<service>_api_version`, `<service>_service_type`, `<service>_service_name`
+// and `<service>_interface`) are returned in the Overrides field of the
+// EndpointOpts, where the service client factory functions of the openstack
+// package pick them up.
+//
 // Like in python-openstackclient, relative paths in the `clouds.yaml` section
 // `cacert` are interpreted as relative the the current directory, and not to
 // the `clouds.yaml` location.
@@ -96,6 +110,15 @@ func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOp
 			defer f.Close()
 			options.cloudsyamlReader = f
 
+			if options.publicyamlReader == nil {
+				publicPath := path.Join(path.Dir(cloudsPath), "clouds-public.yaml")
+				publicF, err := os.Open(publicPath)
+				if err == nil {
+					defer publicF.Close()
+					options.publicyamlReader = publicF
+				}
+			}
+
 			if options.secureyamlReader == nil {
 				securePath := path.Join(path.Dir(cloudsPath), "secure.yaml")
 				secureF, err := os.Open(securePath)
@@ -141,6 +164,21 @@ func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOp
 		}
 	}
 
+	if profileName := coalesce(cloud.Profile, cloud.Cloud); profileName != "" && options.publicyamlReader != nil {
+		var publicClouds PublicClouds
+		if err := yaml.NewDecoder(options.publicyamlReader).Decode(&publicClouds); err != nil {
+			return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("failed to parse clouds-public.yaml: %w", err)
+		}
+
+		if profile, ok := publicClouds.Clouds[profileName]; ok {
+			var err error
+			cloud, err = mergeClouds(cloud, profile)
+			if err != nil {
+				return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("unable to merge information from clouds.yaml and profile %q", profileName)
+			}
+		}
+	}
+
 	tlsConfig, err := computeTLSConfig(cloud, options)
 	if err != nil {
 		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("unable to compute TLS configuration: %w", err)
@@ -172,6 +210,7 @@ func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOp
 		}, gophercloud.EndpointOpts{
 			Region:       coalesce(options.region, cloud.RegionName),
 			Availability: computeAvailability(endpointType),
+			Overrides:    computeOverrides(cloud),
 		},
 		tlsConfig,
 		nil
@@ -232,6 +271,18 @@ func mergeClouds(override, cloud Cloud) (Cloud, error) {
 	if err != nil {
 		return Cloud{}, err
 	}
+
+	// Extra is not serialized to JSON, because YAML decodes nested maps to
+	// types that encoding/json cannot handle. Merge it separately.
+	if len(override.Extra)+len(cloud.Extra) > 0 {
+		mergedCloud.Extra = make(map[string]ExtraValue, len(override.Extra)+len(cloud.Extra))
+		for k, v := range cloud.Extra {
+			mergedCloud.Extra[k] = v
+		}
+		for k, v := range override.Extra {
+			mergedCloud.Extra[k] = v
+		}
+	}
 	return mergedCloud, nil
 }
 
@@ -272,3 +323,24 @@ func mergeInterfaces(overridingInterface, inferiorInterface any) any {
 		return overridingInterface
 	}
 }
+
+// Credentials returns a function that parses clouds.yaml and secure.yaml
+// again every time it is called, and returns the resulting AuthOptions. It can
+// be used as an openstack.CredentialsProvider, so that a long-running process
+// picks up credentials rotated in the files:
+//
+//	ao, eo, tlsConfig, err := clouds.Parse()
+//	providerClient, err := config.NewProviderClient(ctx, ao,
+//		config.WithTLSConfig(tlsConfig),
+//		config.WithCredentialsProvider(clouds.Credentials()),
+//	)
+//
+// The files are searched as in Parse. Readers passed with WithCloudsYAML,
+// WithSecureYAML or WithPublicCloudsYAML can only be consumed once, so they
+// should not be used with this function.
+func Credentials(opts ...ParseOption) func(context.Context) (gophercloud.AuthOptions, error) {
+	return func(context.Context) (gophercloud.AuthOptions, error) {
+		ao, _, _, err := Parse(opts...)
+		return ao, err
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/vnpaycloud-console/gophercloud/v2"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/config/clouds"
 )
 
@@ -223,3 +225,142 @@ func TestParse(t *testing.T) {
 		}
 	})
 }
+
+func TestParseProfile(t *testing.T) {
+	const cloudsYAML = `clouds:
+  vnpay:
+    profile: vnpay-public
+    auth:
+      username: gophercloud-test-username
+    region_name: HCM02`
+	const publicCloudsYAML = `public-clouds:
+  vnpay-public:
+    auth:
+      auth_url: https://identity.example.com:5000/v3
+      user_domain_name: Default
+    region_name: HCM01
+    interface: internal`
+
+	ao, eo, _, err := clouds.Parse(
+		clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+		clouds.WithPublicCloudsYAML(strings.NewReader(publicCloudsYAML)),
+		clouds.WithCloudName("vnpay"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ao.IdentityEndpoint; got != "https://identity.example.com:5000/v3" {
+		t.Errorf("unexpected identity endpoint: %q", got)
+	}
+	if got := ao.Username; got != "gophercloud-test-username" {
+		t.Errorf("unexpected username: %q", got)
+	}
+	if got := ao.DomainName; got != "Default" {
+		t.Errorf("unexpected domain name: %q", got)
+	}
+	if got := eo.Region; got != "HCM02" {
+		t.Errorf("unexpected region: %q", got)
+	}
+	if got := eo.Availability; got != gophercloud.AvailabilityInternal {
+		t.Errorf("unexpected availability: %q", got)
+	}
+
+	t.Run("ignores a profile missing from clouds-public.yaml", func(t *testing.T) {
+		ao, eo, _, err := clouds.Parse(
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithPublicCloudsYAML(strings.NewReader("public-clouds: {}")),
+			clouds.WithCloudName("vnpay"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := ao.Username; got != "gophercloud-test-username" {
+			t.Errorf("unexpected username: %q", got)
+		}
+		if got := eo.Region; got != "HCM02" {
+			t.Errorf("unexpected region: %q", got)
+		}
+	})
+
+	t.Run("ignores the profile without clouds-public.yaml", func(t *testing.T) {
+		ao, eo, _, err := clouds.Parse(
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithCloudName("vnpay"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := ao.IdentityEndpoint; got != "" {
+			t.Errorf("unexpected identity endpoint: %q", got)
+		}
+		if got := eo.Region; got != "HCM02" {
+			t.Errorf("unexpected region: %q", got)
+		}
+	})
+
+	t.Run("fails if clouds-public.yaml is invalid", func(t *testing.T) {
+		_, _, _, err := clouds.Parse(
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithPublicCloudsYAML(strings.NewReader("public-clouds: [")),
+			clouds.WithCloudName("vnpay"),
+		)
+		if err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+}
+
+func TestParseServiceOverrides(t *testing.T) {
+	const cloudsYAML = `clouds:
+  vnpay:
+    auth:
+      auth_url: https://identity.example.com:5000/v3
+    compute_api_version: 2.10
+    volume_api_version: 3
+    network_endpoint_override: https://network.example.com:9696
+    block_storage_service_type: volumev3
+    load_balancer_interface: internal
+    image_service_name: glance
+    metadata:
+      owner: console`
+	const secureYAML = `clouds:
+  vnpay:
+    compute_endpoint_override: https://compute.example.com:8774/v2.1`
+
+	_, eo, _, err := clouds.Parse(
+		clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+		clouds.WithSecureYAML(strings.NewReader(secureYAML)),
+		clouds.WithCloudName("vnpay"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]gophercloud.ServiceOverride{
+		"compute": {
+			Endpoint:   "https://compute.example.com:8774/v2.1",
+			APIVersion: "2.10",
+		},
+		"block-storage": {
+			Type:       "volumev3",
+			APIVersion: "3",
+		},
+		"network": {
+			Endpoint: "https://network.example.com:9696",
+		},
+		"load-balancer": {
+			Availability: gophercloud.AvailabilityInternal,
+		},
+		"image": {
+			Name: "glance",
+		},
+	}
+	if !reflect.DeepEqual(expected, eo.Overrides) {
+		t.Errorf("unexpected overrides: %+v", eo.Overrides)
+	}
+
+	if o, ok := eo.Override("volumev3"); !ok || o.Type != "volumev3" {
+		t.Errorf("unexpected override for volumev3: %+v", o)
+	}
+}
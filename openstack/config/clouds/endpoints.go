@@ -0,0 +1,70 @@
+package clouds
+
+import (
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// serviceKeySuffixes lists the suffixes of the per-service keys of a cloud
+// entry. Longer suffixes come first, so that `_endpoint_override` is not
+// mistaken for `_endpoint`.
+var serviceKeySuffixes = []string{
+	"_endpoint_override",
+	"_api_version",
+	"_service_type",
+	"_service_name",
+	"_interface",
+	"_endpoint",
+}
+
+// computeOverrides collects the per-service keys of a cloud entry, indexed by
+// their canonical service type.
+func computeOverrides(cloud Cloud) map[string]gophercloud.ServiceOverride {
+	overrides := make(map[string]gophercloud.ServiceOverride)
+	set := func(service, suffix, value string) {
+		serviceType := gophercloud.CanonicalServiceType(service)
+		o := overrides[serviceType]
+		switch suffix {
+		case "_endpoint_override", "_endpoint":
+			if o.Endpoint == "" || suffix == "_endpoint_override" {
+				o.Endpoint = value
+			}
+		case "_api_version":
+			o.APIVersion = value
+		case "_service_type":
+			o.Type = value
+		case "_service_name":
+			o.Name = value
+		case "_interface":
+			o.Availability = computeAvailability(value)
+		}
+		overrides[serviceType] = o
+	}
+
+	for key, value := range cloud.Extra {
+		if value == "" {
+			continue
+		}
+		for _, suffix := range serviceKeySuffixes {
+			if service, found := strings.CutSuffix(key, suffix); found && service != "" {
+				set(service, suffix, string(value))
+				break
+			}
+		}
+	}
+
+	if cloud.IdentityAPIVersion != "" {
+		set("identity", "_api_version", cloud.IdentityAPIVersion)
+	}
+	if cloud.VolumeAPIVersion != "" {
+		if _, ok := cloud.Extra["block_storage_api_version"]; !ok {
+			set("block-storage", "_api_version", cloud.VolumeAPIVersion)
+		}
+	}
+
+	if len(overrides) == 0 {
+		return nil
+	}
+	return overrides
+}
@@ -11,6 +11,7 @@ type cloudOpts struct {
 	locations        []string
 	cloudsyamlReader io.Reader
 	secureyamlReader io.Reader
+	publicyamlReader io.Reader
 
 	applicationCredentialID     string
 	applicationCredentialName   string
@@ -72,6 +73,17 @@ func WithSecureYAML(secure io.Reader) ParseOption {
 	}
 }
 
+// WithPublicCloudsYAML is a functional option that lets you pass a
+// clouds-public.yaml file as an io.Reader interface. It is used to look up the
+// vendor profile named by the `profile` key of the cloud entry. When this
+// option is not passed, clouds-public.yaml is searched next to the
+// clouds.yaml file found on the file system.
+func WithPublicCloudsYAML(publicClouds io.Reader) ParseOption {
+	return func(co *cloudOpts) {
+		co.publicyamlReader = publicClouds
+	}
+}
+
 func WithApplicationCredentialID(applicationCredentialID string) ParseOption {
 	return func(co *cloudOpts) {
 		co.applicationCredentialID = applicationCredentialID
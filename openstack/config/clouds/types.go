@@ -9,6 +9,13 @@ type Clouds struct {
 	Clouds map[string]Cloud `yaml:"clouds" json:"clouds"`
 }
 
+// PublicClouds represents a collection of vendor profiles in a
+// clouds-public.yaml file. A Cloud entry refers to a profile by name with its
+// `profile` (or legacy `cloud`) key.
+type PublicClouds struct {
+	Clouds map[string]Cloud `yaml:"public-clouds" json:"public-clouds"`
+}
+
 // Cloud represents an entry in a clouds.yaml/public-clouds.yaml/secure.yaml file.
 type Cloud struct {
 	Cloud      string    `yaml:"cloud,omitempty" json:"cloud,omitempty"`
@@ -42,6 +49,31 @@ type Cloud struct {
 	// ClientKeyFile a path to a client key to use as part of the SSL
 	// transaction.
 	ClientKeyFile string `yaml:"key,omitempty" json:"key,omitempty"`
+
+	// Extra holds the keys of the cloud entry that are not mapped to any
+	// other field. Among them are the per-service keys
+	// `<service>_endpoint_override`, `<service>_api_version`,
+	// `<service>_service_type`, `<service>_service_name` and
+	// `<service>_interface`, which are returned as endpoint overrides by
+	// Parse.
+	Extra map[string]ExtraValue `yaml:",inline" json:"-"`
+}
+
+// ExtraValue is the value of a cloud entry key that is not mapped to any
+// field of Cloud. Scalars are kept verbatim, so that an API version such as
+// `2.10` is not read as a number; other values are left empty.
+type ExtraValue string
+
+// UnmarshalYAML keeps the text of scalar values and ignores sequences and
+// mappings.
+func (v *ExtraValue) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		*v = ""
+		return nil
+	}
+	*v = ExtraValue(s)
+	return nil
 }
 
 // AuthInfo represents the auth section of a cloud entry or
@@ -12,6 +12,9 @@ import (
 type options struct {
 	httpClient http.Client
 	tlsConfig  *tls.Config
+	overrides  map[string]gophercloud.ServiceOverride
+
+	credentials openstack.CredentialsProvider
 }
 
 // WithHTTPClient enables passing a custom http.Client to be used in the
@@ -32,6 +35,28 @@ func WithTLSConfig(tlsConfig *tls.Config) func(*options) {
 	}
 }
 
+// WithEndpointOpts registers the per-service overrides of the given
+// EndpointOpts (for example, as returned by clouds.Parse) on the
+// ProviderClient. Any ServiceClient derived from the ProviderClient then uses
+// the overridden endpoint, service type, service name and interface, even if
+// the EndpointOpts passed to the service client factory function do not carry
+// the overrides.
+func WithEndpointOpts(eo gophercloud.EndpointOpts) func(*options) {
+	return func(o *options) {
+		o.overrides = eo.Overrides
+	}
+}
+
+// WithCredentialsProvider makes the ProviderClient fetch fresh credentials
+// from the given provider every time it re-authenticates, instead of reusing
+// the AuthOptions passed to NewProviderClient. See
+// openstack.AuthenticateWithCredentials.
+func WithCredentialsProvider(provider openstack.CredentialsProvider) func(*options) {
+	return func(o *options) {
+		o.credentials = provider
+	}
+}
+
 // NewProviderClient logs in to an OpenStack cloud found at the identity
 // endpoint specified by the options, acquires a token, and returns a Provider
 // Client instance that's ready to operate.
@@ -62,9 +87,42 @@ func NewProviderClient(ctx context.Context, authOptions gophercloud.AuthOptions,
 	}
 	client.HTTPClient = options.httpClient
 
-	err = openstack.Authenticate(ctx, client, authOptions)
+	if options.credentials != nil {
+		err = openstack.AuthenticateWithCredentials(ctx, client, authOptions, options.credentials)
+	} else {
+		err = openstack.Authenticate(ctx, client, authOptions)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	if len(options.overrides) > 0 {
+		client.EndpointLocator = overrideLocator(client.EndpointLocator, options.overrides)
+	}
 	return client, nil
 }
+
+// overrideLocator wraps an EndpointLocator so that the given overrides apply
+// to the services they are registered for.
+func overrideLocator(locator gophercloud.EndpointLocator, overrides map[string]gophercloud.ServiceOverride) gophercloud.EndpointLocator {
+	eo := gophercloud.EndpointOpts{Overrides: overrides}
+	return func(opts gophercloud.EndpointOpts) (string, error) {
+		o, ok := eo.Override(opts.Type)
+		if !ok {
+			return locator(opts)
+		}
+		if o.Endpoint != "" {
+			return gophercloud.NormalizeURL(o.Endpoint), nil
+		}
+		if o.Type != "" {
+			opts.Type = o.Type
+		}
+		if o.Name != "" {
+			opts.Name = o.Name
+		}
+		if o.Availability != "" {
+			opts.Availability = o.Availability
+		}
+		return locator(opts)
+	}
+}
@@ -0,0 +1,102 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// CredentialsProvider returns the AuthOptions to use when the ProviderClient
+// re-authenticates. It is called on every re-authentication, so that
+// credentials rotated while the process is running are picked up.
+type CredentialsProvider func(ctx context.Context) (gophercloud.AuthOptions, error)
+
+// EnvCredentials returns a CredentialsProvider that reads the OS_* environment
+// variables again on every re-authentication. See AuthOptionsFromEnv.
+func EnvCredentials() CredentialsProvider {
+	return func(context.Context) (gophercloud.AuthOptions, error) {
+		return AuthOptionsFromEnv()
+	}
+}
+
+// StaticCredentials returns a CredentialsProvider that always returns the
+// given AuthOptions.
+func StaticCredentials(options gophercloud.AuthOptions) CredentialsProvider {
+	return func(context.Context) (gophercloud.AuthOptions, error) {
+		return options, nil
+	}
+}
+
+// AuthenticateWithCredentials authenticates the ProviderClient with the given
+// AuthOptions, and sets its ReauthFunc so that re-authentication uses the
+// AuthOptions returned by the CredentialsProvider rather than the original
+// ones. The ReauthFunc is set regardless of AllowReauth.
+//
+// Credentials are rotated gradually: while the new secret propagates, the
+// identity service may still reject it. Therefore, if the provider fails, or
+// if the identity service rejects the fresh credentials with a 401, the last
+// credentials that were accepted are tried again.
+//
+// The identity endpoint of the ProviderClient is not changed on
+// re-authentication, and neither is its EndpointLocator.
+//
+// Example:
+//
+//	ao, err := openstack.AuthOptionsFromEnv()
+//	provider, err := openstack.NewClient(ao.IdentityEndpoint)
+//	err = openstack.AuthenticateWithCredentials(ctx, provider, ao, openstack.EnvCredentials())
+func AuthenticateWithCredentials(ctx context.Context, client *gophercloud.ProviderClient, options gophercloud.AuthOptions, provider CredentialsProvider) error {
+	options.AllowReauth = false
+	if err := Authenticate(ctx, client, options); err != nil {
+		return err
+	}
+
+	// here we're creating a throw-away client (tac), like v2auth and v3auth
+	// do, so that a failed re-authentication does not trigger another one.
+	tac := *client
+	tac.SetThrowaway(true)
+	tac.ReauthFunc = nil
+	if err := tac.SetTokenAndAuthResult(nil); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	lastGood := options
+
+	authenticate := func(ctx context.Context, options gophercloud.AuthOptions) error {
+		options.AllowReauth = false
+		if err := Authenticate(ctx, &tac, options); err != nil {
+			return err
+		}
+		client.CopyTokenFrom(&tac)
+		return nil
+	}
+
+	client.ReauthFunc = func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fresh, err := provider(ctx)
+		if err != nil {
+			return authenticate(ctx, lastGood)
+		}
+
+		err = authenticate(ctx, fresh)
+		if err == nil {
+			lastGood = fresh
+			return nil
+		}
+		if reflect.DeepEqual(fresh, lastGood) || !gophercloud.ResponseCodeIs(err, http.StatusUnauthorized) {
+			return err
+		}
+		if fallbackErr := authenticate(ctx, lastGood); fallbackErr != nil {
+			return err
+		}
+		return nil
+	}
+
+	return nil
+}
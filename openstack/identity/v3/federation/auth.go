@@ -0,0 +1,340 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/tokens"
+)
+
+// AuthOptionsBuilder is implemented by the options of the federated
+// authentication flows. On top of satisfying tokens.AuthOptionsBuilder, so
+// that they can be passed to openstack.AuthenticateV3, they know how to
+// obtain an unscoped token from the federated authentication endpoint of
+// Keystone.
+type AuthOptionsBuilder interface {
+	tokens.AuthOptionsBuilder
+
+	// AuthenticateUnscoped authenticates against the identity provider and
+	// returns an unscoped token.
+	AuthenticateUnscoped(ctx context.Context, client *gophercloud.ServiceClient) tokens.CreateResult
+}
+
+// Create obtains an unscoped token through federated authentication and, if
+// the options carry a scope, exchanges it for a scoped token with the token
+// method.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, opts AuthOptionsBuilder) (r tokens.CreateResult) {
+	scope, err := opts.ToTokenV3ScopeMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	unscoped := opts.AuthenticateUnscoped(ctx, client)
+	if unscoped.Err != nil || len(scope) == 0 {
+		return unscoped
+	}
+
+	tokenID, err := unscoped.ExtractTokenID()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	return tokens.Create(ctx, client, &scopedOptions{tokenID: tokenID, scope: scope})
+}
+
+// scopedOptions requests a scoped token in exchange of an unscoped one.
+type scopedOptions struct {
+	tokenID string
+	scope   map[string]any
+}
+
+func (opts *scopedOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]any, error) {
+	ao := gophercloud.AuthOptions{TokenID: opts.tokenID}
+	return ao.ToTokenV3CreateMap(scope)
+}
+
+func (opts *scopedOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
+	return nil, nil
+}
+
+func (opts *scopedOptions) ToTokenV3ScopeMap() (map[string]any, error) {
+	return opts.scope, nil
+}
+
+func (opts *scopedOptions) CanReauth() bool {
+	return false
+}
+
+// errUnscopedOnly is returned by ToTokenV3CreateMap, because a federated
+// token can't be requested from the regular token endpoint.
+func errUnscopedOnly() error {
+	return fmt.Errorf("federated authentication options must be passed to federation.Create")
+}
+
+// scopeMap builds the scope of the token request from a tokens.Scope.
+func scopeMap(scope tokens.Scope) (map[string]any, error) {
+	ao := tokens.AuthOptions{Scope: scope}
+	return ao.ToTokenV3ScopeMap()
+}
+
+// OIDCAuthOptions represents options for authenticating through an OpenID
+// Connect identity provider.
+//
+// If AccessToken is set, it is passed as is to Keystone. Otherwise, an access
+// token is obtained from the identity provider with the resource owner
+// password credentials grant, using Username, Password, ClientID and
+// ClientSecret.
+//
+// It can be set as the FederatedAuth of gophercloud.AuthOptions to
+// authenticate with openstack.Authenticate.
+type OIDCAuthOptions struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the federation protocol in Keystone. Defaults to "openid".
+	Protocol string
+
+	// AccessToken is an OpenID Connect access token obtained beforehand.
+	AccessToken string
+
+	// DiscoveryEndpoint is the OpenID Connect discovery document of the
+	// identity provider (the `.well-known/openid-configuration` URL). It is
+	// used to find the token endpoint if TokenEndpoint is not set.
+	DiscoveryEndpoint string
+
+	// TokenEndpoint is the token endpoint of the identity provider.
+	TokenEndpoint string
+
+	// ClientID and ClientSecret identify the OpenID Connect client. The
+	// ClientSecret of a public client is empty: its ClientID is then sent in
+	// the request instead of HTTP basic authentication.
+	ClientID     string
+	ClientSecret string
+
+	// Username and Password are the credentials of the user at the identity
+	// provider.
+	Username string
+	Password string
+
+	// OIDCScope are the scopes requested to the identity provider. Defaults
+	// to "openid".
+	OIDCScope []string
+
+	// Scope is the scope of the Keystone token. If empty, the token is
+	// unscoped.
+	Scope tokens.Scope
+
+	// AllowReauth allows Gophercloud to authenticate again, with the same
+	// options, when the token expires.
+	AllowReauth bool
+}
+
+// ToTokenV3CreateMap allows OIDCAuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface. Federated authentication does not use
+// the regular token endpoint: it always returns an error.
+func (opts *OIDCAuthOptions) ToTokenV3CreateMap(map[string]any) (map[string]any, error) {
+	return nil, errUnscopedOnly()
+}
+
+// ToTokenV3HeadersMap allows OIDCAuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface.
+func (opts *OIDCAuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
+	return nil, nil
+}
+
+// ToTokenV3ScopeMap builds the scope of the token.
+func (opts *OIDCAuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
+	return scopeMap(opts.Scope)
+}
+
+// CanReauth allows OIDCAuthOptions to satisfy the tokens.AuthOptionsBuilder
+// interface.
+func (opts *OIDCAuthOptions) CanReauth() bool {
+	return opts.AllowReauth
+}
+
+// AuthenticateUnscoped passes the access token of the user to the federated
+// authentication endpoint of Keystone.
+func (opts *OIDCAuthOptions) AuthenticateUnscoped(ctx context.Context, client *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	if opts.IdentityProvider == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+
+	accessToken := opts.AccessToken
+	if accessToken == "" {
+		var err error
+		accessToken, err = opts.passwordGrant(ctx, client.ProviderClient)
+		if err != nil {
+			r.Err = err
+			return
+		}
+	}
+
+	protocol := opts.Protocol
+	if protocol == "" {
+		protocol = "openid"
+	}
+
+	resp, err := client.Post(ctx, protocolsAuthURL(client, opts.IdentityProvider, protocol), nil, &r.Body, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Authorization": "Bearer " + accessToken},
+		OmitHeaders: []string{"X-Auth-Token"},
+		OkCodes:     []int{200, 201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// passwordGrant obtains an access token from the identity provider with the
+// resource owner password credentials grant.
+func (opts *OIDCAuthOptions) passwordGrant(ctx context.Context, client *gophercloud.ProviderClient) (string, error) {
+	if opts.Username == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "Username"}
+	}
+	if opts.Password == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "Password"}
+	}
+	if opts.ClientID == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "ClientID"}
+	}
+
+	tokenEndpoint := opts.TokenEndpoint
+	if tokenEndpoint == "" {
+		if opts.DiscoveryEndpoint == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "TokenEndpoint"}
+		}
+		var discovery struct {
+			TokenEndpoint string `json:"token_endpoint"`
+		}
+		_, err := client.Request(ctx, "GET", opts.DiscoveryEndpoint, &gophercloud.RequestOpts{
+			JSONResponse: &discovery,
+			OmitHeaders:  []string{"X-Auth-Token"},
+			OkCodes:      []int{200},
+		})
+		if err != nil {
+			return "", err
+		}
+		if discovery.TokenEndpoint == "" {
+			return "", fmt.Errorf("no token_endpoint in the discovery document at %s", opts.DiscoveryEndpoint)
+		}
+		tokenEndpoint = discovery.TokenEndpoint
+	}
+
+	scope := opts.OIDCScope
+	if len(scope) == 0 {
+		scope = []string{"openid"}
+	}
+
+	form := url.Values{
+		"grant_type": {"password"},
+		"username":   {opts.Username},
+		"password":   {opts.Password},
+		"scope":      {strings.Join(scope, " ")},
+	}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	}
+	if opts.ClientSecret == "" {
+		form.Set("client_id", opts.ClientID)
+	} else {
+		headers["Authorization"] = basicAuth(opts.ClientID, opts.ClientSecret)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	_, err := client.Request(ctx, "POST", tokenEndpoint, &gophercloud.RequestOpts{
+		RawBody:      strings.NewReader(form.Encode()),
+		JSONResponse: &token,
+		MoreHeaders:  headers,
+		OmitHeaders:  []string{"X-Auth-Token"},
+		OkCodes:      []int{200},
+	})
+	if err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("no access_token in the response of %s", tokenEndpoint)
+	}
+	return token.AccessToken, nil
+}
+
+// SAML2ECPAuthOptions represents options for authenticating through a SAML2
+// identity provider supporting the Enhanced Client or Proxy (ECP) profile.
+//
+// It can be set as the FederatedAuth of gophercloud.AuthOptions to
+// authenticate with openstack.Authenticate.
+type SAML2ECPAuthOptions struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the federation protocol in Keystone. Defaults to "saml2".
+	Protocol string
+
+	// IdentityProviderURL is the ECP endpoint of the identity provider, for
+	// example https://idp.example.com/idp/profile/SAML2/SOAP/ECP.
+	IdentityProviderURL string
+
+	// Username and Password are the credentials of the user at the identity
+	// provider. They are sent with HTTP basic authentication.
+	Username string
+	Password string
+
+	// Scope is the scope of the Keystone token. If empty, the token is
+	// unscoped.
+	Scope tokens.Scope
+
+	// AllowReauth allows Gophercloud to authenticate again, with the same
+	// options, when the token expires.
+	AllowReauth bool
+}
+
+// ToTokenV3CreateMap allows SAML2ECPAuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface. Federated authentication does not use
+// the regular token endpoint: it always returns an error.
+func (opts *SAML2ECPAuthOptions) ToTokenV3CreateMap(map[string]any) (map[string]any, error) {
+	return nil, errUnscopedOnly()
+}
+
+// ToTokenV3HeadersMap allows SAML2ECPAuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface.
+func (opts *SAML2ECPAuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
+	return nil, nil
+}
+
+// ToTokenV3ScopeMap builds the scope of the token.
+func (opts *SAML2ECPAuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
+	return scopeMap(opts.Scope)
+}
+
+// CanReauth allows SAML2ECPAuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface.
+func (opts *SAML2ECPAuthOptions) CanReauth() bool {
+	return opts.AllowReauth
+}
+
+// AuthenticateUnscoped runs the SAML2 ECP flow between Keystone, acting as
+// the service provider, and the identity provider.
+func (opts *SAML2ECPAuthOptions) AuthenticateUnscoped(ctx context.Context, client *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	if opts.IdentityProvider == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+	if opts.IdentityProviderURL == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProviderURL"}
+		return
+	}
+
+	protocol := opts.Protocol
+	if protocol == "" {
+		protocol = "saml2"
+	}
+
+	r.Body, r.Header, r.Err = ecpAuthenticate(ctx, client.ProviderClient.HTTPClient, protocolsAuthURL(client, opts.IdentityProvider, protocol), opts.IdentityProviderURL, opts.Username, opts.Password)
+	return
+}
@@ -101,5 +101,64 @@ Example to Delete a Mapping
 	if err != nil {
 		panic(err)
 	}
+
+Example to Create an Identity Provider
+
+	createOpts := federation.CreateIdentityProviderOpts{
+		Enabled:   gophercloud.Enabled,
+		RemoteIDs: []string{"https://sso.vnpay.vn/realms/cloud"},
+	}
+
+	idp, err := federation.CreateIdentityProvider(context.TODO(), identityClient, "vnpay-sso", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Protocol
+
+	createOpts := federation.CreateProtocolOpts{
+		MappingID: "vnpay-sso-mapping",
+	}
+
+	protocol, err := federation.CreateProtocol(context.TODO(), identityClient, "vnpay-sso", "openid", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate with OpenID Connect
+
+	authOptions := gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v3",
+		FederatedAuth: &federation.OIDCAuthOptions{
+			IdentityProvider:  "vnpay-sso",
+			DiscoveryEndpoint: "https://sso.vnpay.vn/realms/cloud/.well-known/openid-configuration",
+			ClientID:          "openstack",
+			ClientSecret:      "secret",
+			Username:          "alice",
+			Password:          "password",
+			Scope:             tokens.Scope{ProjectID: "0fe36e73809d46aeae6705c39077b1b3"},
+			AllowReauth:       true,
+		},
+	}
+
+	provider, err := openstack.AuthenticatedClient(context.TODO(), authOptions)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate with SAML2 ECP
+
+	authOptions := &federation.SAML2ECPAuthOptions{
+		IdentityProvider:    "vnpay-adfs",
+		IdentityProviderURL: "https://idp.vnpay.vn/idp/profile/SAML2/SOAP/ECP",
+		Username:            "alice",
+		Password:            "password",
+		Scope:               tokens.Scope{ProjectID: "0fe36e73809d46aeae6705c39077b1b3"},
+	}
+
+	token, err := federation.Create(context.TODO(), identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
 */
 package federation
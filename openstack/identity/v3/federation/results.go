@@ -207,3 +207,282 @@ func ExtractMappings(r pagination.Page) ([]Mapping, error) {
 	err := (r.(MappingsPage)).ExtractInto(&s)
 	return s.Mappings, err
 }
+
+// IdentityProvider is a trusted source of federated identities.
+type IdentityProvider struct {
+	// ID is the unique ID of the identity provider.
+	ID string `json:"id"`
+
+	// Description of the identity provider.
+	Description string `json:"description"`
+
+	// DomainID is the ID of the domain federated users are created in.
+	DomainID string `json:"domain_id"`
+
+	// AuthorizationTTL is the number of minutes group memberships of
+	// federated users are kept.
+	AuthorizationTTL *int `json:"authorization_ttl"`
+
+	// Enabled indicates whether the identity provider is enabled.
+	Enabled bool `json:"enabled"`
+
+	// RemoteIDs are the unique identifiers of the identity provider.
+	RemoteIDs []string `json:"remote_ids"`
+
+	// Links contains referencing links to the identity provider.
+	Links map[string]any `json:"links"`
+}
+
+type identityProviderResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any identityProviderResult as an IdentityProvider.
+func (c identityProviderResult) Extract() (*IdentityProvider, error) {
+	var s struct {
+		IdentityProvider *IdentityProvider `json:"identity_provider"`
+	}
+	err := c.ExtractInto(&s)
+	return s.IdentityProvider, err
+}
+
+// CreateIdentityProviderResult is the response from a CreateIdentityProvider
+// operation. Call its Extract method to interpret it as an IdentityProvider.
+type CreateIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// GetIdentityProviderResult is the response from a GetIdentityProvider
+// operation. Call its Extract method to interpret it as an IdentityProvider.
+type GetIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// UpdateIdentityProviderResult is the response from an UpdateIdentityProvider
+// operation. Call its Extract method to interpret it as an IdentityProvider.
+type UpdateIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// DeleteIdentityProviderResult is the response from a DeleteIdentityProvider
+// operation. Call its ExtractErr to determine if the request succeeded or
+// failed.
+type DeleteIdentityProviderResult struct {
+	gophercloud.ErrResult
+}
+
+// IdentityProvidersPage is a single page of IdentityProvider results.
+type IdentityProvidersPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of IdentityProviders contains any
+// results.
+func (c IdentityProvidersPage) IsEmpty() (bool, error) {
+	if c.StatusCode == 204 {
+		return true, nil
+	}
+
+	identityProviders, err := ExtractIdentityProviders(c)
+	return len(identityProviders) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (c IdentityProvidersPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := c.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractIdentityProviders returns a slice of IdentityProviders contained in
+// a single page of results.
+func ExtractIdentityProviders(r pagination.Page) ([]IdentityProvider, error) {
+	var s struct {
+		IdentityProviders []IdentityProvider `json:"identity_providers"`
+	}
+	err := (r.(IdentityProvidersPage)).ExtractInto(&s)
+	return s.IdentityProviders, err
+}
+
+// Protocol binds a federation protocol of an identity provider, such as
+// "openid" or "saml2", to a mapping.
+type Protocol struct {
+	// ID is the name of the protocol.
+	ID string `json:"id"`
+
+	// MappingID is the ID of the mapping applied to the assertions of the
+	// identity provider.
+	MappingID string `json:"mapping_id"`
+
+	// RemoteIDAttribute is the attribute of the assertion holding the remote
+	// ID of the identity provider.
+	RemoteIDAttribute string `json:"remote_id_attribute"`
+
+	// Links contains referencing links to the protocol.
+	Links map[string]any `json:"links"`
+}
+
+type protocolResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any protocolResult as a Protocol.
+func (c protocolResult) Extract() (*Protocol, error) {
+	var s struct {
+		Protocol *Protocol `json:"protocol"`
+	}
+	err := c.ExtractInto(&s)
+	return s.Protocol, err
+}
+
+// CreateProtocolResult is the response from a CreateProtocol operation. Call
+// its Extract method to interpret it as a Protocol.
+type CreateProtocolResult struct {
+	protocolResult
+}
+
+// GetProtocolResult is the response from a GetProtocol operation. Call its
+// Extract method to interpret it as a Protocol.
+type GetProtocolResult struct {
+	protocolResult
+}
+
+// UpdateProtocolResult is the response from an UpdateProtocol operation. Call
+// its Extract method to interpret it as a Protocol.
+type UpdateProtocolResult struct {
+	protocolResult
+}
+
+// DeleteProtocolResult is the response from a DeleteProtocol operation. Call
+// its ExtractErr to determine if the request succeeded or failed.
+type DeleteProtocolResult struct {
+	gophercloud.ErrResult
+}
+
+// ProtocolsPage is a single page of Protocol results.
+type ProtocolsPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Protocols contains any results.
+func (c ProtocolsPage) IsEmpty() (bool, error) {
+	if c.StatusCode == 204 {
+		return true, nil
+	}
+
+	protocols, err := ExtractProtocols(c)
+	return len(protocols) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (c ProtocolsPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := c.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractProtocols returns a slice of Protocols contained in a single page of
+// results.
+func ExtractProtocols(r pagination.Page) ([]Protocol, error) {
+	var s struct {
+		Protocols []Protocol `json:"protocols"`
+	}
+	err := (r.(ProtocolsPage)).ExtractInto(&s)
+	return s.Protocols, err
+}
+
+// ServiceProvider is a cloud this Keystone can issue SAML2 assertions for.
+type ServiceProvider struct {
+	// ID is the unique ID of the service provider.
+	ID string `json:"id"`
+
+	// AuthURL is the URL to authenticate against on the service provider.
+	AuthURL string `json:"auth_url"`
+
+	// Description of the service provider.
+	Description string `json:"description"`
+
+	// Enabled indicates whether the service provider is enabled.
+	Enabled bool `json:"enabled"`
+
+	// RelayStatePrefix is the prefix of the RelayState SAML attribute.
+	RelayStatePrefix string `json:"relay_state_prefix"`
+
+	// SPURL is the URL of the service provider ECP endpoint.
+	SPURL string `json:"sp_url"`
+
+	// Links contains referencing links to the service provider.
+	Links map[string]any `json:"links"`
+}
+
+// GetServiceProviderResult is the response from a GetServiceProvider
+// operation. Call its Extract method to interpret it as a ServiceProvider.
+type GetServiceProviderResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetServiceProviderResult as a ServiceProvider.
+func (c GetServiceProviderResult) Extract() (*ServiceProvider, error) {
+	var s struct {
+		ServiceProvider *ServiceProvider `json:"service_provider"`
+	}
+	err := c.ExtractInto(&s)
+	return s.ServiceProvider, err
+}
+
+// ServiceProvidersPage is a single page of ServiceProvider results.
+type ServiceProvidersPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of ServiceProviders contains any
+// results.
+func (c ServiceProvidersPage) IsEmpty() (bool, error) {
+	if c.StatusCode == 204 {
+		return true, nil
+	}
+
+	serviceProviders, err := ExtractServiceProviders(c)
+	return len(serviceProviders) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (c ServiceProvidersPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := c.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractServiceProviders returns a slice of ServiceProviders contained in a
+// single page of results.
+func ExtractServiceProviders(r pagination.Page) ([]ServiceProvider, error) {
+	var s struct {
+		ServiceProviders []ServiceProvider `json:"service_providers"`
+	}
+	err := (r.(ServiceProvidersPage)).ExtractInto(&s)
+	return s.ServiceProviders, err
+}
@@ -0,0 +1,239 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+const (
+	soapNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	paosNamespace = "urn:liberty:paos:2003-08"
+	ecpNamespace  = "urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"
+
+	paosMediaType = "application/vnd.paos+xml"
+	paosHeader    = `ver="` + paosNamespace + `";"` + ecpNamespace + `"`
+)
+
+// basicAuth returns the value of an HTTP basic Authorization header.
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// xmlElement is an element found in an XML document, along with its position
+// in the document.
+type xmlElement struct {
+	start, end int
+	element    xml.StartElement
+	text       string
+}
+
+// attr returns the value of the attribute with the given local name.
+func (e xmlElement) attr(local string) string {
+	for _, a := range e.element.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// findElement returns the first element of the document with the given
+// namespace and local name.
+func findElement(doc []byte, space, local string) (xmlElement, error) {
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		offset := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return xmlElement{}, fmt.Errorf("element %s not found in the SAML2 ECP message", local)
+			}
+			return xmlElement{}, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Space != space || start.Name.Local != local {
+			continue
+		}
+
+		found := xmlElement{start: offset, element: start.Copy()}
+		var text bytes.Buffer
+		for depth := 1; depth > 0; {
+			tok, err := dec.Token()
+			if err != nil {
+				return xmlElement{}, err
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				depth++
+			case xml.EndElement:
+				depth--
+			case xml.CharData:
+				if depth == 1 {
+					text.Write(t)
+				}
+			}
+		}
+		found.end = int(dec.InputOffset())
+		found.text = text.String()
+		return found, nil
+	}
+}
+
+// relayStateXML serializes the ECP RelayState element of the service
+// provider, with its namespaces declared, so that it can be moved into the
+// response of the identity provider.
+func relayStateXML(e xmlElement) []byte {
+	var b bytes.Buffer
+	b.WriteString(`<ecp:RelayState xmlns:ecp="` + ecpNamespace + `" xmlns:S="` + soapNamespace + `"`)
+	for _, a := range e.element.Attr {
+		var prefix string
+		switch a.Name.Space {
+		case "":
+		case soapNamespace:
+			prefix = "S:"
+		case ecpNamespace:
+			prefix = "ecp:"
+		default:
+			// Namespace declarations, and attributes we can't qualify.
+			continue
+		}
+		b.WriteString(" " + prefix + a.Name.Local + `="`)
+		_ = xml.EscapeText(&b, []byte(a.Value))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+	_ = xml.EscapeText(&b, []byte(e.text))
+	b.WriteString("</ecp:RelayState>")
+	return b.Bytes()
+}
+
+// ecpAuthenticate runs the SAML2 ECP profile: it requests an authentication
+// request from the service provider (Keystone), forwards it to the identity
+// provider with the credentials of the user, checks that both agree on where
+// the assertion must be sent, and sends the assertion to the service
+// provider, which then issues an unscoped token.
+func ecpAuthenticate(ctx context.Context, httpClient http.Client, spURL, idpURL, username, password string) (any, http.Header, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClient.Jar = jar
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	do := func(method, url string, body []byte, headers map[string]string, okCodes ...int) (*http.Response, []byte, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, code := range okCodes {
+			if resp.StatusCode == code {
+				return resp, respBody, nil
+			}
+		}
+		return nil, nil, gophercloud.ErrUnexpectedResponseCode{
+			URL:            url,
+			Method:         method,
+			Expected:       okCodes,
+			Actual:         resp.StatusCode,
+			Body:           respBody,
+			ResponseHeader: resp.Header,
+		}
+	}
+
+	// 1. Get the authentication request from the service provider.
+	_, authnRequest, err := do("GET", spURL, nil, map[string]string{
+		"Accept": "text/html, " + paosMediaType,
+		"PAOS":   paosHeader,
+	}, 200)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relayState, err := findElement(authnRequest, ecpNamespace, "RelayState")
+	if err != nil {
+		return nil, nil, err
+	}
+	paosRequest, err := findElement(authnRequest, paosNamespace, "Request")
+	if err != nil {
+		return nil, nil, err
+	}
+	spConsumerURL := paosRequest.attr("responseConsumerURL")
+
+	// 2. Forward it, without its SOAP header, to the identity provider.
+	header, err := findElement(authnRequest, soapNamespace, "Header")
+	if err != nil {
+		return nil, nil, err
+	}
+	idpRequest := append(append([]byte{}, authnRequest[:header.start]...), authnRequest[header.end:]...)
+
+	_, idpResponse, err := do("POST", idpURL, idpRequest, map[string]string{
+		"Content-Type":  "text/xml",
+		"Authorization": basicAuth(username, password),
+	}, 200)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Make sure the identity provider sends the assertion where the
+	// service provider expects it.
+	ecpResponse, err := findElement(idpResponse, ecpNamespace, "Response")
+	if err != nil {
+		return nil, nil, err
+	}
+	idpConsumerURL := ecpResponse.attr("AssertionConsumerServiceURL")
+	if idpConsumerURL == "" || idpConsumerURL != spConsumerURL {
+		return nil, nil, fmt.Errorf("the assertion consumer service URL of the identity provider (%q) does not match the one of the service provider (%q)", idpConsumerURL, spConsumerURL)
+	}
+
+	// 4. Send the assertion, with the relay state of the service provider
+	// in place of the ECP header of the identity provider.
+	assertion := append(append(append([]byte{}, idpResponse[:ecpResponse.start]...), relayStateXML(relayState)...), idpResponse[ecpResponse.end:]...)
+	resp, _, err := do("POST", idpConsumerURL, assertion, map[string]string{
+		"Content-Type": paosMediaType,
+	}, 200, 201, 302, 303)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 5. The service provider redirects to the federated authentication
+	// endpoint, which is now accessible with the session cookie.
+	tokenURL := spURL
+	if location, err := resp.Location(); err == nil {
+		tokenURL = location.String()
+	}
+	resp, tokenBody, err := do("GET", tokenURL, nil, map[string]string{
+		"Accept": "application/json",
+	}, 200, 201)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body any
+	if err := json.Unmarshal(tokenBody, &body); err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
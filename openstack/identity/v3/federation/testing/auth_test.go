@@ -0,0 +1,207 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/federation"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/tokens"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const federatedTokenOutput = `{"token": {"expires_at": "2014-10-02T13:45:00.000000Z", "methods": ["openid"]}}`
+
+func federationClient() *gophercloud.ServiceClient {
+	return &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+}
+
+func TestCreateOIDCPasswordGrant(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"token_endpoint": "%soidc/token"}`, th.Endpoint())
+	})
+
+	th.Mux.HandleFunc("/oidc/token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/x-www-form-urlencoded")
+		user, pass, ok := r.BasicAuth()
+		th.AssertEquals(t, true, ok)
+		th.CheckEquals(t, "console", user)
+		th.CheckEquals(t, "client-secret", pass)
+		th.TestFormValues(t, r, map[string]string{
+			"grant_type": "password",
+			"username":   "alice",
+			"password":   "secret",
+			"scope":      "openid profile",
+		})
+		fmt.Fprint(w, `{"access_token": "oidc-access-token", "token_type": "Bearer"}`)
+	})
+
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", "Bearer oidc-access-token")
+		w.Header().Set("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, federatedTokenOutput)
+	})
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"auth": {
+				"identity": {
+					"methods": ["token"],
+					"token": {"id": "unscoped-token"}
+				},
+				"scope": {"project": {"id": "project-id"}}
+			}
+		}`)
+		w.Header().Set("X-Subject-Token", "scoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, federatedTokenOutput)
+	})
+
+	opts := &federation.OIDCAuthOptions{
+		IdentityProvider:  "vnpay-sso",
+		DiscoveryEndpoint: th.Endpoint() + ".well-known/openid-configuration",
+		ClientID:          "console",
+		ClientSecret:      "client-secret",
+		Username:          "alice",
+		Password:          "secret",
+		OIDCScope:         []string{"openid", "profile"},
+		Scope:             tokens.Scope{ProjectID: "project-id"},
+	}
+
+	tokenID, err := federation.Create(context.TODO(), federationClient(), opts).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "scoped-token", tokenID)
+}
+
+func TestCreateOIDCPasswordGrantPublicClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/oidc/token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.AssertEquals(t, "", r.Header.Get("Authorization"))
+		th.TestFormValues(t, r, map[string]string{
+			"grant_type": "password",
+			"client_id":  "console",
+			"username":   "alice",
+			"password":   "secret",
+			"scope":      "openid",
+		})
+		fmt.Fprint(w, `{"access_token": "oidc-access-token", "token_type": "Bearer"}`)
+	})
+
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", "Bearer oidc-access-token")
+		w.Header().Set("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, federatedTokenOutput)
+	})
+
+	opts := &federation.OIDCAuthOptions{
+		IdentityProvider: "vnpay-sso",
+		TokenEndpoint:    th.Endpoint() + "oidc/token",
+		ClientID:         "console",
+		Username:         "alice",
+		Password:         "secret",
+	}
+
+	tokenID, err := federation.Create(context.TODO(), federationClient(), opts).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "unscoped-token", tokenID)
+}
+
+const ecpAuthnRequest = `<S:Envelope xmlns:S="http://schemas.xmlsoap.org/soap/envelope/">
+<S:Header>
+<paos:Request xmlns:paos="urn:liberty:paos:2003-08" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1" responseConsumerURL="%[1]sShibboleth.sso/SAML2/ECP" service="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"/>
+<ecp:Request xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" IsPassive="0" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1"/>
+<ecp:RelayState xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1">ss:mem:relay</ecp:RelayState>
+</S:Header>
+<S:Body><samlp:AuthnRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_authn"/></S:Body>
+</S:Envelope>`
+
+const ecpIdPResponse = `<soap11:Envelope xmlns:soap11="http://schemas.xmlsoap.org/soap/envelope/">
+<soap11:Header>
+<ecp:Response xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" AssertionConsumerServiceURL="%[1]sShibboleth.sso/SAML2/ECP" soap11:actor="http://schemas.xmlsoap.org/soap/actor/next" soap11:mustUnderstand="1"/>
+</soap11:Header>
+<soap11:Body><saml2p:Response xmlns:saml2p="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response"/></soap11:Body>
+</soap11:Envelope>`
+
+func TestCreateSAML2ECP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-adfs/protocols/saml2/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		if _, err := r.Cookie("_shibsession"); err != nil {
+			th.TestHeader(t, r, "PAOS", `ver="urn:liberty:paos:2003-08";"urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"`)
+			w.Header().Set("Content-Type", "application/vnd.paos+xml")
+			fmt.Fprintf(w, ecpAuthnRequest, th.Endpoint())
+			return
+		}
+		w.Header().Set("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, federatedTokenOutput)
+	})
+
+	th.Mux.HandleFunc("/idp/profile/SAML2/SOAP/ECP", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		user, pass, ok := r.BasicAuth()
+		th.AssertEquals(t, true, ok)
+		th.CheckEquals(t, "alice", user)
+		th.CheckEquals(t, "secret", pass)
+
+		body, err := io.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, false, strings.Contains(string(body), "S:Header"))
+		th.CheckEquals(t, true, strings.Contains(string(body), "AuthnRequest"))
+
+		fmt.Fprintf(w, ecpIdPResponse, th.Endpoint())
+	})
+
+	th.Mux.HandleFunc("/Shibboleth.sso/SAML2/ECP", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/vnd.paos+xml")
+
+		body, err := io.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, true, strings.Contains(string(body), ">ss:mem:relay</ecp:RelayState>"))
+		th.CheckEquals(t, false, strings.Contains(string(body), "AssertionConsumerServiceURL"))
+		th.CheckEquals(t, true, strings.Contains(string(body), `ID="_response"`))
+
+		http.SetCookie(w, &http.Cookie{Name: "_shibsession", Value: "session", Path: "/"})
+		w.Header().Set("Location", th.Endpoint()+"OS-FEDERATION/identity_providers/vnpay-adfs/protocols/saml2/auth")
+		w.WriteHeader(http.StatusFound)
+	})
+
+	opts := &federation.SAML2ECPAuthOptions{
+		IdentityProvider:    "vnpay-adfs",
+		IdentityProviderURL: th.Endpoint() + "idp/profile/SAML2/SOAP/ECP",
+		Username:            "alice",
+		Password:            "secret",
+	}
+
+	result := federation.Create(context.TODO(), federationClient(), opts)
+	tokenID, err := result.ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "unscoped-token", tokenID)
+
+	token, err := result.ExtractToken()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2014, token.ExpiresAt.Year())
+}
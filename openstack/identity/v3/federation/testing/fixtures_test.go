@@ -343,3 +343,247 @@ func HandleDeleteMappingSuccessfully(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+const IdentityProviderListOutput = `
+{
+    "identity_providers": [
+        {
+            "authorization_ttl": null,
+            "description": "VNPay SSO",
+            "domain_id": "d2b8e0",
+            "enabled": true,
+            "id": "vnpay-sso",
+            "remote_ids": ["https://sso.example.com/realms/vnpay"],
+            "links": {
+                "protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols",
+                "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso"
+            }
+        }
+    ],
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers"
+    }
+}
+`
+
+const IdentityProviderCreateRequest = `
+{
+    "identity_provider": {
+        "description": "VNPay SSO",
+        "domain_id": "d2b8e0",
+        "enabled": true,
+        "remote_ids": ["https://sso.example.com/realms/vnpay"]
+    }
+}
+`
+
+const IdentityProviderGetOutput = `
+{
+    "identity_provider": {
+        "authorization_ttl": null,
+        "description": "VNPay SSO",
+        "domain_id": "d2b8e0",
+        "enabled": true,
+        "id": "vnpay-sso",
+        "remote_ids": ["https://sso.example.com/realms/vnpay"],
+        "links": {
+            "protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso"
+        }
+    }
+}
+`
+
+const IdentityProviderUpdateRequest = `
+{
+    "identity_provider": {
+        "enabled": false
+    }
+}
+`
+
+const ProtocolListOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols"
+    },
+    "protocols": [
+        {
+            "id": "openid",
+            "mapping_id": "ACME",
+            "remote_id_attribute": "HTTP_OIDC_ISS",
+            "links": {
+                "identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso",
+                "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid"
+            }
+        }
+    ]
+}
+`
+
+const ProtocolCreateRequest = `
+{
+    "protocol": {
+        "mapping_id": "ACME",
+        "remote_id_attribute": "HTTP_OIDC_ISS"
+    }
+}
+`
+
+const ProtocolGetOutput = `
+{
+    "protocol": {
+        "id": "openid",
+        "mapping_id": "ACME",
+        "remote_id_attribute": "HTTP_OIDC_ISS",
+        "links": {
+            "identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid"
+        }
+    }
+}
+`
+
+const ServiceProviderListOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers"
+    },
+    "service_providers": [
+        {
+            "auth_url": "https://hcm02.example.com:5000/v3/OS-FEDERATION/identity_providers/hcm01/protocols/saml2/auth",
+            "description": "HCM02 region",
+            "enabled": true,
+            "id": "hcm02",
+            "relay_state_prefix": "ss:mem:",
+            "sp_url": "https://hcm02.example.com:5000/Shibboleth.sso/SAML2/ECP",
+            "links": {
+                "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/hcm02"
+            }
+        }
+    ]
+}
+`
+
+var IdentityProviderVNPay = federation.IdentityProvider{
+	ID:          "vnpay-sso",
+	Description: "VNPay SSO",
+	DomainID:    "d2b8e0",
+	Enabled:     true,
+	RemoteIDs:   []string{"https://sso.example.com/realms/vnpay"},
+	Links: map[string]any{
+		"protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols",
+		"self":      "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso",
+	},
+}
+
+var ProtocolOpenID = federation.Protocol{
+	ID:                "openid",
+	MappingID:         "ACME",
+	RemoteIDAttribute: "HTTP_OIDC_ISS",
+	Links: map[string]any{
+		"identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso",
+		"self":              "http://example.com/identity/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid",
+	},
+}
+
+var ServiceProviderHCM02 = federation.ServiceProvider{
+	ID:               "hcm02",
+	AuthURL:          "https://hcm02.example.com:5000/v3/OS-FEDERATION/identity_providers/hcm01/protocols/saml2/auth",
+	Description:      "HCM02 region",
+	Enabled:          true,
+	RelayStatePrefix: "ss:mem:",
+	SPURL:            "https://hcm02.example.com:5000/Shibboleth.sso/SAML2/ECP",
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/hcm02",
+	},
+}
+
+// HandleIdentityProvidersSuccessfully creates HTTP handlers at
+// `/OS-FEDERATION/identity_providers` on the test handler mux that test
+// identity provider listing, creation, retrieval, update and deletion.
+func HandleIdentityProvidersSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"enabled": "true"})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, IdentityProviderListOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-sso", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "PUT":
+			th.TestJSONRequest(t, r, IdentityProviderCreateRequest)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, IdentityProviderGetOutput)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, IdentityProviderGetOutput)
+		case "PATCH":
+			th.TestJSONRequest(t, r, IdentityProviderUpdateRequest)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, IdentityProviderGetOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleProtocolsSuccessfully creates HTTP handlers at
+// `/OS-FEDERATION/identity_providers/vnpay-sso/protocols` on the test handler
+// mux that test protocol listing, creation, retrieval and deletion.
+func HandleProtocolsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-sso/protocols", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ProtocolListOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "PUT":
+			th.TestJSONRequest(t, r, ProtocolCreateRequest)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, ProtocolGetOutput)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, ProtocolGetOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleListServiceProvidersSuccessfully creates an HTTP handler at
+// `/OS-FEDERATION/service_providers` on the test handler mux that responds
+// with a list of service providers.
+func HandleListServiceProvidersSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/service_providers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ServiceProviderListOutput)
+	})
+}
@@ -142,3 +142,76 @@ func TestDeleteMapping(t *testing.T) {
 	res := federation.DeleteMapping(context.TODO(), client.ServiceClient(), "ACME")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestIdentityProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleIdentityProvidersSuccessfully(t)
+
+	enabled := true
+	allPages, err := federation.ListIdentityProviders(client.ServiceClient(), federation.ListIdentityProvidersOpts{Enabled: &enabled}).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractIdentityProviders(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.IdentityProvider{IdentityProviderVNPay}, actual)
+
+	createOpts := federation.CreateIdentityProviderOpts{
+		Description: "VNPay SSO",
+		DomainID:    "d2b8e0",
+		Enabled:     &enabled,
+		RemoteIDs:   []string{"https://sso.example.com/realms/vnpay"},
+	}
+	idp, err := federation.CreateIdentityProvider(context.TODO(), client.ServiceClient(), "vnpay-sso", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, IdentityProviderVNPay, *idp)
+
+	idp, err = federation.GetIdentityProvider(context.TODO(), client.ServiceClient(), "vnpay-sso").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, IdentityProviderVNPay, *idp)
+
+	disabled := false
+	_, err = federation.UpdateIdentityProvider(context.TODO(), client.ServiceClient(), "vnpay-sso", federation.UpdateIdentityProviderOpts{Enabled: &disabled}).Extract()
+	th.AssertNoErr(t, err)
+
+	err = federation.DeleteIdentityProvider(context.TODO(), client.ServiceClient(), "vnpay-sso").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestProtocols(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleProtocolsSuccessfully(t)
+
+	allPages, err := federation.ListProtocols(client.ServiceClient(), "vnpay-sso").AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractProtocols(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.Protocol{ProtocolOpenID}, actual)
+
+	createOpts := federation.CreateProtocolOpts{
+		MappingID:         "ACME",
+		RemoteIDAttribute: "HTTP_OIDC_ISS",
+	}
+	protocol, err := federation.CreateProtocol(context.TODO(), client.ServiceClient(), "vnpay-sso", "openid", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ProtocolOpenID, *protocol)
+
+	protocol, err = federation.GetProtocol(context.TODO(), client.ServiceClient(), "vnpay-sso", "openid").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ProtocolOpenID, *protocol)
+
+	err = federation.DeleteProtocol(context.TODO(), client.ServiceClient(), "vnpay-sso", "openid").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestListServiceProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListServiceProvidersSuccessfully(t)
+
+	allPages, err := federation.ListServiceProviders(client.ServiceClient(), nil).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractServiceProviders(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.ServiceProvider{ServiceProviderHCM02}, actual)
+}
@@ -3,8 +3,12 @@ package federation
 import "github.com/vnpaycloud-console/gophercloud/v2"
 
 const (
-	rootPath     = "OS-FEDERATION"
-	mappingsPath = "mappings"
+	rootPath              = "OS-FEDERATION"
+	mappingsPath          = "mappings"
+	identityProvidersPath = "identity_providers"
+	protocolsPath         = "protocols"
+	serviceProvidersPath  = "service_providers"
+	authPath              = "auth"
 )
 
 func mappingsRootURL(c *gophercloud.ServiceClient) string {
@@ -14,3 +18,31 @@ func mappingsRootURL(c *gophercloud.ServiceClient) string {
 func mappingsResourceURL(c *gophercloud.ServiceClient, mappingID string) string {
 	return c.ServiceURL(rootPath, mappingsPath, mappingID)
 }
+
+func identityProvidersRootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, identityProvidersPath)
+}
+
+func identityProvidersResourceURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID)
+}
+
+func protocolsRootURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID, protocolsPath)
+}
+
+func protocolsResourceURL(c *gophercloud.ServiceClient, idpID, protocolID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID, protocolsPath, protocolID)
+}
+
+func protocolsAuthURL(c *gophercloud.ServiceClient, idpID, protocolID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID, protocolsPath, protocolID, authPath)
+}
+
+func serviceProvidersRootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, serviceProvidersPath)
+}
+
+func serviceProvidersResourceURL(c *gophercloud.ServiceClient, spID string) string {
+	return c.ServiceURL(rootPath, serviceProvidersPath, spID)
+}
@@ -22,6 +22,26 @@ Example to List Projects
 		fmt.Printf("%+v\n", project)
 	}
 
+Example to List the Projects available to the current token, and switch to
+one of them
+
+	allPages, err := projects.ListAvailable(identityClient).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	availableProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	projectProvider, err := openstack.Rescope(context.TODO(), provider, tokens.Scope{
+		ProjectID: availableProjects[0].ID,
+	})
+	if err != nil {
+		panic(err)
+	}
+
 Example to Create a Project
 
 	createOpts := projects.CreateOpts{
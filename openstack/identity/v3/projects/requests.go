@@ -87,6 +87,9 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 }
 
 // ListAvailable enumerates the Projects which are available to a specific user.
+// The user is the owner of the token of the client, whatever the scope of the
+// token, so the result can be used to pick a project to rescope the token to
+// with openstack.Rescope.
 func ListAvailable(client *gophercloud.ServiceClient) pagination.Pager {
 	url := listAvailableURL(client)
 	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
@@ -103,5 +103,31 @@ Example to Create a Token from a Username and Password with Project Name Scope
 	if err != nil {
 		panic(err)
 	}
+
+Example to Create a Token with a Password and a TOTP Passcode Requested on Demand
+
+	authOptions := tokens.AuthOptions{
+		UserID:   "username",
+		Password: "password",
+		PasscodeFunc: func(ctx context.Context) (string, error) {
+			return promptForPasscode()
+		},
+	}
+
+	result := tokens.Create(context.TODO(), identityClient, &authOptions)
+
+	var receiptErr tokens.ErrReceiptRequired
+	if errors.As(result.Err, &receiptErr) {
+		next, ok, err := tokens.ContinueWithPasscode(context.TODO(), &authOptions, receiptErr)
+		if err != nil || !ok {
+			panic(result.Err)
+		}
+		result = tokens.Create(context.TODO(), identityClient, next)
+	}
+
+	token, err := result.ExtractToken()
+	if err != nil {
+		panic(err)
+	}
 */
 package tokens
@@ -0,0 +1,98 @@
+package tokens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// receiptHeader is the header carrying auth receipts, in both directions.
+const receiptHeader = "Openstack-Auth-Receipt"
+
+// ErrReceiptRequired is returned by Create when the supplied authentication
+// methods were accepted, but do not satisfy any of the multi-factor
+// authentication rules of the user. The receipt must be sent back, in the
+// Receipt field of the AuthOptions, along with the missing methods.
+type ErrReceiptRequired struct {
+	gophercloud.ErrUnexpectedResponseCode
+
+	// Receipt is the auth receipt, to be passed back as AuthOptions.Receipt.
+	Receipt string
+
+	// Methods are the authentication methods already satisfied.
+	Methods []string
+
+	// RequiredAuthMethods are the rules, each a list of methods, of which one
+	// must be fully satisfied.
+	RequiredAuthMethods [][]string
+
+	// User is the user the receipt was issued for.
+	User User
+
+	// ExpiresAt is the time after which the receipt is not accepted anymore.
+	ExpiresAt time.Time
+}
+
+func (e ErrReceiptRequired) Error() string {
+	return fmt.Sprintf("Additional authentication methods are required: %v (already satisfied: %v)", e.RequiredAuthMethods, e.Methods)
+}
+
+// Unwrap returns the underlying ErrUnexpectedResponseCode, so that
+// gophercloud.ResponseCodeIs(err, http.StatusUnauthorized) holds.
+func (e ErrReceiptRequired) Unwrap() error {
+	return e.ErrUnexpectedResponseCode
+}
+
+// Requires reports whether method is part of a rule that is not yet
+// satisfied.
+func (e ErrReceiptRequired) Requires(method string) bool {
+	if slices.Contains(e.Methods, method) {
+		return false
+	}
+	for _, rule := range e.RequiredAuthMethods {
+		if slices.Contains(rule, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// receiptError turns a 401 response carrying an auth receipt into an
+// ErrReceiptRequired. Other errors are returned unchanged.
+func receiptError(err error) error {
+	var codeErr gophercloud.ErrUnexpectedResponseCode
+	if !errors.As(err, &codeErr) || codeErr.Actual != http.StatusUnauthorized {
+		return err
+	}
+
+	receipt := codeErr.ResponseHeader.Get(receiptHeader)
+	if receipt == "" {
+		return err
+	}
+
+	var body struct {
+		Receipt struct {
+			Methods   []string  `json:"methods"`
+			User      User      `json:"user"`
+			ExpiresAt time.Time `json:"expires_at"`
+		} `json:"receipt"`
+		RequiredAuthMethods [][]string `json:"required_auth_methods"`
+	}
+	if jsonErr := json.Unmarshal(codeErr.Body, &body); jsonErr != nil {
+		return err
+	}
+
+	return ErrReceiptRequired{
+		ErrUnexpectedResponseCode: codeErr,
+		Receipt:                   receipt,
+		Methods:                   body.Receipt.Methods,
+		RequiredAuthMethods:       body.RequiredAuthMethods,
+		User:                      body.Receipt.User,
+		ExpiresAt:                 body.Receipt.ExpiresAt,
+	}
+}
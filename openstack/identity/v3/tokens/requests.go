@@ -46,6 +46,16 @@ type AuthOptions struct {
 	// Passcode is used in TOTP authentication method
 	Passcode string `json:"passcode,omitempty"`
 
+	// PasscodeFunc, if set, is called to obtain a TOTP passcode when the
+	// identity service answers with an auth receipt requiring the totp
+	// method. When AllowReauth is set, it is also called on each
+	// reauthentication; see gophercloud.AuthOptions.PasscodeFunc.
+	PasscodeFunc func(ctx context.Context) (string, error) `json:"-"`
+
+	// Receipt is an auth receipt returned by a previous, partially
+	// successful authentication attempt. See ErrReceiptRequired.
+	Receipt string `json:"-"`
+
 	// At most one of DomainID and DomainName must be provided if using Username
 	// with Identity V3. Otherwise, either are optional.
 	DomainID   string `json:"-"`
@@ -78,6 +88,7 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]an
 		UserID:                      opts.UserID,
 		Password:                    opts.Password,
 		Passcode:                    opts.Passcode,
+		Receipt:                     opts.Receipt,
 		DomainID:                    opts.DomainID,
 		DomainName:                  opts.DomainName,
 		AllowReauth:                 opts.AllowReauth,
@@ -113,9 +124,13 @@ func (opts *AuthOptions) CanReauth() bool {
 }
 
 // ToTokenV3HeadersMap allows AuthOptions to satisfy the AuthOptionsBuilder
-// interface in the v3 tokens package.
+// interface in the v3 tokens package. It sets the auth receipt header when
+// Receipt is set.
 func (opts *AuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
-	return nil, nil
+	if opts.Receipt == "" {
+		return nil, nil
+	}
+	return map[string]string{receiptHeader: opts.Receipt}, nil
 }
 
 func subjectTokenHeaders(subjectToken string) map[string]string {
@@ -126,6 +141,11 @@ func subjectTokenHeaders(subjectToken string) map[string]string {
 
 // Create authenticates and either generates a new token, or changes the Scope
 // of an existing token.
+//
+// If the identity service requires more authentication methods to satisfy
+// a multi-factor authentication rule, the error of the result is an
+// ErrReceiptRequired, carrying the auth receipt to send along with the
+// missing methods.
 func Create(ctx context.Context, c *gophercloud.ServiceClient, opts AuthOptionsBuilder) (r CreateResult) {
 	scope, err := opts.ToTokenV3ScopeMap()
 	if err != nil {
@@ -139,13 +159,72 @@ func Create(ctx context.Context, c *gophercloud.ServiceClient, opts AuthOptionsB
 		return
 	}
 
+	h, err := opts.ToTokenV3HeadersMap(map[string]any{
+		"method": "POST",
+		"url":    tokenURL(c),
+	})
+	if err != nil {
+		r.Err = err
+		return
+	}
+
 	resp, err := c.Post(ctx, tokenURL(c), b, &r.Body, &gophercloud.RequestOpts{
+		MoreHeaders: h,
 		OmitHeaders: []string{"X-Auth-Token"},
 	})
 	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if r.Err != nil {
+		r.Err = receiptError(r.Err)
+	}
 	return
 }
 
+// ContinueWithPasscode returns a copy of the AuthOptions carrying the auth
+// receipt of the given error and a passcode obtained from PasscodeFunc, ready
+// to be passed to Create again. When the AuthOptions name no user, as with
+// application credentials, UserID is set to the user of the receipt, whom
+// the passcode is checked against. It returns false if the receipt does not
+// require the totp method or if PasscodeFunc is not set.
+func ContinueWithPasscode(ctx context.Context, opts AuthOptionsBuilder, receipt ErrReceiptRequired) (AuthOptionsBuilder, bool, error) {
+	if !receipt.Requires("totp") {
+		return nil, false, nil
+	}
+
+	switch o := opts.(type) {
+	case *gophercloud.AuthOptions:
+		if o.PasscodeFunc == nil {
+			return nil, false, nil
+		}
+		passcode, err := o.PasscodeFunc(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		next := *o
+		next.Receipt = receipt.Receipt
+		next.Passcode = passcode
+		if next.UserID == "" && next.Username == "" {
+			next.UserID = receipt.User.ID
+		}
+		return &next, true, nil
+	case *AuthOptions:
+		if o.PasscodeFunc == nil {
+			return nil, false, nil
+		}
+		passcode, err := o.PasscodeFunc(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		next := *o
+		next.Receipt = receipt.Receipt
+		next.Passcode = passcode
+		if next.UserID == "" && next.Username == "" {
+			next.UserID = receipt.User.ID
+		}
+		return &next, true, nil
+	}
+	return nil, false, nil
+}
+
 // Get validates and retrieves information about another token.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, token string) (r GetResult) {
 	resp, err := c.Get(ctx, tokenURL(c), &r.Body, &gophercloud.RequestOpts{
@@ -110,6 +110,29 @@ const TokenOutput = `
    }
 }`
 
+// ReceiptOutput is a sample response to a token creation request that only
+// satisfied part of a multi-factor authentication rule.
+const ReceiptOutput = `
+{
+	"receipt": {
+		"methods": ["password"],
+		"user": {
+			"domain": {
+				"id": "default",
+				"name": "Default"
+			},
+			"id": "someuser",
+			"name": "admin"
+		},
+		"expires_at": "2018-07-05T08:39:23.000000Z",
+		"issued_at": "2018-07-05T08:34:23.000000Z"
+	},
+	"required_auth_methods": [
+		["password", "totp"]
+	]
+}
+`
+
 const DomainToken = `
 {
   "token": {
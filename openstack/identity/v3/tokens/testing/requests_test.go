@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -512,6 +513,149 @@ func TestCreatePasswordTOTPProjectNameAndDomainNameScope(t *testing.T) {
 	`)
 }
 
+func TestCreateApplicationCredentialTOTP(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialID: "12345abcdef", ApplicationCredentialSecret: "mysecret", UserID: "someuser", Passcode: "12345678"}
+	authTokenPost(t, options, nil, `
+		{
+			"auth": {
+				"identity": {
+					"application_credential": {
+						"id": "12345abcdef",
+						"secret": "mysecret"
+					},
+					"methods": ["application_credential", "totp"],
+					"totp": {
+						"user": {
+							"id": "someuser",
+							"passcode": "12345678"
+						}
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateReceiptRequired(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+
+		if r.Header.Get("Openstack-Auth-Receipt") == "" {
+			th.TestJSONRequest(t, r, `{"auth": {"identity": {"methods": ["password"], "password": {"user": {"id": "someuser", "password": "somepassword"}}}}}`)
+			w.Header().Add("Openstack-Auth-Receipt", "receipt-id")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, ReceiptOutput)
+			return
+		}
+
+		th.TestHeader(t, r, "Openstack-Auth-Receipt", "receipt-id")
+		th.TestJSONRequest(t, r, `{
+			"auth": {
+				"identity": {
+					"methods": ["password", "totp"],
+					"password": {"user": {"id": "someuser", "password": "somepassword"}},
+					"totp": {"user": {"id": "someuser", "passcode": "12345678"}}
+				}
+			}
+		}`)
+		w.Header().Add("X-Subject-Token", "token-id")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token": {"expires_at": "2014-10-02T13:45:00.000000Z"}}`)
+	})
+
+	options := &tokens.AuthOptions{
+		UserID:   "someuser",
+		Password: "somepassword",
+		PasscodeFunc: func(context.Context) (string, error) {
+			return "12345678", nil
+		},
+	}
+
+	err := tokens.Create(context.TODO(), &client, options).Err
+	var receiptErr tokens.ErrReceiptRequired
+	th.AssertEquals(t, true, errors.As(err, &receiptErr))
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(err, http.StatusUnauthorized))
+	th.CheckEquals(t, "receipt-id", receiptErr.Receipt)
+	th.CheckDeepEquals(t, []string{"password"}, receiptErr.Methods)
+	th.CheckDeepEquals(t, [][]string{{"password", "totp"}}, receiptErr.RequiredAuthMethods)
+	th.CheckEquals(t, "someuser", receiptErr.User.ID)
+	th.CheckEquals(t, true, receiptErr.Requires("totp"))
+	th.CheckEquals(t, false, receiptErr.Requires("password"))
+
+	next, ok, err := tokens.ContinueWithPasscode(context.TODO(), options, receiptErr)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, ok)
+
+	token, err := tokens.Create(context.TODO(), &client, next).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "token-id", token)
+}
+
+func TestCreateReceiptRequiredApplicationCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+
+		if r.Header.Get("Openstack-Auth-Receipt") == "" {
+			th.TestJSONRequest(t, r, `{"auth": {"identity": {"methods": ["application_credential"], "application_credential": {"id": "12345abcdef", "secret": "mysecret"}}}}`)
+			w.Header().Add("Openstack-Auth-Receipt", "receipt-id")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, ReceiptOutput)
+			return
+		}
+
+		th.TestHeader(t, r, "Openstack-Auth-Receipt", "receipt-id")
+		th.TestJSONRequest(t, r, `{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential", "totp"],
+					"application_credential": {"id": "12345abcdef", "secret": "mysecret"},
+					"totp": {"user": {"id": "someuser", "passcode": "12345678"}}
+				}
+			}
+		}`)
+		w.Header().Add("X-Subject-Token", "token-id")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token": {"expires_at": "2014-10-02T13:45:00.000000Z"}}`)
+	})
+
+	options := &tokens.AuthOptions{
+		ApplicationCredentialID:     "12345abcdef",
+		ApplicationCredentialSecret: "mysecret",
+		PasscodeFunc: func(context.Context) (string, error) {
+			return "12345678", nil
+		},
+	}
+
+	err := tokens.Create(context.TODO(), &client, options).Err
+	var receiptErr tokens.ErrReceiptRequired
+	th.AssertEquals(t, true, errors.As(err, &receiptErr))
+
+	next, ok, err := tokens.ContinueWithPasscode(context.TODO(), options, receiptErr)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, ok)
+	th.CheckEquals(t, "", options.UserID)
+
+	token, err := tokens.Create(context.TODO(), &client, next).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "token-id", token)
+}
+
 func TestCreateExtractsTokenFromResponse(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
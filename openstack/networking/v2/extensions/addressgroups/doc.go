@@ -0,0 +1,79 @@
+/*
+Package addressgroups provides information and interaction with the address
+groups extension for the OpenStack Networking service.
+
+An address group is a set of CIDRs which security group rules match with
+their RemoteAddressGroupID, so that a large allow-list is managed in one
+place instead of one rule per address.
+
+Example to List Address Groups
+
+	allPages, err := addressgroups.List(networkClient, addressgroups.ListOpts{}).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allGroups, err := addressgroups.ExtractAddressGroups(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, group := range allGroups {
+		fmt.Printf("%+v\n", group)
+	}
+
+Example to Create an Address Group
+
+	createOpts := addressgroups.CreateOpts{
+		Name:      "partners",
+		Addresses: []string{"192.0.2.0/24", "198.51.100.7/32"},
+	}
+
+	group, err := addressgroups.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Allow HTTPS from an Address Group
+
+	ruleOpts := rules.CreateOpts{
+		Direction:            rules.DirIngress,
+		EtherType:            rules.EtherType4,
+		SecGroupID:           "85cc3048-abc3-43cc-89b3-377341426ac5",
+		Protocol:             rules.ProtocolTCP,
+		PortRangeMin:         443,
+		PortRangeMax:         443,
+		RemoteAddressGroupID: group.ID,
+	}
+
+	rule, err := rules.Create(context.TODO(), networkClient, ruleOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Add Addresses to an Address Group
+
+	addressesOpts := addressgroups.AddressesOpts{
+		Addresses: []string{"203.0.113.0/25"},
+	}
+
+	group, err := addressgroups.AddAddresses(context.TODO(), networkClient, "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11", addressesOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Remove Addresses from an Address Group
+
+	group, err := addressgroups.RemoveAddresses(context.TODO(), networkClient, "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11", addressesOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an Address Group
+
+	err := addressgroups.Delete(context.TODO(), networkClient, "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package addressgroups
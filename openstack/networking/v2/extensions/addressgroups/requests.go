@@ -0,0 +1,195 @@
+package addressgroups
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToAddressGroupListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the address group attributes you want to see returned. SortKey allows you to
+// sort by a particular address group attribute. SortDir sets the direction,
+// and is either `asc' or `desc'. Marker and Limit are used for pagination.
+type ListOpts struct {
+	ID          string `q:"id"`
+	Name        string `q:"name"`
+	Description string `q:"description"`
+	ProjectID   string `q:"project_id"`
+	Limit       int    `q:"limit"`
+	Marker      string `q:"marker"`
+	SortKey     string `q:"sort_key"`
+	SortDir     string `q:"sort_dir"`
+}
+
+// ToAddressGroupListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToAddressGroupListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over a collection of
+// address groups. It accepts a ListOpts struct, which allows you to filter and
+// sort the returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToAddressGroupListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return AddressGroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific address group based on its unique ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToAddressGroupCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new address
+// group.
+type CreateOpts struct {
+	// Name is a human-readable name of the address group.
+	Name string `json:"name,omitempty"`
+
+	// Description of the address group.
+	Description string `json:"description,omitempty"`
+
+	// ProjectID is the project owner of the address group. Only
+	// administrative users can specify a project other than their own.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Addresses are the CIDRs of the address group. A single address is a
+	// CIDR with a /32 or /128 prefix length.
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// ToAddressGroupCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToAddressGroupCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "address_group")
+}
+
+// Create accepts a CreateOpts struct and creates a new address group using
+// the values provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToAddressGroupCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToAddressGroupUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts represents the attributes used when updating an existing
+// address group. Use AddAddresses and RemoveAddresses to change its
+// addresses.
+type UpdateOpts struct {
+	// Name is a human-readable name of the address group.
+	Name *string `json:"name,omitempty"`
+
+	// Description of the address group.
+	Description *string `json:"description,omitempty"`
+}
+
+// ToAddressGroupUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToAddressGroupUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "address_group")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing address group
+// using the values provided.
+func Update(ctx context.Context, c *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToAddressGroupUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, resourceURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the address group associated with
+// it. An address group used by security group rules can't be deleted.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// AddressesOptsBuilder allows extensions to add additional parameters to the
+// AddAddresses and RemoveAddresses requests.
+type AddressesOptsBuilder interface {
+	ToAddressGroupAddressesMap() (map[string]any, error)
+}
+
+// AddressesOpts represents the addresses to add to or to remove from an
+// address group.
+type AddressesOpts struct {
+	// Addresses are the CIDRs to add or to remove.
+	Addresses []string `json:"addresses" required:"true"`
+}
+
+// ToAddressGroupAddressesMap builds a request body from AddressesOpts.
+func (opts AddressesOpts) ToAddressGroupAddressesMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// AddAddresses adds addresses to an address group. The security group rules
+// referencing the address group are updated by the networking service.
+func AddAddresses(ctx context.Context, c *gophercloud.ServiceClient, id string, opts AddressesOptsBuilder) (r AddAddressesResult) {
+	b, err := opts.ToAddressGroupAddressesMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, addAddressesURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// RemoveAddresses removes addresses from an address group. Removing an
+// address which is not in the address group fails.
+func RemoveAddresses(ctx context.Context, c *gophercloud.ServiceClient, id string, opts AddressesOptsBuilder) (r RemoveAddressesResult) {
+	b, err := opts.ToAddressGroupAddressesMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, removeAddressesURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
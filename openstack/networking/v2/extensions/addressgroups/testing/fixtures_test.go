@@ -0,0 +1,117 @@
+package testing
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/addressgroups"
+)
+
+const AddressGroupID = "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11"
+
+// AddressGroupListResult is the response of a list request.
+const AddressGroupListResult = `
+{
+	"address_groups": [
+		{
+			"id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+			"name": "partners",
+			"description": "partner networks",
+			"project_id": "45977fa2dbd7482098dd68d0d8970117",
+			"addresses": ["192.0.2.0/24", "198.51.100.7/32"]
+		}
+	]
+}
+`
+
+// AddressGroupGetResult is the response of a get request.
+const AddressGroupGetResult = `
+{
+	"address_group": {
+		"id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+		"name": "partners",
+		"description": "partner networks",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"addresses": ["192.0.2.0/24", "198.51.100.7/32"]
+	}
+}
+`
+
+// AddressGroupCreateRequest is the request of a create request.
+const AddressGroupCreateRequest = `
+{
+	"address_group": {
+		"name": "partners",
+		"description": "partner networks",
+		"addresses": ["192.0.2.0/24", "198.51.100.7/32"]
+	}
+}
+`
+
+// AddressGroupUpdateRequest is the request of an update request.
+const AddressGroupUpdateRequest = `
+{
+	"address_group": {
+		"name": "suppliers"
+	}
+}
+`
+
+// AddressGroupUpdateResult is the response of an update request.
+const AddressGroupUpdateResult = `
+{
+	"address_group": {
+		"id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+		"name": "suppliers",
+		"description": "partner networks",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"addresses": ["192.0.2.0/24", "198.51.100.7/32"]
+	}
+}
+`
+
+// AddAddressesRequest is the request of an add addresses request.
+const AddAddressesRequest = `
+{
+	"addresses": ["203.0.113.0/25"]
+}
+`
+
+// AddAddressesResult is the response of an add addresses request.
+const AddAddressesResult = `
+{
+	"address_group": {
+		"id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+		"name": "partners",
+		"description": "partner networks",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"addresses": ["192.0.2.0/24", "198.51.100.7/32", "203.0.113.0/25"]
+	}
+}
+`
+
+// RemoveAddressesRequest is the request of a remove addresses request.
+const RemoveAddressesRequest = `
+{
+	"addresses": ["198.51.100.7/32"]
+}
+`
+
+// RemoveAddressesResult is the response of a remove addresses request.
+const RemoveAddressesResult = `
+{
+	"address_group": {
+		"id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+		"name": "partners",
+		"description": "partner networks",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"addresses": ["192.0.2.0/24"]
+	}
+}
+`
+
+// Partners is the address group of the responses.
+var Partners = addressgroups.AddressGroup{
+	ID:          AddressGroupID,
+	Name:        "partners",
+	Description: "partner networks",
+	ProjectID:   "45977fa2dbd7482098dd68d0d8970117",
+	Addresses:   []string{"192.0.2.0/24", "198.51.100.7/32"},
+}
@@ -0,0 +1,179 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/addressgroups"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"name": "partners"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, AddressGroupListResult)
+	})
+
+	count := 0
+	err := addressgroups.List(fake.ServiceClient(), addressgroups.ListOpts{Name: "partners"}).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := addressgroups.ExtractAddressGroups(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []addressgroups.AddressGroup{Partners}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+AddressGroupID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, AddressGroupGetResult)
+	})
+
+	group, err := addressgroups.Get(context.TODO(), fake.ServiceClient(), AddressGroupID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Partners, *group)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, AddressGroupCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, AddressGroupGetResult)
+	})
+
+	group, err := addressgroups.Create(context.TODO(), fake.ServiceClient(), addressgroups.CreateOpts{
+		Name:        "partners",
+		Description: "partner networks",
+		Addresses:   []string{"192.0.2.0/24", "198.51.100.7/32"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Partners, *group)
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+AddressGroupID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, AddressGroupUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, AddressGroupUpdateResult)
+	})
+
+	name := "suppliers"
+	group, err := addressgroups.Update(context.TODO(), fake.ServiceClient(), AddressGroupID, addressgroups.UpdateOpts{
+		Name: &name,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "suppliers", group.Name)
+}
+
+func TestAddAddresses(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+AddressGroupID+"/add_addresses", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, AddAddressesRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, AddAddressesResult)
+	})
+
+	group, err := addressgroups.AddAddresses(context.TODO(), fake.ServiceClient(), AddressGroupID, addressgroups.AddressesOpts{
+		Addresses: []string{"203.0.113.0/25"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"192.0.2.0/24", "198.51.100.7/32", "203.0.113.0/25"}, group.Addresses)
+}
+
+func TestRemoveAddresses(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+AddressGroupID+"/remove_addresses", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, RemoveAddressesRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, RemoveAddressesResult)
+	})
+
+	group, err := addressgroups.RemoveAddresses(context.TODO(), fake.ServiceClient(), AddressGroupID, addressgroups.AddressesOpts{
+		Addresses: []string{"198.51.100.7/32"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"192.0.2.0/24"}, group.Addresses)
+}
+
+func TestRequiredAddressesOpts(t *testing.T) {
+	res := addressgroups.AddAddresses(context.TODO(), fake.ServiceClient(), AddressGroupID, addressgroups.AddressesOpts{})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+AddressGroupID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := addressgroups.Delete(context.TODO(), fake.ServiceClient(), AddressGroupID)
+	th.AssertNoErr(t, res.Err)
+}
@@ -0,0 +1,30 @@
+/*
+Package autoallocatedtopology provides access to the auto-allocated-topology
+extension of the OpenStack Networking service, which provisions a ready to
+use network, connected to the default external network through a router,
+for a project.
+
+Example to Check that a Topology can be Auto-Allocated
+
+	err := autoallocatedtopology.Validate(context.TODO(), networkClient, "45977fa2dbd7482098dd68d0d8970117").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get the Auto-Allocated Network of a Project
+
+	topology, err := autoallocatedtopology.Get(context.TODO(), networkClient, "45977fa2dbd7482098dd68d0d8970117").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Network: %s\n", topology.ID)
+
+Example to Delete the Auto-Allocated Topology of a Project
+
+	err := autoallocatedtopology.Delete(context.TODO(), networkClient, "45977fa2dbd7482098dd68d0d8970117").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package autoallocatedtopology
@@ -0,0 +1,36 @@
+package autoallocatedtopology
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// Get retrieves the auto-allocated topology of a project, allocating it
+// first if the project has none: a network with a subnet from the default
+// subnet pools, and a router with a gateway on the default external network.
+// Provisioning can take several seconds.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, projectID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Validate checks, without allocating anything, that the topology of a
+// project can be auto-allocated. The networking service fails the request
+// with a 409 Conflict naming the missing requirement, such as a default
+// external network or default subnet pools.
+func Validate(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r ValidateResult) {
+	resp, err := c.Get(ctx, resourceURL(c, projectID)+"?fields=dry-run", &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes the auto-allocated topology of a project: its router, its
+// network and its subnets. It fails if ports other than the ones of the
+// router and DHCP are on the network.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, projectID string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, projectID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,43 @@
+package autoallocatedtopology
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Topology.
+type GetResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a Topology.
+func (r GetResult) Extract() (*Topology, error) {
+	var s Topology
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r GetResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "auto_allocated_topology")
+}
+
+// ValidateResult represents the result of a validate operation. Call its
+// ExtractErr method to determine if the topology can be auto-allocated.
+type ValidateResult struct {
+	gophercloud.ErrResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Topology represents the auto-allocated topology of a project.
+type Topology struct {
+	// ID is the ID of the auto-allocated network.
+	ID string `json:"id"`
+
+	// ProjectID is the project owner of the topology.
+	ProjectID string `json:"project_id"`
+}
@@ -0,0 +1,34 @@
+package testing
+
+const ProjectID = "45977fa2dbd7482098dd68d0d8970117"
+
+// GetResponse is the response of a get request.
+const GetResponse = `
+{
+	"auto_allocated_topology": {
+		"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"tenant_id": "45977fa2dbd7482098dd68d0d8970117",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117"
+	}
+}
+`
+
+// ValidateResponse is the response of a successful validate request.
+const ValidateResponse = `
+{
+	"auto_allocated_topology": {
+		"dry-run": "pass"
+	}
+}
+`
+
+// ValidateConflictResponse is the response of a failed validate request.
+const ValidateConflictResponse = `
+{
+	"NeutronError": {
+		"type": "AutoAllocationFailure",
+		"message": "Deployment error: No default router:external network.",
+		"detail": ""
+	}
+}
+`
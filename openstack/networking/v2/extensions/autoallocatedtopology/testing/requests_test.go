@@ -0,0 +1,88 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/autoallocatedtopology"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/auto-allocated-topology/"+ProjectID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, GetResponse)
+	})
+
+	topology, err := autoallocatedtopology.Get(context.TODO(), fake.ServiceClient(), ProjectID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, autoallocatedtopology.Topology{
+		ID:        "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		ProjectID: ProjectID,
+	}, *topology)
+}
+
+func TestValidate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/auto-allocated-topology/"+ProjectID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"fields": "dry-run"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ValidateResponse)
+	})
+
+	err := autoallocatedtopology.Validate(context.TODO(), fake.ServiceClient(), ProjectID).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestValidateConflict(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/auto-allocated-topology/"+ProjectID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestFormValues(t, r, map[string]string{"fields": "dry-run"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+
+		fmt.Fprint(w, ValidateConflictResponse)
+	})
+
+	err := autoallocatedtopology.Validate(context.TODO(), fake.ServiceClient(), ProjectID).ExtractErr()
+	if !gophercloud.ResponseCodeIs(err, http.StatusConflict) {
+		t.Fatalf("expected a 409 error, got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/auto-allocated-topology/"+ProjectID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := autoallocatedtopology.Delete(context.TODO(), fake.ServiceClient(), ProjectID)
+	th.AssertNoErr(t, res.Err)
+}
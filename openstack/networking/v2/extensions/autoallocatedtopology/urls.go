@@ -0,0 +1,7 @@
+package autoallocatedtopology
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+func resourceURL(c *gophercloud.ServiceClient, projectID string) string {
+	return c.ServiceURL("auto-allocated-topology", projectID)
+}
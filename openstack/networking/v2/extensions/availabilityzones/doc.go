@@ -0,0 +1,43 @@
+/*
+Package availabilityzones lists the availability zones of the OpenStack
+Networking service, which the AvailabilityZoneHints of networks and routers
+name.
+
+Example to List the Availability Zones of Routers
+
+	listOpts := availabilityzones.ListOpts{
+		Resource: availabilityzones.ResourceRouter,
+	}
+
+	allPages, err := availabilityzones.List(networkClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allZones, err := availabilityzones.ExtractAvailabilityZones(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, zone := range allZones {
+		fmt.Printf("%s: %s\n", zone.Name, zone.State)
+	}
+
+Example to Validate the Availability Zone Hints of a Network
+
+	createOpts := networks.CreateOpts{
+		Name:                  "ha-network",
+		AvailabilityZoneHints: []string{"az1", "az2"},
+	}
+
+	err := availabilityzones.ValidateHints(context.TODO(), networkClient, availabilityzones.ResourceNetwork, createOpts.AvailabilityZoneHints)
+	if err != nil {
+		panic(err)
+	}
+
+	network, err := networks.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+*/
+package availabilityzones
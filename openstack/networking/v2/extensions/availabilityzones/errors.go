@@ -0,0 +1,34 @@
+package availabilityzones
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrUnavailableHints is returned by ValidateHints when availability zone
+// hints name availability zones which don't exist for the resource, or
+// which are unavailable.
+type ErrUnavailableHints struct {
+	gophercloud.BaseError
+
+	Resource Resource
+
+	// Unknown are the hints naming no availability zone of the resource.
+	Unknown []string
+
+	// Unavailable are the hints naming unavailable availability zones.
+	Unavailable []string
+}
+
+func (e ErrUnavailableHints) Error() string {
+	var problems []string
+	if len(e.Unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("unknown availability zones %s", strings.Join(e.Unknown, ", ")))
+	}
+	if len(e.Unavailable) > 0 {
+		problems = append(problems, fmt.Sprintf("unavailable availability zones %s", strings.Join(e.Unavailable, ", ")))
+	}
+	return fmt.Sprintf("invalid %s availability zone hints: %s", e.Resource, strings.Join(problems, "; "))
+}
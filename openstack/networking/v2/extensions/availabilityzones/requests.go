@@ -0,0 +1,68 @@
+package availabilityzones
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// Resource is the type of the resources an availability zone schedules.
+type Resource string
+
+const (
+	// ResourceNetwork is the resource of the availability zones of the DHCP
+	// agents, used by the AvailabilityZoneHints of networks.
+	ResourceNetwork Resource = "network"
+
+	// ResourceRouter is the resource of the availability zones of the L3
+	// agents, used by the AvailabilityZoneHints of routers.
+	ResourceRouter Resource = "router"
+)
+
+// State is the state of an availability zone.
+type State string
+
+const (
+	// StateAvailable is the state of an availability zone with an alive
+	// agent.
+	StateAvailable State = "available"
+
+	// StateUnavailable is the state of an availability zone whose agents
+	// are all down.
+	StateUnavailable State = "unavailable"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToAvailabilityZoneListQuery() (string, error)
+}
+
+// ListOpts allows the filtering of the availability zones through the API.
+type ListOpts struct {
+	Name     string   `q:"name"`
+	Resource Resource `q:"resource"`
+	State    State    `q:"state"`
+}
+
+// ToAvailabilityZoneListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToAvailabilityZoneListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over the availability
+// zones of the networking service. An availability zone is listed once per
+// resource it schedules.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(c)
+	if opts != nil {
+		query, err := opts.ToAvailabilityZoneListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return AvailabilityZonePage{pagination.SinglePageBase(r)}
+	})
+}
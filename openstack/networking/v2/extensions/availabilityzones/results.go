@@ -0,0 +1,46 @@
+package availabilityzones
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// AvailabilityZone represents an availability zone of the networking
+// service for a type of resource.
+type AvailabilityZone struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+
+	// Resource is the type of the resources the availability zone schedules.
+	Resource Resource `json:"resource"`
+
+	// State is the state of the availability zone.
+	State State `json:"state"`
+}
+
+// AvailabilityZonePage is the page returned by a pager when traversing over
+// a collection of availability zones.
+type AvailabilityZonePage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty checks whether an AvailabilityZonePage struct is empty.
+func (r AvailabilityZonePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	v, err := ExtractAvailabilityZones(r)
+	return len(v) == 0, err
+}
+
+// ExtractAvailabilityZones accepts a Page struct, specifically an
+// AvailabilityZonePage struct, and extracts the elements into a slice of
+// AvailabilityZone structs.
+func ExtractAvailabilityZones(r pagination.Page) ([]AvailabilityZone, error) {
+	var s struct {
+		AvailabilityZones []AvailabilityZone `json:"availability_zones"`
+	}
+
+	err := (r.(AvailabilityZonePage)).ExtractInto(&s)
+	return s.AvailabilityZones, err
+}
@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+// ListResponse is the response of a list request for the router
+// availability zones.
+const ListResponse = `
+{
+	"availability_zones": [
+		{
+			"name": "az1",
+			"resource": "router",
+			"state": "available"
+		},
+		{
+			"name": "az2",
+			"resource": "router",
+			"state": "unavailable"
+		}
+	]
+}
+`
+
+// HandleList serves the router availability zones.
+func HandleList(t *testing.T) {
+	th.Mux.HandleFunc("/v2.0/availability_zones", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"resource": "router"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ListResponse)
+	})
+}
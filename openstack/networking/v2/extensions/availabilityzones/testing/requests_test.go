@@ -0,0 +1,51 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/availabilityzones"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleList(t)
+
+	allPages, err := availabilityzones.List(fake.ServiceClient(), availabilityzones.ListOpts{
+		Resource: availabilityzones.ResourceRouter,
+	}).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := availabilityzones.ExtractAvailabilityZones(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []availabilityzones.AvailabilityZone{
+		{Name: "az1", Resource: availabilityzones.ResourceRouter, State: availabilityzones.StateAvailable},
+		{Name: "az2", Resource: availabilityzones.ResourceRouter, State: availabilityzones.StateUnavailable},
+	}, actual)
+}
+
+func TestValidateHints(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleList(t)
+
+	err := availabilityzones.ValidateHints(context.TODO(), fake.ServiceClient(), availabilityzones.ResourceRouter, []string{"az1"})
+	th.AssertNoErr(t, err)
+
+	err = availabilityzones.ValidateHints(context.TODO(), fake.ServiceClient(), availabilityzones.ResourceRouter, []string{"az1", "az2", "az3"})
+	var invalid availabilityzones.ErrUnavailableHints
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected an ErrUnavailableHints, got %v", err)
+	}
+	th.CheckDeepEquals(t, []string{"az3"}, invalid.Unknown)
+	th.CheckDeepEquals(t, []string{"az2"}, invalid.Unavailable)
+	th.AssertEquals(t, "invalid router availability zone hints: unknown availability zones az3; unavailable availability zones az2", err.Error())
+}
+
+func TestValidateNoHints(t *testing.T) {
+	err := availabilityzones.ValidateHints(context.TODO(), fake.ServiceClient(), availabilityzones.ResourceNetwork, nil)
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,53 @@
+package availabilityzones
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ValidateHints checks the AvailabilityZoneHints of a network or a router
+// before creating it: it returns an ErrUnavailableHints if a hint names no
+// availability zone of the resource, or an unavailable one.
+//
+// The networking service only checks that the hints exist, so that a
+// resource hinted to an availability zone whose agents are all down is
+// created but not scheduled.
+func ValidateHints(ctx context.Context, client *gophercloud.ServiceClient, resource Resource, hints []string) error {
+	if resource == "" {
+		return gophercloud.ErrMissingInput{Argument: "resource"}
+	}
+	if len(hints) == 0 {
+		return nil
+	}
+
+	allPages, err := List(client, ListOpts{Resource: resource}).AllPages(ctx)
+	if err != nil {
+		return err
+	}
+	zones, err := ExtractAvailabilityZones(allPages)
+	if err != nil {
+		return err
+	}
+	states := make(map[string]State, len(zones))
+	for _, zone := range zones {
+		if zone.Resource == resource {
+			states[zone.Name] = zone.State
+		}
+	}
+
+	invalid := ErrUnavailableHints{Resource: resource}
+	for _, hint := range hints {
+		state, ok := states[hint]
+		switch {
+		case !ok:
+			invalid.Unknown = append(invalid.Unknown, hint)
+		case state != StateAvailable:
+			invalid.Unavailable = append(invalid.Unavailable, hint)
+		}
+	}
+	if len(invalid.Unknown) > 0 || len(invalid.Unavailable) > 0 {
+		return invalid
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Package connectivity tells whether traffic is allowed between two ports, or
+between a port and a CIDR, and explains which security group rules and
+firewall rules allow or deny it.
+
+The egress rules of the security groups of the source port and the ingress
+rules of the security groups of the destination port are evaluated, taking
+port security, allowed address pairs, the members of remote groups and the
+addresses of remote address groups into account. FWaaS v2 firewall groups
+bound to the ports and to the router interfaces on the way of the traffic can
+be evaluated too.
+
+Example to Check Traffic Between Two Ports
+
+	result, err := connectivity.Check(context.TODO(), networkClient, connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{PortID: "web-port-id"},
+		Destination: connectivity.Endpoint{PortID: "db-port-id"},
+		Protocol:    rules.ProtocolTCP,
+		Port:        5432,
+		Firewalls:   true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, step := range result.Steps {
+		fmt.Printf("%s: %t: %s\n", step.Stage, step.Allowed, step.Reason)
+	}
+
+Example to Check Traffic From the Internet to a Port
+
+	result, err := connectivity.Check(context.TODO(), networkClient, connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "0.0.0.0/0"},
+		Destination: connectivity.Endpoint{PortID: "web-port-id"},
+		Protocol:    rules.ProtocolTCP,
+		Port:        443,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if !result.Allowed {
+		for _, step := range result.Denials() {
+			fmt.Println(step.Reason)
+		}
+	}
+*/
+package connectivity
@@ -0,0 +1,20 @@
+package connectivity
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrNoAddress is returned by Check when a port has no fixed IP of the IP
+// version of the traffic.
+type ErrNoAddress struct {
+	gophercloud.BaseError
+
+	PortID    string
+	IPVersion int
+}
+
+func (e ErrNoAddress) Error() string {
+	return fmt.Sprintf("port %s has no IPv%d fixed IP", e.PortID, e.IPVersion)
+}
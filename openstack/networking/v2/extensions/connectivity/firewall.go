@@ -0,0 +1,234 @@
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	fwgroups "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/fwaas_v2/groups"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/fwaas_v2/policies"
+	fwrules "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/deviceowners"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/protocols"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+)
+
+// policy is a firewall policy with its rules.
+type policy struct {
+	policies.Policy
+	rules map[string]fwrules.Rule
+}
+
+// checkFirewalls evaluates the firewall groups bound to the ports on the way
+// of the traffic.
+func (c *checker) checkFirewalls(ctx context.Context, t traffic) ([]Step, error) {
+	if !c.listedGroups {
+		allPages, err := fwgroups.List(c.client, fwgroups.ListOpts{}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		groups, err := fwgroups.ExtractGroups(allPages)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			if group.AdminStateUp {
+				c.firewallGroups = append(c.firewallGroups, group)
+			}
+		}
+		c.listedGroups = true
+	}
+
+	// hop is a port on the way of the traffic, and the direction of the
+	// traffic relative to the device of the port.
+	type hop struct {
+		stage   Stage
+		portID  string
+		ingress bool
+	}
+	var hops []hop
+
+	routed := t.src.port == nil || t.dst.port == nil || t.src.port.NetworkID != t.dst.port.NetworkID
+	if t.src.port != nil {
+		hops = append(hops, hop{StageSourceFirewall, t.src.port.ID, false})
+		if routed {
+			interfaces, err := c.routerInterfaces(ctx, t.src.port.NetworkID, t.version)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range interfaces {
+				hops = append(hops, hop{StageRouterIngress, p.ID, true})
+			}
+		}
+	}
+	if t.dst.port != nil {
+		if routed {
+			interfaces, err := c.routerInterfaces(ctx, t.dst.port.NetworkID, t.version)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range interfaces {
+				hops = append(hops, hop{StageRouterEgress, p.ID, false})
+			}
+		}
+		hops = append(hops, hop{StageDestinationFirewall, t.dst.port.ID, true})
+	}
+
+	var steps []Step
+	for _, h := range hops {
+		for _, group := range c.firewallGroups {
+			if !containsString(group.Ports, h.portID) {
+				continue
+			}
+
+			step := Step{Stage: h.stage, PortID: h.portID, FirewallGroupID: group.ID}
+			direction, policyID := "egress", group.EgressFirewallPolicyID
+			if h.ingress {
+				direction, policyID = "ingress", group.IngressFirewallPolicyID
+			}
+			if policyID == "" {
+				step.Allowed = true
+				step.Reason = fmt.Sprintf("firewall group %s of port %s has no %s policy", group.ID, h.portID, direction)
+				steps = append(steps, step)
+				continue
+			}
+			step.FirewallPolicyID = policyID
+
+			p, err := c.policy(ctx, policyID)
+			if err != nil {
+				return nil, err
+			}
+			step.Reason = fmt.Sprintf("no rule of %s policy %s of firewall group %s of port %s matches %s traffic from %s to %s on port %d, so it is denied", direction, policyID, group.ID, h.portID, t.protocol, t.src, t.dst, t.port)
+			for _, ruleID := range p.Rules {
+				rule, ok := p.rules[ruleID]
+				if !ok || !matchFirewallRule(&rule, t) {
+					continue
+				}
+				step.Allowed = rule.Action == "allow"
+				step.FirewallRule = &rule
+				verb := map[string]string{"allow": "allows", "deny": "denies", "reject": "rejects"}[rule.Action]
+				step.Reason = fmt.Sprintf("rule %s of %s policy %s of firewall group %s of port %s %s the traffic", rule.ID, direction, policyID, group.ID, h.portID, verb)
+				break
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	return steps, nil
+}
+
+// routerInterfaces returns the router interfaces on a network with a fixed
+// IP of an IP version.
+func (c *checker) routerInterfaces(ctx context.Context, networkID string, version int) ([]ports.Port, error) {
+	networkPorts, ok := c.networkPorts[networkID]
+	if !ok {
+		allPages, err := ports.List(c.client, ports.ListOpts{NetworkID: networkID}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		allPorts, err := ports.ExtractPorts(allPages)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range allPorts {
+			if deviceowners.IsRouterInterface(p.DeviceOwner) {
+				networkPorts = append(networkPorts, p)
+			}
+		}
+		c.networkPorts[networkID] = networkPorts
+	}
+
+	var interfaces []ports.Port
+	for _, p := range networkPorts {
+		for _, cidr := range portAddresses(&p) {
+			if ipVersion(cidr.IP) == version {
+				interfaces = append(interfaces, p)
+				break
+			}
+		}
+	}
+	return interfaces, nil
+}
+
+// policy gets a firewall policy and its rules.
+func (c *checker) policy(ctx context.Context, id string) (*policy, error) {
+	if p, ok := c.policies[id]; ok {
+		return p, nil
+	}
+
+	fwPolicy, err := policies.Get(ctx, c.client, id).Extract()
+	if err != nil {
+		return nil, err
+	}
+	allPages, err := fwrules.List(c.client, fwrules.ListOpts{FirewallPolicyID: id}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policyRules, err := fwrules.ExtractRules(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &policy{Policy: *fwPolicy, rules: make(map[string]fwrules.Rule, len(policyRules))}
+	for _, rule := range policyRules {
+		p.rules[rule.ID] = rule
+	}
+	c.policies[id] = p
+	return p, nil
+}
+
+// matchFirewallRule tells whether a firewall rule matches the traffic.
+func matchFirewallRule(rule *fwrules.Rule, t traffic) bool {
+	if !rule.Enabled || rule.SourcePort != "" {
+		return false
+	}
+	if rule.IPVersion != 0 && rule.IPVersion != t.version {
+		return false
+	}
+	if protocol := protocols.Normalize(rule.Protocol, t.version); protocol != "" && protocol != t.protocol {
+		return false
+	}
+
+	if rule.SourceIPAddress != "" {
+		cidr, err := parseCIDR(rule.SourceIPAddress)
+		if err != nil || !t.src.containedIn(cidr) {
+			return false
+		}
+	}
+	if rule.DestinationIPAddress != "" {
+		cidr, err := parseCIDR(rule.DestinationIPAddress)
+		if err != nil || !t.dst.containedIn(cidr) {
+			return false
+		}
+	}
+
+	if rule.DestinationPort != "" && protocols.HasPorts(t.protocol) {
+		first, last, ok := strings.Cut(rule.DestinationPort, ":")
+		if !ok {
+			last = first
+		}
+		portMin, err := strconv.Atoi(first)
+		if err != nil {
+			return false
+		}
+		portMax, err := strconv.Atoi(last)
+		if err != nil {
+			return false
+		}
+		if t.port < portMin || t.port > portMax {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,528 @@
+package connectivity
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/addressgroups"
+	fwgroups "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/fwaas_v2/groups"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/protocols"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+)
+
+// Endpoint is the source or the destination of the traffic.
+type Endpoint struct {
+	// PortID is the ID of a port.
+	PortID string
+
+	// IPAddress is the address of the port the traffic is sent from or to.
+	// It is a fixed IP of the port, or an address of its allowed address
+	// pairs. Defaults to the first fixed IP of the port of the IP version of
+	// the traffic.
+	IPAddress string
+
+	// CIDR are the addresses, outside of the ports, the traffic is sent from
+	// or to, when PortID is empty. A single address is matched if the prefix
+	// length is omitted.
+	CIDR string
+}
+
+// CheckOpts configures Check.
+type CheckOpts struct {
+	// Source is where the traffic is sent from.
+	Source Endpoint
+
+	// Destination is where the traffic is sent to.
+	Destination Endpoint
+
+	// Protocol is the protocol of the traffic, by name or number, such as
+	// "tcp" or "17".
+	Protocol rules.RuleProtocol
+
+	// Port is the destination port of the traffic, or its ICMP type.
+	Port int
+
+	// Firewalls also evaluates the FWaaS v2 firewall groups bound to the
+	// ports and to the router interfaces on the way of the traffic.
+	Firewalls bool
+}
+
+// endpoint is an Endpoint, with its port and address resolved.
+type endpoint struct {
+	port *port
+	ip   net.IP
+	cidr *net.IPNet
+}
+
+// port is a port with its port security.
+type port struct {
+	ports.Port
+	securityEnabled bool
+}
+
+// containedIn tells whether the addresses of the endpoint are all in a CIDR.
+func (e endpoint) containedIn(cidr *net.IPNet) bool {
+	if e.cidr == nil {
+		return cidr.Contains(e.ip)
+	}
+	ones, _ := e.cidr.Mask.Size()
+	cidrOnes, _ := cidr.Mask.Size()
+	return cidrOnes <= ones && cidr.Contains(e.cidr.IP)
+}
+
+// containedInAny tells whether the endpoint is contained in one of the CIDRs.
+func (e endpoint) containedInAny(cidrs []*net.IPNet) bool {
+	for _, cidr := range cidrs {
+		if e.containedIn(cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e endpoint) String() string {
+	if e.cidr != nil {
+		return e.cidr.String()
+	}
+	return e.ip.String()
+}
+
+// Check tells whether traffic is allowed from the source to the destination.
+// At least one of them must be a port.
+//
+// The egress rules of the security groups of the source port, and the ingress
+// rules of the security groups of the destination port, are evaluated, unless
+// port security is disabled on the port. Rules with a remote group match the
+// fixed IPs and the allowed address pairs of the ports of the group, and
+// rules with a remote address group match its addresses. The traffic sent
+// from an address which is not one of the source port is dropped.
+//
+// With CheckOpts.Firewalls, the firewall groups bound to the source port and
+// to the router interfaces on its network are evaluated for egress and
+// ingress respectively, then the ones bound to the router interfaces on the
+// network of the destination port and to the destination port, for egress
+// and ingress respectively. Router interfaces are only evaluated when the
+// ports are on different networks. Firewall rules with a source port never
+// match, the source port of the traffic being unknown.
+//
+// All the steps are evaluated, even after one denies the traffic, to explain
+// all the reasons the traffic is denied. Security groups and firewalls are
+// stateful: the replies to allowed traffic are allowed.
+func Check(ctx context.Context, client *gophercloud.ServiceClient, opts CheckOpts) (*Result, error) {
+	if opts.Protocol == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "Protocol"}
+	}
+	if opts.Source.PortID == "" && opts.Destination.PortID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "PortID"}
+	}
+
+	c := &checker{
+		client:        client,
+		members:       make(map[string][]*net.IPNet),
+		addressGroups: make(map[string][]*net.IPNet),
+		networkPorts:  make(map[string][]ports.Port),
+		policies:      make(map[string]*policy),
+	}
+
+	src, err := c.resolve(ctx, "Source", opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := c.resolve(ctx, "Destination", opts.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	// The IP version of the traffic is the one of the given addresses, or of
+	// the first fixed IP of the source port.
+	version := 0
+	for _, e := range []*endpoint{src, dst} {
+		switch {
+		case e.cidr != nil:
+			version = ipVersion(e.cidr.IP)
+		case e.ip != nil:
+			version = ipVersion(e.ip)
+		}
+		if version != 0 {
+			break
+		}
+	}
+	if version == 0 {
+		version = 4
+		if src.port != nil {
+			if ip := firstFixedIP(src.port, 0); ip != nil {
+				version = ipVersion(ip)
+			}
+		}
+	}
+
+	result := &Result{
+		Protocol: protocols.Normalize(string(opts.Protocol), version),
+		Port:     opts.Port,
+	}
+	for _, e := range []*endpoint{src, dst} {
+		if e.port != nil && e.ip == nil {
+			e.ip = firstFixedIP(e.port, version)
+			if e.ip == nil {
+				return nil, ErrNoAddress{PortID: e.port.ID, IPVersion: version}
+			}
+		}
+		if e.ip != nil && ipVersion(e.ip) != version || e.cidr != nil && ipVersion(e.cidr.IP) != version {
+			invalid := gophercloud.ErrInvalidInput{}
+			invalid.Argument = "IPAddress"
+			invalid.Value = e.String()
+			invalid.Info = fmt.Sprintf("the source and the destination are not both IPv%d", version)
+			return nil, invalid
+		}
+	}
+	result.Source, result.Destination = src.String(), dst.String()
+
+	t := traffic{
+		protocol:  result.Protocol,
+		port:      opts.Port,
+		version:   version,
+		etherType: fmt.Sprintf("IPv%d", version),
+		src:       src,
+		dst:       dst,
+	}
+
+	if src.port != nil {
+		step, err := c.checkSecurityGroups(ctx, t, StageSourceEgress, src.port, dst)
+		if err != nil {
+			return nil, err
+		}
+		result.Steps = append(result.Steps, step)
+	}
+
+	if opts.Firewalls {
+		steps, err := c.checkFirewalls(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		result.Steps = append(result.Steps, steps...)
+	}
+
+	if dst.port != nil {
+		step, err := c.checkSecurityGroups(ctx, t, StageDestinationIngress, dst.port, src)
+		if err != nil {
+			return nil, err
+		}
+		result.Steps = append(result.Steps, step)
+	}
+
+	result.Allowed = true
+	for _, step := range result.Steps {
+		result.Allowed = result.Allowed && step.Allowed
+	}
+
+	return result, nil
+}
+
+// traffic is the traffic being checked.
+type traffic struct {
+	protocol  string
+	port      int
+	version   int
+	etherType string
+	src, dst  *endpoint
+}
+
+type checker struct {
+	client *gophercloud.ServiceClient
+
+	// members are the addresses of the ports of the security groups.
+	members map[string][]*net.IPNet
+
+	// addressGroups are the addresses of the address groups.
+	addressGroups map[string][]*net.IPNet
+
+	// networkPorts are the router interfaces of the networks.
+	networkPorts map[string][]ports.Port
+
+	// firewallGroups are the enabled firewall groups, listed once.
+	firewallGroups []fwgroups.Group
+	listedGroups   bool
+
+	policies map[string]*policy
+}
+
+// resolve gets the port of an endpoint and parses its addresses.
+func (c *checker) resolve(ctx context.Context, name string, e Endpoint) (*endpoint, error) {
+	var r endpoint
+
+	if e.PortID == "" {
+		if e.CIDR == "" {
+			return nil, gophercloud.ErrMissingInput{Argument: name + ".CIDR"}
+		}
+		cidr, err := parseCIDR(e.CIDR)
+		if err != nil {
+			invalid := gophercloud.ErrInvalidInput{}
+			invalid.Argument = name + ".CIDR"
+			invalid.Value = e.CIDR
+			return nil, invalid
+		}
+		r.cidr = cidr
+		return &r, nil
+	}
+
+	res := ports.Get(ctx, c.client, e.PortID)
+	p, err := res.Extract()
+	if err != nil {
+		return nil, err
+	}
+	// Port security is enabled unless the port security extension says
+	// otherwise.
+	var portSecurity struct {
+		PortSecurityEnabled *bool `json:"port_security_enabled"`
+	}
+	if err := res.ExtractInto(&portSecurity); err != nil {
+		return nil, err
+	}
+	r.port = &port{
+		Port:            *p,
+		securityEnabled: portSecurity.PortSecurityEnabled == nil || *portSecurity.PortSecurityEnabled,
+	}
+
+	if e.IPAddress != "" {
+		r.ip = net.ParseIP(e.IPAddress)
+		if r.ip == nil {
+			invalid := gophercloud.ErrInvalidInput{}
+			invalid.Argument = name + ".IPAddress"
+			invalid.Value = e.IPAddress
+			return nil, invalid
+		}
+	}
+
+	return &r, nil
+}
+
+// checkSecurityGroups evaluates the rules of the security groups of a port,
+// for the traffic to or from the remote endpoint.
+func (c *checker) checkSecurityGroups(ctx context.Context, t traffic, stage Stage, p *port, remote *endpoint) (Step, error) {
+	step := Step{Stage: stage, PortID: p.ID}
+
+	if !p.securityEnabled {
+		step.Allowed = true
+		step.Reason = fmt.Sprintf("port security is disabled on port %s", p.ID)
+		return step, nil
+	}
+
+	local := t.dst
+	direction := string(rules.DirIngress)
+	if stage == StageSourceEgress {
+		local = t.src
+		direction = string(rules.DirEgress)
+	}
+	if !portHasAddress(p, local.ip) {
+		step.Reason = fmt.Sprintf("%s is neither a fixed IP nor an allowed address pair of port %s", local.ip, p.ID)
+		if stage == StageSourceEgress {
+			step.Reason += ", so its traffic is dropped as spoofed"
+		}
+		return step, nil
+	}
+
+	if len(p.SecurityGroups) == 0 {
+		step.Reason = fmt.Sprintf("port %s has no security group, so all its %s traffic is denied", p.ID, direction)
+		return step, nil
+	}
+
+	for _, groupID := range p.SecurityGroups {
+		allPages, err := rules.List(c.client, rules.ListOpts{SecGroupID: groupID}).AllPages(ctx)
+		if err != nil {
+			return step, err
+		}
+		groupRules, err := rules.ExtractRules(allPages)
+		if err != nil {
+			return step, err
+		}
+
+		for i := range groupRules {
+			rule := &groupRules[i]
+			if rule.Direction != direction || rule.EtherType != t.etherType {
+				continue
+			}
+			if !matchSecGroupProtocol(rule, t) {
+				continue
+			}
+
+			switch {
+			case rule.RemoteIPPrefix != "":
+				cidr, err := parseCIDR(rule.RemoteIPPrefix)
+				if err != nil || !remote.containedIn(cidr) {
+					continue
+				}
+			case rule.RemoteGroupID != "":
+				if remote.cidr != nil {
+					continue
+				}
+				members, err := c.groupMembers(ctx, rule.RemoteGroupID)
+				if err != nil {
+					return step, err
+				}
+				if !containsIP(members, remote.ip) {
+					continue
+				}
+			case rule.RemoteAddressGroupID != "":
+				addresses, err := c.addressGroup(ctx, rule.RemoteAddressGroupID)
+				if err != nil {
+					return step, err
+				}
+				if !remote.containedInAny(addresses) {
+					continue
+				}
+			}
+
+			step.Allowed = true
+			step.SecGroupRule = rule
+			step.Reason = fmt.Sprintf("rule %s of security group %s allows %s traffic %s %s", rule.ID, rule.SecGroupID, direction, remoteWord(stage), remote)
+			return step, nil
+		}
+	}
+
+	step.Reason = fmt.Sprintf("no %s rule of security groups %s of port %s matches %s traffic %s %s on port %d", direction, strings.Join(p.SecurityGroups, ", "), p.ID, t.protocol, remoteWord(stage), remote, t.port)
+	return step, nil
+}
+
+func remoteWord(stage Stage) string {
+	if stage == StageSourceEgress {
+		return "to"
+	}
+	return "from"
+}
+
+// groupMembers returns the fixed IPs and the allowed address pairs of the
+// ports of a security group.
+func (c *checker) groupMembers(ctx context.Context, groupID string) ([]*net.IPNet, error) {
+	if members, ok := c.members[groupID]; ok {
+		return members, nil
+	}
+
+	allPages, err := ports.List(c.client, ports.ListOpts{SecurityGroups: []string{groupID}}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []*net.IPNet{}
+	for _, p := range groupPorts {
+		members = append(members, portAddresses(&p)...)
+	}
+	c.members[groupID] = members
+	return members, nil
+}
+
+// addressGroup returns the addresses of an address group.
+func (c *checker) addressGroup(ctx context.Context, id string) ([]*net.IPNet, error) {
+	if addresses, ok := c.addressGroups[id]; ok {
+		return addresses, nil
+	}
+
+	group, err := addressgroups.Get(ctx, c.client, id).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []*net.IPNet{}
+	for _, address := range group.Addresses {
+		if cidr, err := parseCIDR(address); err == nil {
+			addresses = append(addresses, cidr)
+		}
+	}
+	c.addressGroups[id] = addresses
+	return addresses, nil
+}
+
+// portAddresses returns the fixed IPs and the allowed address pairs of a
+// port.
+func portAddresses(p *ports.Port) []*net.IPNet {
+	var addresses []*net.IPNet
+	for _, ip := range p.FixedIPs {
+		if cidr, err := parseCIDR(ip.IPAddress); err == nil {
+			addresses = append(addresses, cidr)
+		}
+	}
+	for _, pair := range p.AllowedAddressPairs {
+		if cidr, err := parseCIDR(pair.IPAddress); err == nil {
+			addresses = append(addresses, cidr)
+		}
+	}
+	return addresses
+}
+
+func portHasAddress(p *port, ip net.IP) bool {
+	return containsIP(portAddresses(&p.Port), ip)
+}
+
+func containsIP(cidrs []*net.IPNet, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstFixedIP returns the first fixed IP of a port of an IP version, or of
+// any version if it is 0.
+func firstFixedIP(p *port, version int) net.IP {
+	for _, fixedIP := range p.FixedIPs {
+		ip := net.ParseIP(fixedIP.IPAddress)
+		if ip != nil && (version == 0 || ipVersion(ip) == version) {
+			return ip
+		}
+	}
+	return nil
+}
+
+func ipVersion(ip net.IP) int {
+	if ip.To4() != nil {
+		return 4
+	}
+	return 6
+}
+
+// parseCIDR parses a CIDR, or a single address.
+func parseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, &net.ParseError{Type: "IP address", Text: s}
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, cidr, err := net.ParseCIDR(s)
+	return cidr, err
+}
+
+// matchSecGroupProtocol tells whether the protocol and the ports of a
+// security group rule match the traffic. The ICMP code of rules is ignored.
+func matchSecGroupProtocol(rule *rules.SecGroupRule, t traffic) bool {
+	protocol := protocols.Normalize(rule.Protocol, t.version)
+	if protocol == "" {
+		return true
+	}
+	if protocol != t.protocol {
+		return false
+	}
+
+	switch {
+	case protocol == "icmp" || protocol == "ipv6-icmp":
+		return rule.PortRangeMin == 0 || rule.PortRangeMin == t.port
+	case protocols.HasPorts(protocol):
+		if rule.PortRangeMin == 0 && rule.PortRangeMax == 0 {
+			return true
+		}
+		return rule.PortRangeMin <= t.port && t.port <= rule.PortRangeMax
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package connectivity
+
+import (
+	fwrules "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+)
+
+// Stage is where the traffic is evaluated, in the order of its way.
+type Stage string
+
+const (
+	// StageSourceEgress evaluates the egress rules of the security groups of
+	// the source port.
+	StageSourceEgress Stage = "source-egress"
+
+	// StageSourceFirewall evaluates the egress policy of a firewall group
+	// bound to the source port.
+	StageSourceFirewall Stage = "source-firewall"
+
+	// StageRouterIngress evaluates the ingress policy of a firewall group
+	// bound to a router interface on the network of the source port.
+	StageRouterIngress Stage = "router-ingress"
+
+	// StageRouterEgress evaluates the egress policy of a firewall group bound
+	// to a router interface on the network of the destination port.
+	StageRouterEgress Stage = "router-egress"
+
+	// StageDestinationFirewall evaluates the ingress policy of a firewall
+	// group bound to the destination port.
+	StageDestinationFirewall Stage = "destination-firewall"
+
+	// StageDestinationIngress evaluates the ingress rules of the security
+	// groups of the destination port.
+	StageDestinationIngress Stage = "destination-ingress"
+)
+
+// Step is the evaluation of the traffic at a stage.
+type Step struct {
+	Stage Stage
+
+	// PortID is the ID of the port the traffic is evaluated on.
+	PortID string
+
+	// Allowed tells whether the traffic is allowed at this stage.
+	Allowed bool
+
+	// Reason explains why the traffic is allowed or denied.
+	Reason string
+
+	// SecGroupRule is the security group rule which allows the traffic.
+	SecGroupRule *rules.SecGroupRule
+
+	// FirewallGroupID and FirewallPolicyID are the firewall group and the
+	// policy evaluated by firewall stages.
+	FirewallGroupID  string
+	FirewallPolicyID string
+
+	// FirewallRule is the firewall rule which allows or denies the traffic.
+	// It is nil when no rule matches, in which case the traffic is denied.
+	FirewallRule *fwrules.Rule
+}
+
+// Result is the result of Check.
+type Result struct {
+	// Allowed tells whether the traffic is allowed by all the steps.
+	Allowed bool
+
+	// Source and Destination are the addresses of the traffic.
+	Source      string
+	Destination string
+
+	// Protocol is the name of the protocol of the traffic.
+	Protocol string
+
+	// Port is the destination port of the traffic, or its ICMP type.
+	Port int
+
+	// Steps are the evaluations of the traffic, in the order of its way.
+	Steps []Step
+}
+
+// Denials returns the steps which deny the traffic.
+func (r Result) Denials() []Step {
+	var denials []Step
+	for _, step := range r.Steps {
+		if !step.Allowed {
+			denials = append(denials, step)
+		}
+	}
+	return denials
+}
@@ -0,0 +1,2 @@
+// connectivity unit tests
+package testing
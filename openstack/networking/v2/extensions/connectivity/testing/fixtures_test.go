@@ -0,0 +1,319 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	WebNetworkID = "b1e1a6f2-6b1a-4b9e-9f5e-2f8a4c1d0e01"
+	DBNetworkID  = "b1e1a6f2-6b1a-4b9e-9f5e-2f8a4c1d0e02"
+
+	WebPortID       = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01"
+	DBPortID        = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e02"
+	UnsecurePortID  = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e03"
+	WebInterfaceID  = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e04"
+	DBInterfaceID   = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e05"
+	WebGroupID      = "85cc3048-abc3-43cc-89b3-377341426a01"
+	DBGroupID       = "85cc3048-abc3-43cc-89b3-377341426a02"
+	FirewallGroupID = "f3d1c2b4-5a6e-4f7d-8c9b-0a1b2c3d4e01"
+	PolicyID        = "f3d1c2b4-5a6e-4f7d-8c9b-0a1b2c3d4e02"
+	DenySSHRuleID   = "f3d1c2b4-5a6e-4f7d-8c9b-0a1b2c3d4e03"
+	AllowTCPRuleID  = "f3d1c2b4-5a6e-4f7d-8c9b-0a1b2c3d4e04"
+
+	WebEgressRuleID = "93aa42e5-80db-4581-9391-3a608bd0e401"
+	PostgresRuleID  = "93aa42e5-80db-4581-9391-3a608bd0e402"
+	SSHRuleID       = "93aa42e5-80db-4581-9391-3a608bd0e403"
+	HTTPSRuleID     = "93aa42e5-80db-4581-9391-3a608bd0e404"
+
+	PartnersAddressGroupID = "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11"
+)
+
+// WebPort is on the web network, in the web security group.
+var WebPort = fmt.Sprintf(`
+{
+	"id": "%s",
+	"network_id": "%s",
+	"device_owner": "compute:nova",
+	"fixed_ips": [{"subnet_id": "web-subnet", "ip_address": "10.0.0.10"}],
+	"allowed_address_pairs": [],
+	"security_groups": ["%s"],
+	"port_security_enabled": true
+}`, WebPortID, WebNetworkID, WebGroupID)
+
+// DBPort is on the database network, in the database security group, with a
+// virtual IP.
+var DBPort = fmt.Sprintf(`
+{
+	"id": "%s",
+	"network_id": "%s",
+	"device_owner": "compute:nova",
+	"fixed_ips": [{"subnet_id": "db-subnet", "ip_address": "10.0.1.20"}],
+	"allowed_address_pairs": [{"ip_address": "10.0.1.100", "mac_address": "fa:16:3e:00:00:01"}],
+	"security_groups": ["%s"],
+	"port_security_enabled": true
+}`, DBPortID, DBNetworkID, DBGroupID)
+
+// UnsecurePort is on the database network, without port security.
+var UnsecurePort = fmt.Sprintf(`
+{
+	"id": "%s",
+	"network_id": "%s",
+	"device_owner": "compute:nova",
+	"fixed_ips": [{"subnet_id": "db-subnet", "ip_address": "10.0.1.30"}],
+	"allowed_address_pairs": [],
+	"security_groups": [],
+	"port_security_enabled": false
+}`, UnsecurePortID, DBNetworkID)
+
+var WebInterface = fmt.Sprintf(`
+{
+	"id": "%s",
+	"network_id": "%s",
+	"device_owner": "network:router_interface",
+	"fixed_ips": [{"subnet_id": "web-subnet", "ip_address": "10.0.0.1"}],
+	"allowed_address_pairs": [],
+	"security_groups": []
+}`, WebInterfaceID, WebNetworkID)
+
+var DBInterface = fmt.Sprintf(`
+{
+	"id": "%s",
+	"network_id": "%s",
+	"device_owner": "network:router_interface_distributed",
+	"fixed_ips": [{"subnet_id": "db-subnet", "ip_address": "10.0.1.1"}],
+	"allowed_address_pairs": [],
+	"security_groups": []
+}`, DBInterfaceID, DBNetworkID)
+
+// WebRules allow all IPv4 egress traffic.
+var WebRules = fmt.Sprintf(`
+{
+	"security_group_rules": [
+		{
+			"id": "%s",
+			"direction": "egress",
+			"ethertype": "IPv4",
+			"port_range_max": null,
+			"port_range_min": null,
+			"protocol": null,
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"security_group_id": "%s"
+		}
+	]
+}`, WebEgressRuleID, WebGroupID)
+
+// DBRules allow PostgreSQL from the web security group, and SSH from
+// 192.168.0.0/16.
+var DBRules = fmt.Sprintf(`
+{
+	"security_group_rules": [
+		{
+			"id": "%s",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 5432,
+			"port_range_min": 5432,
+			"protocol": "6",
+			"remote_group_id": "%s",
+			"remote_ip_prefix": null,
+			"security_group_id": "%s"
+		},
+		{
+			"id": "%s",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 22,
+			"port_range_min": 22,
+			"protocol": "tcp",
+			"remote_group_id": null,
+			"remote_ip_prefix": "192.168.0.0/16",
+			"security_group_id": "%s"
+		},
+		{
+			"id": "%s",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 443,
+			"port_range_min": 443,
+			"protocol": "tcp",
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"remote_address_group_id": "%s",
+			"security_group_id": "%s"
+		}
+	]
+}`, PostgresRuleID, WebGroupID, DBGroupID, SSHRuleID, DBGroupID, HTTPSRuleID, PartnersAddressGroupID, DBGroupID)
+
+// PartnersAddressGroup is the address group of the HTTPS rule of the
+// database security group.
+var PartnersAddressGroup = fmt.Sprintf(`
+{
+	"address_group": {
+		"id": "%s",
+		"name": "partners",
+		"addresses": ["192.0.2.0/24", "198.51.100.7/32"]
+	}
+}`, PartnersAddressGroupID)
+
+// FirewallGroups bind a firewall group to the router interface of the
+// database network.
+var FirewallGroups = fmt.Sprintf(`
+{
+	"firewall_groups": [
+		{
+			"id": "%s",
+			"name": "db",
+			"admin_state_up": true,
+			"ingress_firewall_policy_id": null,
+			"egress_firewall_policy_id": "%s",
+			"ports": ["%s"],
+			"status": "ACTIVE"
+		},
+		{
+			"id": "f3d1c2b4-5a6e-4f7d-8c9b-0a1b2c3d4eff",
+			"name": "disabled",
+			"admin_state_up": false,
+			"ingress_firewall_policy_id": null,
+			"egress_firewall_policy_id": "%s",
+			"ports": ["%s"],
+			"status": "DOWN"
+		}
+	]
+}`, FirewallGroupID, PolicyID, DBInterfaceID, PolicyID, WebPortID)
+
+// FirewallPolicy denies SSH, then allows TCP.
+var FirewallPolicy = fmt.Sprintf(`
+{
+	"firewall_policy": {
+		"id": "%s",
+		"name": "db",
+		"firewall_rules": ["%s", "%s"]
+	}
+}`, PolicyID, DenySSHRuleID, AllowTCPRuleID)
+
+var FirewallRules = fmt.Sprintf(`
+{
+	"firewall_rules": [
+		{
+			"id": "%s",
+			"protocol": "tcp",
+			"action": "allow",
+			"ip_version": 4,
+			"enabled": true,
+			"firewall_policy_id": ["%s"]
+		},
+		{
+			"id": "%s",
+			"protocol": "tcp",
+			"action": "deny",
+			"ip_version": 4,
+			"destination_port": "22",
+			"enabled": true,
+			"firewall_policy_id": ["%s"]
+		}
+	]
+}`, AllowTCPRuleID, PolicyID, DenySSHRuleID, PolicyID)
+
+// HandleNetwork serves the ports, the security groups and the firewall
+// groups of a web and a database network.
+func HandleNetwork(t *testing.T) {
+	portsByID := map[string]string{
+		WebPortID:      WebPort,
+		DBPortID:       DBPort,
+		UnsecurePortID: UnsecurePort,
+	}
+	for id, port := range portsByID {
+		port := port
+		th.Mux.HandleFunc("/v2.0/ports/"+id, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"port": %s}`, port)
+		})
+	}
+
+	th.Mux.HandleFunc("/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		var listed []string
+		query := r.URL.Query()
+		switch {
+		case query.Get("security_groups") == WebGroupID:
+			listed = []string{WebPort}
+		case query.Get("security_groups") == DBGroupID:
+			listed = []string{DBPort}
+		case query.Get("network_id") == WebNetworkID:
+			listed = []string{WebPort, WebInterface}
+		case query.Get("network_id") == DBNetworkID:
+			listed = []string{DBPort, UnsecurePort, DBInterface}
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ports": [`)
+		for i, port := range listed {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprint(w, port)
+		}
+		fmt.Fprint(w, `]}`)
+	})
+
+	th.Mux.HandleFunc("/v2.0/security-group-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Query().Get("security_group_id") {
+		case WebGroupID:
+			fmt.Fprint(w, WebRules)
+		case DBGroupID:
+			fmt.Fprint(w, DBRules)
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+	})
+
+	th.Mux.HandleFunc("/v2.0/address-groups/"+PartnersAddressGroupID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, PartnersAddressGroup)
+	})
+
+	th.Mux.HandleFunc("/v2.0/fwaas/firewall_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, FirewallGroups)
+	})
+
+	th.Mux.HandleFunc("/v2.0/fwaas/firewall_policies/"+PolicyID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, FirewallPolicy)
+	})
+
+	th.Mux.HandleFunc("/v2.0/fwaas/firewall_rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"firewall_policy_id": PolicyID})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, FirewallRules)
+	})
+}
@@ -0,0 +1,176 @@
+package testing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/connectivity"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func stages(result *connectivity.Result) []connectivity.Stage {
+	var s []connectivity.Stage
+	for _, step := range result.Steps {
+		s = append(s, step.Stage)
+	}
+	return s
+}
+
+func TestCheckAllowed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{PortID: WebPortID},
+		Destination: connectivity.Endpoint{PortID: DBPortID, IPAddress: "10.0.1.100"},
+		Protocol:    rules.ProtocolTCP,
+		Port:        5432,
+		Firewalls:   true,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, true, result.Allowed)
+	th.AssertEquals(t, "10.0.0.10", result.Source)
+	th.AssertEquals(t, "10.0.1.100", result.Destination)
+	th.CheckDeepEquals(t, []connectivity.Stage{
+		connectivity.StageSourceEgress,
+		connectivity.StageRouterEgress,
+		connectivity.StageDestinationIngress,
+	}, stages(result))
+
+	th.AssertEquals(t, WebEgressRuleID, result.Steps[0].SecGroupRule.ID)
+	th.AssertEquals(t, DBInterfaceID, result.Steps[1].PortID)
+	th.AssertEquals(t, FirewallGroupID, result.Steps[1].FirewallGroupID)
+	th.AssertEquals(t, AllowTCPRuleID, result.Steps[1].FirewallRule.ID)
+	th.AssertEquals(t, PostgresRuleID, result.Steps[2].SecGroupRule.ID)
+	th.AssertEquals(t, 0, len(result.Denials()))
+}
+
+func TestCheckDeniedByFirewall(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "192.168.1.0/24"},
+		Destination: connectivity.Endpoint{PortID: DBPortID},
+		Protocol:    "6",
+		Port:        22,
+		Firewalls:   true,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, false, result.Allowed)
+	th.AssertEquals(t, "tcp", result.Protocol)
+	th.CheckDeepEquals(t, []connectivity.Stage{
+		connectivity.StageRouterEgress,
+		connectivity.StageDestinationIngress,
+	}, stages(result))
+
+	denials := result.Denials()
+	th.AssertEquals(t, 1, len(denials))
+	th.AssertEquals(t, DenySSHRuleID, denials[0].FirewallRule.ID)
+	th.AssertEquals(t, true, strings.Contains(denials[0].Reason, "denies"))
+	th.AssertEquals(t, SSHRuleID, result.Steps[1].SecGroupRule.ID)
+}
+
+func TestCheckNoMatchingRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "10.1.0.0/16"},
+		Destination: connectivity.Endpoint{PortID: DBPortID},
+		Protocol:    rules.ProtocolTCP,
+		Port:        22,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, false, result.Allowed)
+	th.AssertEquals(t, 1, len(result.Steps))
+	th.AssertEquals(t, connectivity.StageDestinationIngress, result.Steps[0].Stage)
+	th.AssertEquals(t, true, result.Steps[0].SecGroupRule == nil)
+	th.AssertEquals(t, true, strings.HasPrefix(result.Steps[0].Reason, "no ingress rule of security groups "+DBGroupID))
+}
+
+func TestCheckRemoteAddressGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "192.0.2.128/25"},
+		Destination: connectivity.Endpoint{PortID: DBPortID},
+		Protocol:    rules.ProtocolTCP,
+		Port:        443,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, result.Allowed)
+	th.AssertEquals(t, HTTPSRuleID, result.Steps[0].SecGroupRule.ID)
+
+	result, err = connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "198.51.100.0/24"},
+		Destination: connectivity.Endpoint{PortID: DBPortID},
+		Protocol:    rules.ProtocolTCP,
+		Port:        443,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, result.Allowed)
+}
+
+func TestCheckPortSecurityDisabled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{PortID: WebPortID},
+		Destination: connectivity.Endpoint{PortID: UnsecurePortID},
+		Protocol:    rules.ProtocolUDP,
+		Port:        53,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, true, result.Allowed)
+	th.AssertEquals(t, 2, len(result.Steps))
+	th.AssertEquals(t, "port security is disabled on port "+UnsecurePortID, result.Steps[1].Reason)
+}
+
+func TestCheckSpoofedSource(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleNetwork(t)
+
+	result, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{PortID: DBPortID, IPAddress: "10.0.1.50"},
+		Destination: connectivity.Endpoint{PortID: WebPortID},
+		Protocol:    rules.ProtocolICMP,
+		Port:        8,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, false, result.Allowed)
+	th.AssertEquals(t, connectivity.StageSourceEgress, result.Steps[0].Stage)
+	th.AssertEquals(t, false, result.Steps[0].Allowed)
+	th.AssertEquals(t, true, strings.HasSuffix(result.Steps[0].Reason, "spoofed"))
+}
+
+func TestCheckInvalidOpts(t *testing.T) {
+	_, err := connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "10.0.0.0/8"},
+		Destination: connectivity.Endpoint{CIDR: "10.1.0.0/16"},
+		Protocol:    rules.ProtocolTCP,
+	})
+	th.AssertErr(t, err)
+
+	_, err = connectivity.Check(context.TODO(), fake.ServiceClient(), connectivity.CheckOpts{
+		Source:      connectivity.Endpoint{CIDR: "10.0.0.0/8"},
+		Destination: connectivity.Endpoint{PortID: DBPortID},
+	})
+	th.AssertErr(t, err)
+}
@@ -0,0 +1,47 @@
+/*
+Package ipam reports the usage of the addresses of subnets, picks free
+addresses for static assignment, and reports the utilization of the subnets
+of VPCs.
+
+The allocation pools of subnets are taken into account, as well as their
+reserved addresses: the subnet address, the broadcast address of IPv4
+subnets, and the gateway.
+
+Example to List the Free Addresses of a Subnet
+
+	usage, err := ipam.GetSubnetUsage(context.TODO(), networkClient, "08eae331-0402-425a-923c-34f7cfe39c1b")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s of %s addresses are free\n", usage.Free, usage.Total)
+	for _, ip := range usage.FreeIPs(20) {
+		fmt.Println(ip)
+	}
+
+Example to Pick Addresses for Static Assignment
+
+	picked, err := ipam.Pick(context.TODO(), networkClient, "08eae331-0402-425a-923c-34f7cfe39c1b", 3, ipam.PickOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Report the Utilization of VPCs
+
+	reports, err := ipam.Report(context.TODO(), networkClient, ipam.ReportOpts{
+		Warning:  0.7,
+		Critical: 0.9,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, report := range reports {
+		for _, subnet := range report.Subnets {
+			if subnet.Level != ipam.LevelOK {
+				fmt.Printf("%s: subnet %s is %.0f%% used\n", subnet.Level, subnet.CIDR, subnet.Utilization*100)
+			}
+		}
+	}
+*/
+package ipam
@@ -0,0 +1,21 @@
+package ipam
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrNotEnoughFreeIPs is returned by Pick when a subnet has less free
+// addresses than requested.
+type ErrNotEnoughFreeIPs struct {
+	gophercloud.BaseError
+
+	SubnetID  string
+	Requested int
+	Free      int
+}
+
+func (e ErrNotEnoughFreeIPs) Error() string {
+	return fmt.Sprintf("subnet %s has %d free addresses, %d requested", e.SubnetID, e.Free, e.Requested)
+}
@@ -0,0 +1,251 @@
+package ipam
+
+import (
+	"context"
+	"math/big"
+	"net/netip"
+	"sort"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/networkipavailabilities"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/subnets"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/vpcs"
+)
+
+// GetSubnetUsage lists the used and the reserved addresses of a subnet.
+//
+// The used addresses are the fixed IPs of the ports of the subnet visible to
+// the client, whatever their status, so that ports being created are
+// included. Without administrative privileges, the ports of other projects,
+// such as router interfaces created by administrators, may be missing.
+func GetSubnetUsage(ctx context.Context, client *gophercloud.ServiceClient, subnetID string) (*SubnetUsage, error) {
+	if subnetID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "subnetID"}
+	}
+
+	subnet, err := subnets.Get(ctx, client, subnetID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := ports.List(client, ports.ListOpts{
+		FixedIPs: []ports.FixedIPOpts{{SubnetID: subnetID}},
+	}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subnetPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := newSubnetUsage(*subnet)
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range subnetPorts {
+		for _, ip := range port.FixedIPs {
+			if ip.SubnetID != subnetID {
+				continue
+			}
+			addr, err := netip.ParseAddr(ip.IPAddress)
+			if err != nil {
+				continue
+			}
+			usage.used[addr] = true
+			usage.Used = append(usage.Used, UsedIP{
+				IPAddress:   addr.String(),
+				PortID:      port.ID,
+				DeviceOwner: port.DeviceOwner,
+				Status:      port.Status,
+			})
+		}
+	}
+	sort.Slice(usage.Used, func(i, j int) bool {
+		return netip.MustParseAddr(usage.Used[i].IPAddress).Less(netip.MustParseAddr(usage.Used[j].IPAddress))
+	})
+
+	usage.Total, usage.Free = new(big.Int), new(big.Int)
+	for _, pool := range usage.pools {
+		usage.Total.Add(usage.Total, pool.size())
+	}
+	for addr := range usage.reserved {
+		if usage.inPools(addr) {
+			usage.Total.Sub(usage.Total, big.NewInt(1))
+		}
+	}
+	usage.Free.Set(usage.Total)
+	for addr := range usage.used {
+		if usage.inPools(addr) && !usage.reserved[addr] {
+			usage.Free.Sub(usage.Free, big.NewInt(1))
+		}
+	}
+
+	return usage, nil
+}
+
+// PickOpts configures Pick.
+type PickOpts struct {
+	// Exclude are addresses not to pick, such as the ones picked by other
+	// requests for ports which are not created yet.
+	Exclude []string
+}
+
+// Pick returns n free addresses of a subnet for static assignment, in
+// ascending order. It returns an ErrNotEnoughFreeIPs if the subnet has less
+// free addresses.
+//
+// The ports of the subnet are listed at every call, so that the addresses
+// of the ports created since, or being created, are not picked. Two
+// concurrent calls may still pick the same addresses: exclude the addresses
+// picked by requests in flight, or retry when the networking service reports
+// that an address is already allocated.
+func Pick(ctx context.Context, client *gophercloud.ServiceClient, subnetID string, n int, opts PickOpts) ([]string, error) {
+	usage, err := GetSubnetUsage(ctx, client, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := usage.FreeIPs(n, opts.Exclude...)
+	if len(picked) < n {
+		return picked, ErrNotEnoughFreeIPs{SubnetID: subnetID, Requested: n, Free: len(picked)}
+	}
+	return picked, nil
+}
+
+// ReportOpts configures Report.
+type ReportOpts struct {
+	// VPCIDs are the VPCs to report on. Defaults to all the VPCs of the
+	// project.
+	VPCIDs []string
+
+	// ProjectID restricts the listed VPCs to a project.
+	ProjectID string
+
+	// Warning and Critical are the utilizations, between 0 and 1, from which
+	// a subnet is at LevelWarning and LevelCritical. Default to 0.8 and 0.95.
+	Warning  float64
+	Critical float64
+}
+
+// Report reports the utilization of the subnets of VPCs, from the IP
+// availabilities of their networks. It usually requires administrative
+// privileges.
+func Report(ctx context.Context, client *gophercloud.ServiceClient, opts ReportOpts) ([]VPCReport, error) {
+	warning, critical := opts.Warning, opts.Critical
+	if warning <= 0 {
+		warning = 0.8
+	}
+	if critical <= 0 {
+		critical = 0.95
+	}
+
+	var allVPCs []vpcs.VPC
+	if len(opts.VPCIDs) == 0 {
+		allPages, err := vpcs.List(client, vpcs.ListOpts{ProjectID: opts.ProjectID}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if allVPCs, err = vpcs.ExtractVPCs(allPages); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, id := range opts.VPCIDs {
+			vpc, err := vpcs.Get(ctx, client, id).Extract()
+			if err != nil {
+				return nil, err
+			}
+			allVPCs = append(allVPCs, *vpc)
+		}
+	}
+
+	// availabilities are the IP availabilities of the subnets, by ID, read
+	// once per network.
+	availabilities := make(map[string]networkipavailabilities.SubnetIPAvailability)
+	readNetworks := make(map[string]bool)
+
+	reports := make([]VPCReport, 0, len(allVPCs))
+	for _, vpc := range allVPCs {
+		allPages, err := subnets.List(client, subnets.ListOpts{VPCID: vpc.ID}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		vpcSubnets, err := subnets.ExtractSubnets(allPages)
+		if err != nil {
+			return nil, err
+		}
+
+		report := VPCReport{
+			VPCID: vpc.ID,
+			Name:  vpc.Name,
+			CIDR:  vpc.CIDR,
+			Total: new(big.Int),
+			Used:  new(big.Int),
+			Level: LevelOK,
+		}
+		for _, subnet := range vpcSubnets {
+			if !readNetworks[subnet.NetworkID] {
+				availability, err := networkipavailabilities.Get(ctx, client, subnet.NetworkID).Extract()
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range availability.SubnetIPAvailabilities {
+					availabilities[s.SubnetID] = s
+				}
+				readNetworks[subnet.NetworkID] = true
+			}
+
+			subnetReport := SubnetReport{
+				SubnetID:  subnet.ID,
+				Name:      subnet.Name,
+				NetworkID: subnet.NetworkID,
+				CIDR:      subnet.CIDR,
+				IPVersion: subnet.IPVersion,
+				Total:     new(big.Int),
+				Used:      new(big.Int),
+			}
+			if availability, ok := availabilities[subnet.ID]; ok {
+				subnetReport.Total.SetString(availability.TotalIPs, 10)
+				subnetReport.Used.SetString(availability.UsedIPs, 10)
+			}
+			subnetReport.Utilization = utilization(subnetReport.Used, subnetReport.Total)
+			subnetReport.Level = level(subnetReport.Utilization, warning, critical)
+
+			report.Total.Add(report.Total, subnetReport.Total)
+			report.Used.Add(report.Used, subnetReport.Used)
+			if subnetReport.Level.worseThan(report.Level) {
+				report.Level = subnetReport.Level
+			}
+			report.Subnets = append(report.Subnets, subnetReport)
+		}
+		if len(report.Subnets) > 0 {
+			report.Utilization = utilization(report.Used, report.Total)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// utilization returns used/total, or 1 if total is 0, since no address can
+// be allocated.
+func utilization(used, total *big.Int) float64 {
+	if total.Sign() == 0 {
+		return 1
+	}
+	f, _ := new(big.Rat).SetFrac(used, total).Float64()
+	return f
+}
+
+func level(utilization, warning, critical float64) Level {
+	switch {
+	case utilization >= critical:
+		return LevelCritical
+	case utilization >= warning:
+		return LevelWarning
+	default:
+		return LevelOK
+	}
+}
@@ -0,0 +1,203 @@
+package ipam
+
+import (
+	"math/big"
+	"net/netip"
+	"sort"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/subnets"
+)
+
+// UsedIP is an address of a subnet used by a port.
+type UsedIP struct {
+	IPAddress   string
+	PortID      string
+	DeviceOwner string
+	Status      string
+}
+
+// SubnetUsage is the usage of the addresses of a subnet.
+type SubnetUsage struct {
+	Subnet subnets.Subnet
+
+	// Reserved are the addresses of the subnet which can't be assigned to
+	// ports: the subnet address, the broadcast address of IPv4 subnets, and
+	// the gateway.
+	Reserved []string
+
+	// Used are the addresses used by the ports of the subnet, in ascending
+	// order.
+	Used []UsedIP
+
+	// Total is the number of addresses of the allocation pools, except the
+	// reserved ones.
+	Total *big.Int
+
+	// Free is the number of addresses of the allocation pools which are
+	// neither reserved nor used.
+	Free *big.Int
+
+	pools    []pool
+	reserved map[netip.Addr]bool
+	used     map[netip.Addr]bool
+}
+
+// pool is an allocation pool.
+type pool struct {
+	start, end netip.Addr
+}
+
+func (p pool) contains(addr netip.Addr) bool {
+	return p.start.Compare(addr) <= 0 && addr.Compare(p.end) <= 0
+}
+
+// size returns the number of addresses of the pool.
+func (p pool) size() *big.Int {
+	start, end := p.start.As16(), p.end.As16()
+	size := new(big.Int).Sub(new(big.Int).SetBytes(end[:]), new(big.Int).SetBytes(start[:]))
+	return size.Add(size, big.NewInt(1))
+}
+
+func newSubnetUsage(subnet subnets.Subnet) (*SubnetUsage, error) {
+	prefix, err := netip.ParsePrefix(subnet.CIDR)
+	if err != nil {
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "CIDR"
+		invalid.Value = subnet.CIDR
+		return nil, invalid
+	}
+	prefix = prefix.Masked()
+
+	usage := &SubnetUsage{
+		Subnet:   subnet,
+		reserved: make(map[netip.Addr]bool),
+		used:     make(map[netip.Addr]bool),
+	}
+
+	usage.reserved[prefix.Addr()] = true
+	if prefix.Addr().Is4() {
+		usage.reserved[lastAddr(prefix)] = true
+	}
+	if gateway, err := netip.ParseAddr(subnet.GatewayIP); err == nil {
+		usage.reserved[gateway] = true
+	}
+	for addr := range usage.reserved {
+		usage.Reserved = append(usage.Reserved, addr.String())
+	}
+	sort.Slice(usage.Reserved, func(i, j int) bool {
+		return netip.MustParseAddr(usage.Reserved[i]).Less(netip.MustParseAddr(usage.Reserved[j]))
+	})
+
+	for _, p := range subnet.AllocationPools {
+		start, err := netip.ParseAddr(p.Start)
+		if err != nil {
+			continue
+		}
+		end, err := netip.ParseAddr(p.End)
+		if err != nil || end.Less(start) {
+			continue
+		}
+		usage.pools = append(usage.pools, pool{start: start, end: end})
+	}
+	sort.Slice(usage.pools, func(i, j int) bool {
+		return usage.pools[i].start.Less(usage.pools[j].start)
+	})
+
+	return usage, nil
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+func (u *SubnetUsage) inPools(addr netip.Addr) bool {
+	for _, p := range u.pools {
+		if p.contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// FreeIPs returns up to n free addresses of the allocation pools, in
+// ascending order, except the excluded ones.
+func (u *SubnetUsage) FreeIPs(n int, exclude ...string) []string {
+	excluded := make(map[netip.Addr]bool, len(exclude))
+	for _, e := range exclude {
+		if addr, err := netip.ParseAddr(e); err == nil {
+			excluded[addr] = true
+		}
+	}
+
+	var free []string
+	for _, p := range u.pools {
+		for addr := p.start; addr.IsValid() && addr.Compare(p.end) <= 0 && len(free) < n; addr = addr.Next() {
+			if u.reserved[addr] || u.used[addr] || excluded[addr] {
+				continue
+			}
+			free = append(free, addr.String())
+		}
+	}
+	return free
+}
+
+// Level is the level of utilization of a subnet.
+type Level string
+
+const (
+	LevelOK       Level = "ok"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+func (l Level) worseThan(other Level) bool {
+	rank := map[Level]int{LevelOK: 0, LevelWarning: 1, LevelCritical: 2}
+	return rank[l] > rank[other]
+}
+
+// SubnetReport is the utilization of a subnet.
+type SubnetReport struct {
+	SubnetID  string
+	Name      string
+	NetworkID string
+	CIDR      string
+	IPVersion int
+
+	// Total and Used are the numbers of addresses of the allocation pools,
+	// and of the used ones.
+	Total *big.Int
+	Used  *big.Int
+
+	// Utilization is the ratio of used addresses, between 0 and 1. It is 1
+	// for subnets without allocation pools.
+	Utilization float64
+
+	Level Level
+}
+
+// VPCReport is the utilization of the subnets of a VPC.
+type VPCReport struct {
+	VPCID string
+	Name  string
+	CIDR  string
+
+	Subnets []SubnetReport
+
+	// Total and Used are the sums of the ones of the subnets.
+	Total *big.Int
+	Used  *big.Int
+
+	// Utilization is the ratio of used addresses of all the subnets.
+	Utilization float64
+
+	// Level is the worst level of the subnets. A VPC can be at LevelCritical
+	// while having free addresses in other subnets.
+	Level Level
+}
@@ -0,0 +1,197 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	SubnetID   = "08eae331-0402-425a-923c-34f7cfe39c1b"
+	Subnet2ID  = "54d6f61d-db07-451c-9ab3-b9609b6b6f0b"
+	Subnet6ID  = "fc0d4c55-6c15-4f5d-9a5e-1c1c1b7e6a21"
+	NetworkID  = "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	Network2ID = "a87cc70a-3e15-4acf-8205-9b711a3531b7"
+	VPCID      = "e4a7f1b2-3c5d-4e6f-8a9b-0c1d2e3f4a5b"
+)
+
+// GetSubnetResponse is a subnet with two allocation pools.
+const GetSubnetResponse = `
+{
+	"subnet": {
+		"id": "08eae331-0402-425a-923c-34f7cfe39c1b",
+		"name": "private-subnet",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"ip_version": 4,
+		"cidr": "192.168.1.0/24",
+		"gateway_ip": "192.168.1.1",
+		"allocation_pools": [
+			{"start": "192.168.1.100", "end": "192.168.1.101"},
+			{"start": "192.168.1.2", "end": "192.168.1.10"}
+		]
+	}
+}
+`
+
+// ListPortsResponse are the ports of the subnet: a router interface on the
+// gateway, a port being created, and two other ports, one of which also has
+// an address in another subnet.
+const ListPortsResponse = `
+{
+	"ports": [
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000001",
+			"device_owner": "network:router_interface",
+			"status": "ACTIVE",
+			"fixed_ips": [{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "192.168.1.1"}]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000002",
+			"device_owner": "compute:nova",
+			"status": "BUILD",
+			"fixed_ips": [{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "192.168.1.4"}]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000003",
+			"device_owner": "compute:nova",
+			"status": "ACTIVE",
+			"fixed_ips": [
+				{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "192.168.1.100"},
+				{"subnet_id": "54d6f61d-db07-451c-9ab3-b9609b6b6f0b", "ip_address": "10.0.0.3"}
+			]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000004",
+			"device_owner": "network:dhcp",
+			"status": "ACTIVE",
+			"fixed_ips": [{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "192.168.1.2"}]
+		}
+	]
+}
+`
+
+// HandleSubnet serves the subnet and its ports.
+func HandleSubnet(t *testing.T) {
+	th.Mux.HandleFunc("/v2.0/subnets/"+SubnetID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, GetSubnetResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"fixed_ips": "subnet_id=" + SubnetID})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ListPortsResponse)
+	})
+}
+
+// ListVPCsResponse is a VPC.
+const ListVPCsResponse = `
+{
+	"vpcs": [
+		{
+			"id": "e4a7f1b2-3c5d-4e6f-8a9b-0c1d2e3f4a5b",
+			"name": "production",
+			"cidr": "192.168.0.0/16"
+		}
+	]
+}
+`
+
+// ListVPCSubnetsResponse are the subnets of the VPC, on two networks.
+const ListVPCSubnetsResponse = `
+{
+	"subnets": [
+		{
+			"id": "08eae331-0402-425a-923c-34f7cfe39c1b",
+			"name": "private-subnet",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"ip_version": 4,
+			"cidr": "192.168.1.0/24",
+			"vpc_id": "e4a7f1b2-3c5d-4e6f-8a9b-0c1d2e3f4a5b"
+		},
+		{
+			"id": "fc0d4c55-6c15-4f5d-9a5e-1c1c1b7e6a21",
+			"name": "private-subnet-v6",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"ip_version": 6,
+			"cidr": "fdf8:f53b:82e4::/64",
+			"vpc_id": "e4a7f1b2-3c5d-4e6f-8a9b-0c1d2e3f4a5b"
+		},
+		{
+			"id": "54d6f61d-db07-451c-9ab3-b9609b6b6f0b",
+			"name": "db-subnet",
+			"network_id": "a87cc70a-3e15-4acf-8205-9b711a3531b7",
+			"ip_version": 4,
+			"cidr": "192.168.2.0/24",
+			"vpc_id": "e4a7f1b2-3c5d-4e6f-8a9b-0c1d2e3f4a5b"
+		}
+	]
+}
+`
+
+// HandleVPCs serves the VPC, its subnets and the IP availabilities of its
+// networks.
+func HandleVPCs(t *testing.T) {
+	th.Mux.HandleFunc("/v2.0/vpcs", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ListVPCsResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/subnets", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"vpc_id": VPCID})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ListVPCSubnetsResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/network-ip-availabilities/"+NetworkID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+{
+	"network_ip_availability": {
+		"network_id": "%s",
+		"subnet_ip_availability": [
+			{"subnet_id": "%s", "cidr": "192.168.1.0/24", "ip_version": 4, "total_ips": 200, "used_ips": 170},
+			{"subnet_id": "%s", "cidr": "fdf8:f53b:82e4::/64", "ip_version": 6, "total_ips": 18446744073709551616, "used_ips": 2}
+		],
+		"total_ips": 18446744073709551816,
+		"used_ips": 172
+	}
+}`, NetworkID, SubnetID, Subnet6ID)
+	})
+
+	th.Mux.HandleFunc("/v2.0/network-ip-availabilities/"+Network2ID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+{
+	"network_ip_availability": {
+		"network_id": "%s",
+		"subnet_ip_availability": [
+			{"subnet_id": "%s", "cidr": "192.168.2.0/24", "ip_version": 4, "total_ips": 100, "used_ips": 97}
+		],
+		"total_ips": 100,
+		"used_ips": 97
+	}
+}`, Network2ID, Subnet2ID)
+	})
+}
@@ -0,0 +1,82 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/ipam"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestGetSubnetUsage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSubnet(t)
+
+	usage, err := ipam.GetSubnetUsage(context.TODO(), fake.ServiceClient(), SubnetID)
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []string{"192.168.1.0", "192.168.1.1", "192.168.1.255"}, usage.Reserved)
+	th.CheckDeepEquals(t, []ipam.UsedIP{
+		{IPAddress: "192.168.1.1", PortID: "c0d1e2f3-0000-4000-8000-000000000001", DeviceOwner: "network:router_interface", Status: "ACTIVE"},
+		{IPAddress: "192.168.1.2", PortID: "c0d1e2f3-0000-4000-8000-000000000004", DeviceOwner: "network:dhcp", Status: "ACTIVE"},
+		{IPAddress: "192.168.1.4", PortID: "c0d1e2f3-0000-4000-8000-000000000002", DeviceOwner: "compute:nova", Status: "BUILD"},
+		{IPAddress: "192.168.1.100", PortID: "c0d1e2f3-0000-4000-8000-000000000003", DeviceOwner: "compute:nova", Status: "ACTIVE"},
+	}, usage.Used)
+	th.AssertEquals(t, int64(11), usage.Total.Int64())
+	th.AssertEquals(t, int64(8), usage.Free.Int64())
+
+	th.CheckDeepEquals(t, []string{"192.168.1.3", "192.168.1.5", "192.168.1.6"}, usage.FreeIPs(3))
+	th.CheckDeepEquals(t, []string{"192.168.1.9", "192.168.1.10", "192.168.1.101"}, usage.FreeIPs(3, "192.168.1.3", "192.168.1.5", "192.168.1.6", "192.168.1.7", "192.168.1.8"))
+}
+
+func TestPick(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSubnet(t)
+
+	picked, err := ipam.Pick(context.TODO(), fake.ServiceClient(), SubnetID, 2, ipam.PickOpts{
+		Exclude: []string{"192.168.1.3"},
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"192.168.1.5", "192.168.1.6"}, picked)
+}
+
+func TestPickNotEnoughFreeIPs(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSubnet(t)
+
+	picked, err := ipam.Pick(context.TODO(), fake.ServiceClient(), SubnetID, 10, ipam.PickOpts{})
+	notEnough, ok := err.(ipam.ErrNotEnoughFreeIPs)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, 8, notEnough.Free)
+	th.AssertEquals(t, 8, len(picked))
+}
+
+func TestReport(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleVPCs(t)
+
+	reports, err := ipam.Report(context.TODO(), fake.ServiceClient(), ipam.ReportOpts{})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(reports))
+	report := reports[0]
+	th.AssertEquals(t, VPCID, report.VPCID)
+	th.AssertEquals(t, ipam.LevelCritical, report.Level)
+	th.AssertEquals(t, int64(269), report.Used.Int64())
+	th.AssertEquals(t, 3, len(report.Subnets))
+
+	th.AssertEquals(t, SubnetID, report.Subnets[0].SubnetID)
+	th.AssertEquals(t, 0.85, report.Subnets[0].Utilization)
+	th.AssertEquals(t, ipam.LevelWarning, report.Subnets[0].Level)
+
+	th.AssertEquals(t, true, report.Subnets[1].Utilization < 1e-9)
+	th.AssertEquals(t, ipam.LevelOK, report.Subnets[1].Level)
+
+	th.AssertEquals(t, 0.97, report.Subnets[2].Utilization)
+	th.AssertEquals(t, ipam.LevelCritical, report.Subnets[2].Level)
+}
@@ -0,0 +1,58 @@
+/*
+Package conntrackhelpers enables and manages the netfilter conntrack helpers
+of routers, through the l3-conntrack-helper extension of the OpenStack
+Networking service.
+
+The protocols and helpers allowed are configured by the administrator of the
+deployment.
+
+Example to List the Conntrack Helpers of a Router
+
+	routerID := "f8a44de0-fc8e-45df-93c7-f79bf3b01c95"
+
+	allPages, err := conntrackhelpers.List(networkClient, routerID, conntrackhelpers.ListOpts{}).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allHelpers, err := conntrackhelpers.ExtractConntrackHelpers(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, helper := range allHelpers {
+		fmt.Printf("%+v\n", helper)
+	}
+
+Example to Enable the FTP Conntrack Helper on a Router
+
+	createOpts := conntrackhelpers.CreateOpts{
+		Protocol: "tcp",
+		Port:     21,
+		Helper:   "ftp",
+	}
+
+	helper, err := conntrackhelpers.Create(context.TODO(), networkClient, routerID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Conntrack Helper
+
+	updateOpts := conntrackhelpers.UpdateOpts{
+		Port: 2121,
+	}
+
+	helper, err := conntrackhelpers.Update(context.TODO(), networkClient, routerID, "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Conntrack Helper
+
+	err := conntrackhelpers.Delete(context.TODO(), networkClient, routerID, "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package conntrackhelpers
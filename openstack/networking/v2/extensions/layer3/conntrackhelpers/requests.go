@@ -0,0 +1,148 @@
+package conntrackhelpers
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToConntrackHelperListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the conntrack helper attributes you want to see returned. SortKey allows you
+// to sort by a particular conntrack helper attribute. SortDir sets the
+// direction, and is either `asc' or `desc'. Marker and Limit are used for
+// pagination.
+type ListOpts struct {
+	ID       string `q:"id"`
+	Protocol string `q:"protocol"`
+	Port     int    `q:"port"`
+	Helper   string `q:"helper"`
+	Limit    int    `q:"limit"`
+	Marker   string `q:"marker"`
+	SortKey  string `q:"sort_key"`
+	SortDir  string `q:"sort_dir"`
+}
+
+// ToConntrackHelperListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToConntrackHelperListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over the conntrack
+// helpers of a router. It accepts a ListOpts struct, which allows you to
+// filter and sort the returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, routerID string, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c, routerID)
+	if opts != nil {
+		query, err := opts.ToConntrackHelperListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return ConntrackHelperPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific conntrack helper of a router based on its unique
+// ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, routerID, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, routerID, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToConntrackHelperCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new conntrack
+// helper. All attributes are required.
+type CreateOpts struct {
+	// Protocol is the network protocol of the traffic handled by the helper,
+	// such as "tcp" or "udp".
+	Protocol string `json:"protocol" required:"true"`
+
+	// Port is the network port of the traffic handled by the helper.
+	Port int `json:"port" required:"true"`
+
+	// Helper is the name of the netfilter conntrack helper module, such as
+	// "ftp" or "tftp".
+	Helper string `json:"helper" required:"true"`
+}
+
+// ToConntrackHelperCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToConntrackHelperCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "conntrack_helper")
+}
+
+// Create accepts a CreateOpts struct and creates a new conntrack helper on a
+// router using the values provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, routerID string, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToConntrackHelperCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c, routerID), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToConntrackHelperUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts represents the attributes used when updating an existing
+// conntrack helper.
+type UpdateOpts struct {
+	// Protocol is the network protocol of the traffic handled by the helper.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Port is the network port of the traffic handled by the helper.
+	Port int `json:"port,omitempty"`
+
+	// Helper is the name of the netfilter conntrack helper module.
+	Helper string `json:"helper,omitempty"`
+}
+
+// ToConntrackHelperUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToConntrackHelperUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "conntrack_helper")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing conntrack
+// helper of a router using the values provided.
+func Update(ctx context.Context, c *gophercloud.ServiceClient, routerID, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToConntrackHelperUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, resourceURL(c, routerID, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the conntrack helper of a router
+// associated with it.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, routerID, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, routerID, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,76 @@
+package testing
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/conntrackhelpers"
+)
+
+const (
+	RouterID = "f8a44de0-fc8e-45df-93c7-f79bf3b01c95"
+	HelperID = "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a"
+)
+
+// ConntrackHelperListResult is the response of a list request.
+const ConntrackHelperListResult = `
+{
+	"conntrack_helpers": [
+		{
+			"id": "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a",
+			"protocol": "tcp",
+			"port": 21,
+			"helper": "ftp"
+		}
+	]
+}
+`
+
+// ConntrackHelperGetResult is the response of a get request.
+const ConntrackHelperGetResult = `
+{
+	"conntrack_helper": {
+		"id": "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a",
+		"protocol": "tcp",
+		"port": 21,
+		"helper": "ftp"
+	}
+}
+`
+
+// ConntrackHelperCreateRequest is the request of a create request.
+const ConntrackHelperCreateRequest = `
+{
+	"conntrack_helper": {
+		"protocol": "tcp",
+		"port": 21,
+		"helper": "ftp"
+	}
+}
+`
+
+// ConntrackHelperUpdateRequest is the request of an update request.
+const ConntrackHelperUpdateRequest = `
+{
+	"conntrack_helper": {
+		"port": 2121
+	}
+}
+`
+
+// ConntrackHelperUpdateResult is the response of an update request.
+const ConntrackHelperUpdateResult = `
+{
+	"conntrack_helper": {
+		"id": "7d4a1c3e-5b2f-4e6a-9c8d-1f0e2d3c4b5a",
+		"protocol": "tcp",
+		"port": 2121,
+		"helper": "ftp"
+	}
+}
+`
+
+// FTPHelper is the conntrack helper of the responses.
+var FTPHelper = conntrackhelpers.ConntrackHelper{
+	ID:       HelperID,
+	Protocol: "tcp",
+	Port:     21,
+	Helper:   "ftp",
+}
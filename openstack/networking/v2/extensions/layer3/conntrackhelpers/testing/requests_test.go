@@ -0,0 +1,130 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/conntrackhelpers"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID+"/conntrack_helpers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"helper": "ftp"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ConntrackHelperListResult)
+	})
+
+	count := 0
+	err := conntrackhelpers.List(fake.ServiceClient(), RouterID, conntrackhelpers.ListOpts{Helper: "ftp"}).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := conntrackhelpers.ExtractConntrackHelpers(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []conntrackhelpers.ConntrackHelper{FTPHelper}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID+"/conntrack_helpers/"+HelperID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ConntrackHelperGetResult)
+	})
+
+	helper, err := conntrackhelpers.Get(context.TODO(), fake.ServiceClient(), RouterID, HelperID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FTPHelper, *helper)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID+"/conntrack_helpers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, ConntrackHelperCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, ConntrackHelperGetResult)
+	})
+
+	helper, err := conntrackhelpers.Create(context.TODO(), fake.ServiceClient(), RouterID, conntrackhelpers.CreateOpts{
+		Protocol: "tcp",
+		Port:     21,
+		Helper:   "ftp",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FTPHelper, *helper)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := conntrackhelpers.Create(context.TODO(), fake.ServiceClient(), RouterID, conntrackhelpers.CreateOpts{Protocol: "tcp", Port: 21})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID+"/conntrack_helpers/"+HelperID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, ConntrackHelperUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ConntrackHelperUpdateResult)
+	})
+
+	helper, err := conntrackhelpers.Update(context.TODO(), fake.ServiceClient(), RouterID, HelperID, conntrackhelpers.UpdateOpts{
+		Port: 2121,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2121, helper.Port)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID+"/conntrack_helpers/"+HelperID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := conntrackhelpers.Delete(context.TODO(), fake.ServiceClient(), RouterID, HelperID)
+	th.AssertNoErr(t, res.Err)
+}
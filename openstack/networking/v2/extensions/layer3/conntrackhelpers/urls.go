@@ -0,0 +1,14 @@
+package conntrackhelpers
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+const resourcePath = "routers"
+const conntrackHelperPath = "conntrack_helpers"
+
+func rootURL(c *gophercloud.ServiceClient, routerID string) string {
+	return c.ServiceURL(resourcePath, routerID, conntrackHelperPath)
+}
+
+func resourceURL(c *gophercloud.ServiceClient, routerID, id string) string {
+	return c.ServiceURL(resourcePath, routerID, conntrackHelperPath, id)
+}
@@ -0,0 +1,36 @@
+/*
+Package fipmanager allocates floating IPs to ports safely when several
+workers share a project: floating IPs are reused or created, associated with
+the right fixed IP of a port, waited for, and released only by the worker
+which associated them.
+
+Concurrent changes are detected with the revision numbers of floating IPs,
+sent in If-Match headers, which requires the standard-attr-revisions and
+revision-if-match extensions. Floating IPs are only reused within the
+project given in AllocateOpts.
+
+Example to Allocate a Floating IP to a Port
+
+	fip, err := fipmanager.AllocateAndAssociate(context.TODO(), networkClient, fipmanager.AllocateOpts{
+		FloatingNetworkID: "376da547-b977-4cfe-9cba-275c80debf57",
+		ProjectID:         "4969c491a3c74ee4af974e6d800c62de",
+	}, "423abc8d-2991-4a55-ba98-2aaea84cc72e", fipmanager.AssociateOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	fip, err = fipmanager.WaitForActive(context.TODO(), networkClient, fip.ID)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Release a Floating IP
+
+	err := fipmanager.Release(context.TODO(), networkClient, fip.ID, "423abc8d-2991-4a55-ba98-2aaea84cc72e", fipmanager.ReleaseOpts{
+		Delete: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package fipmanager
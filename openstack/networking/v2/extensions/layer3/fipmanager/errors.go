@@ -0,0 +1,65 @@
+package fipmanager
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrConflict is returned when a floating IP changed since it was read, such
+// as when another worker associated it first.
+type ErrConflict struct {
+	gophercloud.BaseError
+
+	FloatingIPID string
+}
+
+func (e ErrConflict) Error() string {
+	return fmt.Sprintf("floating IP %s was changed concurrently", e.FloatingIPID)
+}
+
+// ErrNotOwner is returned by Release when the floating IP is not associated
+// with the port anymore.
+type ErrNotOwner struct {
+	gophercloud.BaseError
+
+	FloatingIPID string
+	PortID       string
+
+	// OwnerPortID is the port the floating IP is associated with, or empty if
+	// it is unassociated.
+	OwnerPortID string
+}
+
+func (e ErrNotOwner) Error() string {
+	if e.OwnerPortID == "" {
+		return fmt.Sprintf("floating IP %s is not associated with port %s anymore", e.FloatingIPID, e.PortID)
+	}
+	return fmt.Sprintf("floating IP %s is associated with port %s instead of %q", e.FloatingIPID, e.OwnerPortID, e.PortID)
+}
+
+// ErrNoFixedIP is returned by Associate when the port has no IPv4 fixed IP
+// in a subnet routed to the external network of the floating IP.
+type ErrNoFixedIP struct {
+	gophercloud.BaseError
+
+	PortID            string
+	FloatingNetworkID string
+}
+
+func (e ErrNoFixedIP) Error() string {
+	return fmt.Sprintf("port %s has no IPv4 fixed IP reachable from external network %s", e.PortID, e.FloatingNetworkID)
+}
+
+// ErrNoRevision is returned when a floating IP has no revision number, such
+// as when the standard-attr-revisions extension is not enabled, so that it
+// cannot be changed safely.
+type ErrNoRevision struct {
+	gophercloud.BaseError
+
+	FloatingIPID string
+}
+
+func (e ErrNoRevision) Error() string {
+	return fmt.Sprintf("floating IP %s has no revision number to detect concurrent changes", e.FloatingIPID)
+}
@@ -0,0 +1,338 @@
+package fipmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/deviceowners"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/subnets"
+)
+
+// releaseAttempts is the number of times Release reads a floating IP again
+// after its revision changed.
+const releaseAttempts = 5
+
+// AllocateOpts configures Allocate.
+type AllocateOpts struct {
+	// FloatingNetworkID is the ID of the external network of the floating IP.
+	FloatingNetworkID string
+
+	// ProjectID is the project of the floating IP. It is required, so that
+	// floating IPs of other projects are never reused when the client has
+	// administrative rights.
+	ProjectID string
+
+	// FloatingIP is the address of the floating IP, if a specific one is
+	// wanted.
+	FloatingIP string
+
+	// SubnetID is the subnet of the external network the address of the
+	// floating IP is in, if a specific one is wanted.
+	SubnetID string
+
+	// Description is the description of a created floating IP.
+	Description string
+}
+
+// Allocate returns an unassociated floating IP of the project on the external
+// network, with the requested address or in the requested subnet, or creates
+// one.
+//
+// A reused floating IP is not reserved: other workers may find it too. Claim
+// it with Associate, which fails with ErrConflict if another worker
+// associated it first, or use AllocateAndAssociate, which tries the next
+// floating IP on conflicts.
+func Allocate(ctx context.Context, client *gophercloud.ServiceClient, opts AllocateOpts) (*floatingips.FloatingIP, error) {
+	return allocate(ctx, client, opts, nil)
+}
+
+// allocate is Allocate, without reusing the excluded floating IPs.
+func allocate(ctx context.Context, client *gophercloud.ServiceClient, opts AllocateOpts, excluded map[string]bool) (*floatingips.FloatingIP, error) {
+	if opts.FloatingNetworkID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "FloatingNetworkID"}
+	}
+	if opts.ProjectID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "ProjectID"}
+	}
+
+	var cidr *net.IPNet
+	if opts.SubnetID != "" {
+		subnet, err := subnets.Get(ctx, client, opts.SubnetID).Extract()
+		if err != nil {
+			return nil, err
+		}
+		if _, cidr, err = net.ParseCIDR(subnet.CIDR); err != nil {
+			return nil, err
+		}
+	}
+
+	allPages, err := floatingips.List(client, floatingips.ListOpts{
+		FloatingNetworkID: opts.FloatingNetworkID,
+		ProjectID:         opts.ProjectID,
+		FloatingIP:        opts.FloatingIP,
+	}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allFIPs, err := floatingips.ExtractFloatingIPs(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allFIPs {
+		fip := &allFIPs[i]
+		if fip.PortID != "" || excluded[fip.ID] {
+			continue
+		}
+		if cidr != nil && !cidr.Contains(net.ParseIP(fip.FloatingIP)) {
+			continue
+		}
+		return fip, nil
+	}
+
+	return floatingips.Create(ctx, client, floatingips.CreateOpts{
+		Description:       opts.Description,
+		FloatingNetworkID: opts.FloatingNetworkID,
+		FloatingIP:        opts.FloatingIP,
+		SubnetID:          opts.SubnetID,
+		ProjectID:         opts.ProjectID,
+	}).Extract()
+}
+
+// AssociateOpts configures Associate.
+type AssociateOpts struct {
+	// FixedIP is the fixed IP of the port to associate the floating IP with.
+	// By default, the first IPv4 fixed IP of the port in a subnet routed to
+	// the external network of the floating IP is chosen.
+	FixedIP string
+}
+
+// Associate associates a floating IP with a port, unless the floating IP
+// changed since it was read, in which case it returns an ErrConflict. It
+// returns an ErrNoRevision if the floating IP has no revision number.
+//
+// When the port has several IPv4 fixed IPs and AssociateOpts.FixedIP is
+// empty, the router interfaces on the network of the port are looked up to
+// choose a fixed IP of a subnet with a router whose gateway is on the
+// external network of the floating IP.
+func Associate(ctx context.Context, client *gophercloud.ServiceClient, fip *floatingips.FloatingIP, portID string, opts AssociateOpts) (*floatingips.FloatingIP, error) {
+	if portID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "portID"}
+	}
+
+	fixedIP, err := chooseFixedIP(ctx, client, portID, fip.FloatingNetworkID, opts.FixedIP)
+	if err != nil {
+		return nil, err
+	}
+	if fip.PortID == portID && fip.FixedIP == fixedIP {
+		return fip, nil
+	}
+	if fip.RevisionNumber == 0 {
+		return nil, ErrNoRevision{FloatingIPID: fip.ID}
+	}
+
+	updateOpts := floatingips.UpdateOpts{
+		PortID:         &portID,
+		FixedIP:        fixedIP,
+		RevisionNumber: &fip.RevisionNumber,
+	}
+	associated, err := floatingips.Update(ctx, client, fip.ID, updateOpts).Extract()
+	if gophercloud.ResponseCodeIs(err, http.StatusPreconditionFailed) {
+		return nil, ErrConflict{FloatingIPID: fip.ID}
+	}
+	return associated, err
+}
+
+// AllocateAndAssociate allocates a floating IP like Allocate and associates
+// it with a port like Associate. When another worker associates the
+// allocated floating IP first, the next unassociated floating IP is tried.
+func AllocateAndAssociate(ctx context.Context, client *gophercloud.ServiceClient, allocateOpts AllocateOpts, portID string, associateOpts AssociateOpts) (*floatingips.FloatingIP, error) {
+	excluded := make(map[string]bool)
+	for {
+		fip, err := allocate(ctx, client, allocateOpts, excluded)
+		if err != nil {
+			return nil, err
+		}
+
+		associated, err := Associate(ctx, client, fip, portID, associateOpts)
+		if errors.As(err, &ErrConflict{}) {
+			excluded[fip.ID] = true
+			continue
+		}
+		return associated, err
+	}
+}
+
+// chooseFixedIP returns the fixed IP of a port to associate a floating IP of
+// an external network with.
+func chooseFixedIP(ctx context.Context, client *gophercloud.ServiceClient, portID, floatingNetworkID, fixedIP string) (string, error) {
+	port, err := ports.Get(ctx, client, portID).Extract()
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []ports.IP
+	for _, ip := range port.FixedIPs {
+		if parsed := net.ParseIP(ip.IPAddress); parsed != nil && parsed.To4() != nil {
+			candidates = append(candidates, ip)
+		}
+	}
+
+	if fixedIP != "" {
+		for _, ip := range candidates {
+			if ip.IPAddress == fixedIP {
+				return fixedIP, nil
+			}
+		}
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "FixedIP"
+		invalid.Value = fixedIP
+		invalid.Info = fmt.Sprintf("not an IPv4 fixed IP of port %s", portID)
+		return "", invalid
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", ErrNoFixedIP{PortID: portID, FloatingNetworkID: floatingNetworkID}
+	case 1:
+		return candidates[0].IPAddress, nil
+	}
+
+	routed, err := routedSubnets(ctx, client, port.NetworkID, floatingNetworkID)
+	if err != nil {
+		return "", err
+	}
+	for _, ip := range candidates {
+		if routed[ip.SubnetID] {
+			return ip.IPAddress, nil
+		}
+	}
+	return "", ErrNoFixedIP{PortID: portID, FloatingNetworkID: floatingNetworkID}
+}
+
+// routedSubnets returns the subnets of a network with a router interface of
+// a router whose gateway is on an external network.
+func routedSubnets(ctx context.Context, client *gophercloud.ServiceClient, networkID, externalNetworkID string) (map[string]bool, error) {
+	allPages, err := ports.List(client, ports.ListOpts{NetworkID: networkID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networkPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	routed := make(map[string]bool)
+	gateways := make(map[string]bool)
+	for _, p := range networkPorts {
+		if !deviceowners.IsRouterInterface(p.DeviceOwner) {
+			continue
+		}
+
+		onExternalNetwork, ok := gateways[p.DeviceID]
+		if !ok {
+			router, err := routers.Get(ctx, client, p.DeviceID).Extract()
+			if err != nil {
+				return nil, err
+			}
+			onExternalNetwork = router.GatewayInfo.NetworkID == externalNetworkID
+			gateways[p.DeviceID] = onExternalNetwork
+		}
+		if !onExternalNetwork {
+			continue
+		}
+
+		for _, ip := range p.FixedIPs {
+			routed[ip.SubnetID] = true
+		}
+	}
+	return routed, nil
+}
+
+// WaitForActive waits until a floating IP is ACTIVE, and returns it.
+func WaitForActive(ctx context.Context, client *gophercloud.ServiceClient, id string) (*floatingips.FloatingIP, error) {
+	if err := floatingips.WaitForStatus(ctx, client, id, "ACTIVE"); err != nil {
+		return nil, err
+	}
+	return floatingips.Get(ctx, client, id).Extract()
+}
+
+// ReleaseOpts configures Release.
+type ReleaseOpts struct {
+	// Delete deletes the floating IP instead of disassociating it.
+	Delete bool
+}
+
+// Release disassociates a floating IP from a port, or deletes it, only if it
+// is still associated with this port, or still unassociated if portID is
+// empty. It returns an ErrNotOwner otherwise, such as when another worker
+// reused the floating IP.
+//
+// The floating IP is read, then updated or deleted with its revision number
+// in an If-Match header, so that it is not released if another worker
+// associated it in between; an ErrNoRevision is returned if the floating IP
+// has no revision number. Floating IPs which don't exist anymore are
+// considered released.
+func Release(ctx context.Context, client *gophercloud.ServiceClient, id, portID string, opts ReleaseOpts) error {
+	for attempt := 0; attempt < releaseAttempts; attempt++ {
+		fip, err := floatingips.Get(ctx, client, id).Extract()
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if fip.PortID != portID {
+			return ErrNotOwner{FloatingIPID: id, PortID: portID, OwnerPortID: fip.PortID}
+		}
+
+		if fip.PortID == "" && !opts.Delete {
+			return nil
+		}
+		if fip.RevisionNumber == 0 {
+			return ErrNoRevision{FloatingIPID: id}
+		}
+
+		if opts.Delete {
+			headers := map[string]string{
+				"If-Match": fmt.Sprintf("revision_number=%d", fip.RevisionNumber),
+			}
+			// floatingips.Delete does not take headers.
+			resp, err := client.Delete(ctx, client.ServiceURL("floatingips", id), &gophercloud.RequestOpts{
+				MoreHeaders: headers,
+			})
+			_, _, err = gophercloud.ParseResponse(resp, err)
+			err = ignoreNotFound(err)
+			if !gophercloud.ResponseCodeIs(err, http.StatusPreconditionFailed) {
+				return err
+			}
+			continue
+		}
+
+		updateOpts := floatingips.UpdateOpts{
+			PortID:         new(string),
+			RevisionNumber: &fip.RevisionNumber,
+		}
+		err = ignoreNotFound(floatingips.Update(ctx, client, id, updateOpts).Err)
+		if !gophercloud.ResponseCodeIs(err, http.StatusPreconditionFailed) {
+			return err
+		}
+	}
+
+	return ErrConflict{FloatingIPID: id}
+}
+
+func ignoreNotFound(err error) error {
+	if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,2 @@
+// fipmanager unit tests
+package testing
@@ -0,0 +1,154 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	ExternalNetworkID = "376da547-b977-4cfe-9cba-275c80debf57"
+	NetworkID         = "a87cc70a-3e15-4acf-8205-9b711a3531b7"
+	PortID            = "423abc8d-2991-4a55-ba98-2aaea84cc72e"
+	OtherPortID       = "ce705c24-c1ef-408a-bda3-7bbd946164ab"
+	FloatingIPID      = "2f245a7b-796b-4f26-9cf9-9e82d248fda7"
+	OtherFloatingIPID = "61cea855-49cb-4846-997d-801b70c71bdd"
+	ProjectID         = "4969c491a3c74ee4af974e6d800c62de"
+)
+
+// FloatingIP returns a floating IP on the external network.
+func FloatingIP(id, address, portID, fixedIP, status string, revision int) string {
+	port, fixed := "null", "null"
+	if portID != "" {
+		port, fixed = `"`+portID+`"`, `"`+fixedIP+`"`
+	}
+	return fmt.Sprintf(`
+{
+	"id": "%s",
+	"floating_network_id": "%s",
+	"floating_ip_address": "%s",
+	"port_id": %s,
+	"fixed_ip_address": %s,
+	"status": "%s",
+	"project_id": "%s",
+	"revision_number": %d
+}`, id, ExternalNetworkID, address, port, fixed, status, ProjectID, revision)
+}
+
+// HandlePort serves a port with a fixed IP in two subnets, only the second
+// of which is routed to the external network.
+func HandlePort(t *testing.T) {
+	th.Mux.HandleFunc("/v2.0/ports/"+PortID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+{
+	"port": {
+		"id": "%s",
+		"network_id": "%s",
+		"fixed_ips": [
+			{"subnet_id": "subnet-a", "ip_address": "10.0.0.5"},
+			{"subnet_id": "subnet-v6", "ip_address": "fd00::5"},
+			{"subnet_id": "subnet-b", "ip_address": "10.1.0.5"}
+		]
+	}
+}`, PortID, NetworkID)
+	})
+
+	th.Mux.HandleFunc("/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"network_id": NetworkID})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+{
+	"ports": [
+		{
+			"id": "%s",
+			"network_id": "%s",
+			"device_owner": "compute:nova",
+			"fixed_ips": [{"subnet_id": "subnet-a", "ip_address": "10.0.0.5"}]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-00000000000a",
+			"network_id": "%s",
+			"device_owner": "network:router_interface",
+			"device_id": "router-internal",
+			"fixed_ips": [{"subnet_id": "subnet-a", "ip_address": "10.0.0.1"}]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-00000000000b",
+			"network_id": "%s",
+			"device_owner": "network:router_interface",
+			"device_id": "router-external",
+			"fixed_ips": [{"subnet_id": "subnet-b", "ip_address": "10.1.0.1"}]
+		}
+	]
+}`, PortID, NetworkID, NetworkID, NetworkID)
+	})
+
+	for id, networkID := range map[string]string{
+		"router-internal": "9d4b1e8c-0000-4000-8000-000000000001",
+		"router-external": ExternalNetworkID,
+	} {
+		id, networkID := id, networkID
+		th.Mux.HandleFunc("/v2.0/routers/"+id, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"router": {"id": "%s", "external_gateway_info": {"network_id": "%s"}}}`, id, networkID)
+		})
+	}
+}
+
+// HandleListFloatingIPs lists floating IPs of the external network.
+func HandleListFloatingIPs(t *testing.T, fips ...string) {
+	th.Mux.HandleFunc("/v2.0/floatingips", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			th.TestFormValues(t, r, map[string]string{"floating_network_id": ExternalNetworkID, "project_id": ProjectID})
+			fmt.Fprint(w, `{"floatingips": [`)
+			for i, fip := range fips {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprint(w, fip)
+			}
+			fmt.Fprint(w, `]}`)
+		case "POST":
+			th.TestJSONRequest(t, r, fmt.Sprintf(`
+{
+	"floatingip": {
+		"floating_network_id": "%s",
+		"subnet_id": "ext-subnet",
+		"project_id": "%s"
+	}
+}`, ExternalNetworkID, ProjectID))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(OtherFloatingIPID, "172.24.5.10", "", "", "DOWN", 1))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleExternalSubnet serves the subnet of the external network.
+func HandleExternalSubnet(t *testing.T) {
+	th.Mux.HandleFunc("/v2.0/subnets/ext-subnet", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"subnet": {"id": "ext-subnet", "network_id": "%s", "cidr": "172.24.5.0/24", "ip_version": 4}}`, ExternalNetworkID)
+	})
+}
@@ -0,0 +1,238 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/fipmanager"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestAllocateReusesUnassociated(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListFloatingIPs(t,
+		FloatingIP(OtherFloatingIPID, "172.24.4.10", OtherPortID, "10.0.0.9", "ACTIVE", 4),
+		FloatingIP(FloatingIPID, "172.24.4.11", "", "", "DOWN", 2),
+	)
+
+	fip, err := fipmanager.Allocate(context.TODO(), fake.ServiceClient(), fipmanager.AllocateOpts{
+		FloatingNetworkID: ExternalNetworkID,
+		ProjectID:         ProjectID,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, FloatingIPID, fip.ID)
+	th.AssertEquals(t, 2, fip.RevisionNumber)
+}
+
+func TestAllocateCreatesInSubnet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleExternalSubnet(t)
+	HandleListFloatingIPs(t,
+		FloatingIP(FloatingIPID, "172.24.4.11", "", "", "DOWN", 2),
+	)
+
+	fip, err := fipmanager.Allocate(context.TODO(), fake.ServiceClient(), fipmanager.AllocateOpts{
+		FloatingNetworkID: ExternalNetworkID,
+		ProjectID:         ProjectID,
+		SubnetID:          "ext-subnet",
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, OtherFloatingIPID, fip.ID)
+	th.AssertEquals(t, "172.24.5.10", fip.FloatingIP)
+}
+
+func TestAllocateAndAssociate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandlePort(t)
+	HandleListFloatingIPs(t,
+		FloatingIP(OtherFloatingIPID, "172.24.4.10", "", "", "DOWN", 3),
+		FloatingIP(FloatingIPID, "172.24.4.11", "", "", "DOWN", 7),
+	)
+
+	// Another worker associated the first floating IP.
+	th.Mux.HandleFunc("/v2.0/floatingips/"+OtherFloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "If-Match", "revision_number=3")
+
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "If-Match", "revision_number=7")
+		th.TestJSONRequest(t, r, fmt.Sprintf(`
+{
+	"floatingip": {
+		"port_id": "%s",
+		"fixed_ip_address": "10.1.0.5"
+	}
+}`, PortID))
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", PortID, "10.1.0.5", "DOWN", 8))
+	})
+
+	fip, err := fipmanager.AllocateAndAssociate(context.TODO(), fake.ServiceClient(), fipmanager.AllocateOpts{
+		FloatingNetworkID: ExternalNetworkID,
+		ProjectID:         ProjectID,
+	}, PortID, fipmanager.AssociateOpts{})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, FloatingIPID, fip.ID)
+	th.AssertEquals(t, PortID, fip.PortID)
+	th.AssertEquals(t, "10.1.0.5", fip.FixedIP)
+}
+
+func TestAllocateRequiresProject(t *testing.T) {
+	_, err := fipmanager.Allocate(context.TODO(), fake.ServiceClient(), fipmanager.AllocateOpts{
+		FloatingNetworkID: ExternalNetworkID,
+	})
+	th.AssertEquals(t, true, errors.As(err, &gophercloud.ErrMissingInput{}))
+}
+
+func TestAssociateNoRevision(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandlePort(t)
+
+	fip := &floatingips.FloatingIP{ID: FloatingIPID, FloatingNetworkID: ExternalNetworkID}
+	_, err := fipmanager.Associate(context.TODO(), fake.ServiceClient(), fip, PortID, fipmanager.AssociateOpts{})
+	th.AssertEquals(t, true, errors.As(err, &fipmanager.ErrNoRevision{}))
+}
+
+func TestAssociateInvalidFixedIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandlePort(t)
+
+	fip := &floatingips.FloatingIP{ID: FloatingIPID, FloatingNetworkID: ExternalNetworkID}
+	_, err := fipmanager.Associate(context.TODO(), fake.ServiceClient(), fip, PortID, fipmanager.AssociateOpts{
+		FixedIP: "fd00::5",
+	})
+	th.AssertErr(t, err)
+}
+
+func TestWaitForActive(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	polls := 0
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		polls++
+		status := "DOWN"
+		if polls > 1 {
+			status = "ACTIVE"
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", PortID, "10.1.0.5", status, 8+polls))
+	})
+
+	fip, err := fipmanager.WaitForActive(context.TODO(), fake.ServiceClient(), FloatingIPID)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "ACTIVE", fip.Status)
+}
+
+func TestReleaseDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", PortID, "10.1.0.5", "ACTIVE", 9))
+		case "DELETE":
+			th.TestHeader(t, r, "If-Match", "revision_number=9")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	err := fipmanager.Release(context.TODO(), fake.ServiceClient(), FloatingIPID, PortID, fipmanager.ReleaseOpts{Delete: true})
+	th.AssertNoErr(t, err)
+}
+
+func TestReleaseRetriesOnRevisionChange(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	revision, updates := 9, 0
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", PortID, "10.1.0.5", "ACTIVE", revision))
+		case "PUT":
+			updates++
+			th.TestJSONRequest(t, r, `{"floatingip": {"port_id": null}}`)
+			if r.Header.Get("If-Match") != fmt.Sprintf("revision_number=%d", revision) || updates == 1 {
+				// The status of the floating IP changed after it was read.
+				revision++
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", "", "", "DOWN", revision+1))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	err := fipmanager.Release(context.TODO(), fake.ServiceClient(), FloatingIPID, PortID, fipmanager.ReleaseOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, updates)
+}
+
+func TestReleaseNoRevision(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", PortID, "10.1.0.5", "ACTIVE", 0))
+	})
+
+	err := fipmanager.Release(context.TODO(), fake.ServiceClient(), FloatingIPID, PortID, fipmanager.ReleaseOpts{Delete: true})
+	th.AssertEquals(t, true, errors.As(err, &fipmanager.ErrNoRevision{}))
+}
+
+func TestReleaseNotOwner(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/floatingips/"+FloatingIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"floatingip": %s}`, FloatingIP(FloatingIPID, "172.24.4.11", OtherPortID, "10.0.0.9", "ACTIVE", 12))
+	})
+
+	err := fipmanager.Release(context.TODO(), fake.ServiceClient(), FloatingIPID, PortID, fipmanager.ReleaseOpts{Delete: true})
+	notOwner, ok := err.(fipmanager.ErrNotOwner)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, OtherPortID, notOwner.OwnerPortID)
+}
@@ -0,0 +1,29 @@
+package floatingips
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// WaitForStatus will continually poll the resource, checking for a particular
+// status. It fails if the floating IP goes in ERROR status instead.
+func WaitForStatus(ctx context.Context, c *gophercloud.ServiceClient, id, status string) error {
+	return gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		current, err := Get(ctx, c, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		if current.Status == status {
+			return true, nil
+		}
+
+		if current.Status == "ERROR" {
+			return false, fmt.Errorf("floating IP %s is in ERROR status", id)
+		}
+
+		return false, nil
+	})
+}
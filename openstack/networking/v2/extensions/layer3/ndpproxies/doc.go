@@ -0,0 +1,61 @@
+/*
+Package ndpproxies provides information and interaction with the NDP proxies
+of the l3-ndp-proxy extension for the OpenStack Networking service.
+
+An NDP proxy makes the IPv6 address of a port of an internal network
+reachable from the external network of a router, without NAT. The router
+must have its NDP proxies enabled with EnableNDPProxy.
+
+Example to Enable the NDP Proxies of a Router
+
+	enableNDPProxy := true
+	updateOpts := routers.UpdateOpts{
+		EnableNDPProxy: &enableNDPProxy,
+	}
+
+	router, err := routers.Update(context.TODO(), networkClient, "f8a44de0-fc8e-45df-93c7-f79bf3b01c95", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the NDP Proxies of a Router
+
+	listOpts := ndpproxies.ListOpts{
+		RouterID: "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+	}
+
+	allPages, err := ndpproxies.List(networkClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allProxies, err := ndpproxies.ExtractNDPProxies(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, proxy := range allProxies {
+		fmt.Printf("%+v\n", proxy)
+	}
+
+Example to Create an NDP Proxy
+
+	createOpts := ndpproxies.CreateOpts{
+		Name:     "web",
+		RouterID: "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+		PortID:   "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+	}
+
+	proxy, err := ndpproxies.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an NDP Proxy
+
+	err := ndpproxies.Delete(context.TODO(), networkClient, "5d2b1a3c-4e6f-4a8b-9c0d-1e2f3a4b5c6d").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package ndpproxies
@@ -0,0 +1,152 @@
+package ndpproxies
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToNDPProxyListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the NDP proxy attributes you want to see returned. SortKey allows you to
+// sort by a particular NDP proxy attribute. SortDir sets the direction, and is
+// either `asc' or `desc'. Marker and Limit are used for pagination.
+type ListOpts struct {
+	ID             string `q:"id"`
+	Name           string `q:"name"`
+	Description    string `q:"description"`
+	ProjectID      string `q:"project_id"`
+	RouterID       string `q:"router_id"`
+	PortID         string `q:"port_id"`
+	IPAddress      string `q:"ip_address"`
+	RevisionNumber *int   `q:"revision_number"`
+	Limit          int    `q:"limit"`
+	Marker         string `q:"marker"`
+	SortKey        string `q:"sort_key"`
+	SortDir        string `q:"sort_dir"`
+}
+
+// ToNDPProxyListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToNDPProxyListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over a collection of NDP
+// proxies. It accepts a ListOpts struct, which allows you to filter and sort
+// the returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToNDPProxyListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return NDPProxyPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific NDP proxy based on its unique ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToNDPProxyCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new NDP proxy.
+// The router must have EnableNDPProxy set and an external gateway, and the
+// port must be on an IPv6 subnet attached to the router.
+type CreateOpts struct {
+	// Name is a human-readable name of the NDP proxy.
+	Name string `json:"name,omitempty"`
+
+	// Description of the NDP proxy.
+	Description string `json:"description,omitempty"`
+
+	// RouterID is the router answering the neighbor solicitations.
+	RouterID string `json:"router_id" required:"true"`
+
+	// PortID is the port whose IPv6 address is proxied.
+	PortID string `json:"port_id" required:"true"`
+
+	// IPAddress is the IPv6 address of the port to proxy. It is required if
+	// the port has several IPv6 addresses.
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// ToNDPProxyCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToNDPProxyCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "ndp_proxy")
+}
+
+// Create accepts a CreateOpts struct and creates a new NDP proxy using the
+// values provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToNDPProxyCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToNDPProxyUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts represents the attributes used when updating an existing NDP
+// proxy.
+type UpdateOpts struct {
+	// Name is a human-readable name of the NDP proxy.
+	Name *string `json:"name,omitempty"`
+
+	// Description of the NDP proxy.
+	Description *string `json:"description,omitempty"`
+}
+
+// ToNDPProxyUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToNDPProxyUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "ndp_proxy")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing NDP proxy using
+// the values provided.
+func Update(ctx context.Context, c *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToNDPProxyUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, resourceURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the NDP proxy associated with it.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,127 @@
+package ndpproxies
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts an NDP proxy
+// resource.
+func (r commonResult) Extract() (*NDPProxy, error) {
+	var s NDPProxy
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "ndp_proxy")
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as an NDPProxy.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as an NDPProxy.
+type GetResult struct {
+	commonResult
+}
+
+// UpdateResult represents the result of an update operation. Call its Extract
+// method to interpret it as an NDPProxy.
+type UpdateResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// NDPProxy represents a router answering the IPv6 neighbor solicitations for
+// the address of a port on the external network, so that the port is
+// reachable from outside without NAT.
+type NDPProxy struct {
+	// UUID of the NDP proxy.
+	ID string `json:"id"`
+
+	// Name is a human-readable name of the NDP proxy.
+	Name string `json:"name"`
+
+	// Description of the NDP proxy.
+	Description string `json:"description"`
+
+	// ProjectID is the project owner of the NDP proxy.
+	ProjectID string `json:"project_id"`
+
+	// RouterID is the router answering the neighbor solicitations.
+	RouterID string `json:"router_id"`
+
+	// PortID is the port whose IPv6 address is proxied.
+	PortID string `json:"port_id"`
+
+	// IPAddress is the proxied IPv6 address.
+	IPAddress string `json:"ip_address"`
+
+	// RevisionNumber optionally set via extensions/standard-attr-revisions
+	RevisionNumber int `json:"revision_number"`
+
+	// Timestamp when the NDP proxy was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Timestamp when the NDP proxy was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NDPProxyPage is the page returned by a pager when traversing over a
+// collection of NDP proxies.
+type NDPProxyPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of NDP proxies has
+// reached the end of a page and the pager seeks to traverse over a new one.
+// In order to do this, it needs to construct the next page's URL.
+func (r NDPProxyPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"ndp_proxies_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether an NDPProxyPage struct is empty.
+func (r NDPProxyPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractNDPProxies(r)
+	return len(is) == 0, err
+}
+
+// ExtractNDPProxies accepts a Page struct, specifically an NDPProxyPage
+// struct, and extracts the elements into a slice of NDPProxy structs.
+func ExtractNDPProxies(r pagination.Page) ([]NDPProxy, error) {
+	var s []NDPProxy
+	err := ExtractNDPProxiesInto(r, &s)
+	return s, err
+}
+
+// ExtractNDPProxiesInto extracts the elements into a slice of NDPProxy
+// structs.
+func ExtractNDPProxiesInto(r pagination.Page, v any) error {
+	return r.(NDPProxyPage).Result.ExtractIntoSlicePtr(v, "ndp_proxies")
+}
@@ -0,0 +1,101 @@
+package testing
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/ndpproxies"
+)
+
+const (
+	ProxyID  = "5d2b1a3c-4e6f-4a8b-9c0d-1e2f3a4b5c6d"
+	RouterID = "f8a44de0-fc8e-45df-93c7-f79bf3b01c95"
+	PortID   = "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11"
+)
+
+// NDPProxyListResult is the response of a list request.
+const NDPProxyListResult = `
+{
+	"ndp_proxies": [
+		{
+			"id": "5d2b1a3c-4e6f-4a8b-9c0d-1e2f3a4b5c6d",
+			"name": "web",
+			"description": "",
+			"project_id": "45977fa2dbd7482098dd68d0d8970117",
+			"router_id": "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+			"port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+			"ip_address": "2001:db8::10",
+			"revision_number": 0,
+			"created_at": "2023-05-02T12:00:00Z",
+			"updated_at": "2023-05-02T12:00:00Z"
+		}
+	]
+}
+`
+
+// NDPProxyGetResult is the response of a get request.
+const NDPProxyGetResult = `
+{
+	"ndp_proxy": {
+		"id": "5d2b1a3c-4e6f-4a8b-9c0d-1e2f3a4b5c6d",
+		"name": "web",
+		"description": "",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"router_id": "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+		"port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+		"ip_address": "2001:db8::10",
+		"revision_number": 0,
+		"created_at": "2023-05-02T12:00:00Z",
+		"updated_at": "2023-05-02T12:00:00Z"
+	}
+}
+`
+
+// NDPProxyCreateRequest is the request of a create request.
+const NDPProxyCreateRequest = `
+{
+	"ndp_proxy": {
+		"name": "web",
+		"router_id": "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+		"port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11"
+	}
+}
+`
+
+// NDPProxyUpdateRequest is the request of an update request.
+const NDPProxyUpdateRequest = `
+{
+	"ndp_proxy": {
+		"description": "public web server"
+	}
+}
+`
+
+// NDPProxyUpdateResult is the response of an update request.
+const NDPProxyUpdateResult = `
+{
+	"ndp_proxy": {
+		"id": "5d2b1a3c-4e6f-4a8b-9c0d-1e2f3a4b5c6d",
+		"name": "web",
+		"description": "public web server",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"router_id": "f8a44de0-fc8e-45df-93c7-f79bf3b01c95",
+		"port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+		"ip_address": "2001:db8::10",
+		"revision_number": 1,
+		"created_at": "2023-05-02T12:00:00Z",
+		"updated_at": "2023-05-03T07:45:00Z"
+	}
+}
+`
+
+// WebProxy is the NDP proxy of the responses.
+var WebProxy = ndpproxies.NDPProxy{
+	ID:        ProxyID,
+	Name:      "web",
+	ProjectID: "45977fa2dbd7482098dd68d0d8970117",
+	RouterID:  RouterID,
+	PortID:    PortID,
+	IPAddress: "2001:db8::10",
+	CreatedAt: time.Date(2023, time.May, 2, 12, 0, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2023, time.May, 2, 12, 0, 0, 0, time.UTC),
+}
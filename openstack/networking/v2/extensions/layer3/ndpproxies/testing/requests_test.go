@@ -0,0 +1,132 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/ndpproxies"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/ndp_proxies", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"router_id": RouterID})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, NDPProxyListResult)
+	})
+
+	count := 0
+	err := ndpproxies.List(fake.ServiceClient(), ndpproxies.ListOpts{RouterID: RouterID}).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := ndpproxies.ExtractNDPProxies(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []ndpproxies.NDPProxy{WebProxy}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/ndp_proxies/"+ProxyID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, NDPProxyGetResult)
+	})
+
+	proxy, err := ndpproxies.Get(context.TODO(), fake.ServiceClient(), ProxyID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, WebProxy, *proxy)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/ndp_proxies", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, NDPProxyCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, NDPProxyGetResult)
+	})
+
+	proxy, err := ndpproxies.Create(context.TODO(), fake.ServiceClient(), ndpproxies.CreateOpts{
+		Name:     "web",
+		RouterID: RouterID,
+		PortID:   PortID,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, WebProxy, *proxy)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := ndpproxies.Create(context.TODO(), fake.ServiceClient(), ndpproxies.CreateOpts{RouterID: RouterID})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/ndp_proxies/"+ProxyID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, NDPProxyUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, NDPProxyUpdateResult)
+	})
+
+	description := "public web server"
+	proxy, err := ndpproxies.Update(context.TODO(), fake.ServiceClient(), ProxyID, ndpproxies.UpdateOpts{
+		Description: &description,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "public web server", proxy.Description)
+	th.AssertEquals(t, 1, proxy.RevisionNumber)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/ndp_proxies/"+ProxyID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := ndpproxies.Delete(context.TODO(), fake.ServiceClient(), ProxyID)
+	th.AssertNoErr(t, res.Err)
+}
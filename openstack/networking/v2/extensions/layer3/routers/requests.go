@@ -65,6 +65,7 @@ type CreateOpts struct {
 	ProjectID             string       `json:"project_id,omitempty"`
 	GatewayInfo           *GatewayInfo `json:"external_gateway_info,omitempty"`
 	AvailabilityZoneHints []string     `json:"availability_zone_hints,omitempty"`
+	EnableNDPProxy        *bool        `json:"enable_ndp_proxy,omitempty"`
 }
 
 // ToRouterCreateMap builds a create request body from CreateOpts.
@@ -106,12 +107,13 @@ type UpdateOptsBuilder interface {
 
 // UpdateOpts contains the values used when updating a router.
 type UpdateOpts struct {
-	Name         string       `json:"name,omitempty"`
-	Description  *string      `json:"description,omitempty"`
-	AdminStateUp *bool        `json:"admin_state_up,omitempty"`
-	Distributed  *bool        `json:"distributed,omitempty"`
-	GatewayInfo  *GatewayInfo `json:"external_gateway_info,omitempty"`
-	Routes       *[]Route     `json:"routes,omitempty"`
+	Name           string       `json:"name,omitempty"`
+	Description    *string      `json:"description,omitempty"`
+	AdminStateUp   *bool        `json:"admin_state_up,omitempty"`
+	Distributed    *bool        `json:"distributed,omitempty"`
+	GatewayInfo    *GatewayInfo `json:"external_gateway_info,omitempty"`
+	Routes         *[]Route     `json:"routes,omitempty"`
+	EnableNDPProxy *bool        `json:"enable_ndp_proxy,omitempty"`
 }
 
 // ToRouterUpdateMap builds an update body based on UpdateOpts.
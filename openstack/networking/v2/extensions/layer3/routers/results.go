@@ -75,6 +75,11 @@ type Router struct {
 	// Used to make network resources highly available.
 	AvailabilityZoneHints []string `json:"availability_zone_hints"`
 
+	// EnableNDPProxy tells whether the NDP proxies of the router are
+	// enabled. NDP proxies make IPv6 addresses of internal networks reachable
+	// from the external network without NAT.
+	EnableNDPProxy bool `json:"enable_ndp_proxy"`
+
 	// Tags optionally set via extensions/attributestags
 	Tags []string `json:"tags"`
 }
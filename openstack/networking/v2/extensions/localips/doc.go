@@ -0,0 +1,86 @@
+/*
+Package localips provides information and interaction with the Local IP
+extension for the OpenStack Networking service.
+
+A Local IP is a virtual IP which the ports associated with it can be reached
+at from the other ports of the same host, without going through a router.
+This is useful for node-local services, such as DNS caches.
+
+Example to List Local IPs
+
+	allPages, err := localips.List(networkClient, localips.ListOpts{}).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allLocalIPs, err := localips.ExtractLocalIPs(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, localIP := range allLocalIPs {
+		fmt.Printf("%+v\n", localIP)
+	}
+
+Example to Create a Local IP
+
+	createOpts := localips.CreateOpts{
+		Name:      "dns-cache",
+		NetworkID: "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+	}
+
+	localIP, err := localips.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Local IP
+
+	description := "node-local DNS cache"
+	updateOpts := localips.UpdateOpts{
+		Description: &description,
+	}
+
+	localIP, err := localips.Update(context.TODO(), networkClient, "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Associate a Port with a Local IP
+
+	associationOpts := localips.CreateAssociationOpts{
+		FixedPortID: "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01",
+	}
+
+	association, err := localips.CreateAssociation(context.TODO(), networkClient, "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b", associationOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Port Associations of a Local IP
+
+	allPages, err := localips.ListAssociations(networkClient, "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b", localips.ListAssociationsOpts{}).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	associations, err := localips.ExtractAssociations(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Dissociate a Port from a Local IP
+
+	err := localips.DeleteAssociation(context.TODO(), networkClient, "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b", "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Local IP
+
+	err := localips.Delete(context.TODO(), networkClient, "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package localips
@@ -0,0 +1,262 @@
+package localips
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// IPMode is the mode of a Local IP.
+type IPMode string
+
+const (
+	// IPModeTranslate translates the Local IP to the fixed IP of the
+	// associated port.
+	IPModeTranslate IPMode = "translate"
+
+	// IPModePassthrough delivers the traffic to the associated port without
+	// translation.
+	IPModePassthrough IPMode = "passthrough"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToLocalIPListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the Local IP attributes you want to see returned. SortKey allows you to
+// sort by a particular Local IP attribute. SortDir sets the direction, and is
+// either `asc' or `desc'. Marker and Limit are used for pagination.
+type ListOpts struct {
+	ID             string `q:"id"`
+	Name           string `q:"name"`
+	Description    string `q:"description"`
+	ProjectID      string `q:"project_id"`
+	LocalPortID    string `q:"local_port_id"`
+	NetworkID      string `q:"network_id"`
+	LocalIPAddress string `q:"local_ip_address"`
+	IPMode         IPMode `q:"ip_mode"`
+	RevisionNumber *int   `q:"revision_number"`
+	Limit          int    `q:"limit"`
+	Marker         string `q:"marker"`
+	SortKey        string `q:"sort_key"`
+	SortDir        string `q:"sort_dir"`
+}
+
+// ToLocalIPListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToLocalIPListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over a collection of
+// Local IPs. It accepts a ListOpts struct, which allows you to filter and
+// sort the returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToLocalIPListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return LocalIPPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific Local IP based on its unique ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToLocalIPCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new Local IP.
+// Either LocalPortID or NetworkID must be given.
+type CreateOpts struct {
+	// Name is a human-readable name of the Local IP.
+	Name string `json:"name,omitempty"`
+
+	// Description of the Local IP.
+	Description string `json:"description,omitempty"`
+
+	// ProjectID is the project owner of the Local IP. Only administrative
+	// users can specify a project other than their own.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// LocalPortID is the port holding the Local IP address. A port is
+	// created on NetworkID if it is not given.
+	LocalPortID string `json:"local_port_id,omitempty"`
+
+	// NetworkID is the network to create the port of the Local IP on.
+	NetworkID string `json:"network_id,omitempty"`
+
+	// LocalIPAddress is the address of the Local IP, among the fixed IPs of
+	// its port. Required if the port has several fixed IPs.
+	LocalIPAddress string `json:"local_ip_address,omitempty"`
+
+	// IPMode is the mode of the Local IP. Defaults to IPModeTranslate.
+	IPMode IPMode `json:"ip_mode,omitempty"`
+}
+
+// ToLocalIPCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToLocalIPCreateMap() (map[string]any, error) {
+	if opts.LocalPortID == "" && opts.NetworkID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "LocalPortID"}
+	}
+	return gophercloud.BuildRequestBody(opts, "local_ip")
+}
+
+// Create accepts a CreateOpts struct and creates a new Local IP using the
+// values provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToLocalIPCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToLocalIPUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts represents the attributes used when updating an existing Local
+// IP.
+type UpdateOpts struct {
+	// Name is a human-readable name of the Local IP.
+	Name *string `json:"name,omitempty"`
+
+	// Description of the Local IP.
+	Description *string `json:"description,omitempty"`
+}
+
+// ToLocalIPUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToLocalIPUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "local_ip")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing Local IP using
+// the values provided.
+func Update(ctx context.Context, c *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToLocalIPUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, resourceURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the Local IP associated with it. A
+// Local IP with port associations can't be deleted.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListAssociationsOptsBuilder allows extensions to add additional parameters
+// to the ListAssociations request.
+type ListAssociationsOptsBuilder interface {
+	ToLocalIPAssociationListQuery() (string, error)
+}
+
+// ListAssociationsOpts allows the filtering and sorting of the port
+// associations of a Local IP.
+type ListAssociationsOpts struct {
+	FixedPortID    string `q:"fixed_port_id"`
+	FixedIP        string `q:"fixed_ip"`
+	Host           string `q:"host"`
+	LocalIPAddress string `q:"local_ip_address"`
+	Limit          int    `q:"limit"`
+	Marker         string `q:"marker"`
+	SortKey        string `q:"sort_key"`
+	SortDir        string `q:"sort_dir"`
+}
+
+// ToLocalIPAssociationListQuery formats a ListAssociationsOpts into a query
+// string.
+func (opts ListAssociationsOpts) ToLocalIPAssociationListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// ListAssociations returns a Pager which allows you to iterate over the port
+// associations of a Local IP.
+func ListAssociations(c *gophercloud.ServiceClient, id string, opts ListAssociationsOptsBuilder) pagination.Pager {
+	url := associationsURL(c, id)
+	if opts != nil {
+		query, err := opts.ToLocalIPAssociationListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return AssociationPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// CreateAssociationOptsBuilder allows extensions to add additional parameters
+// to the CreateAssociation request.
+type CreateAssociationOptsBuilder interface {
+	ToLocalIPAssociationCreateMap() (map[string]any, error)
+}
+
+// CreateAssociationOpts represents the attributes used when associating a
+// port with a Local IP.
+type CreateAssociationOpts struct {
+	// FixedPortID is the port to associate with the Local IP.
+	FixedPortID string `json:"fixed_port_id" required:"true"`
+
+	// FixedIP is the fixed IP of the port the traffic to the Local IP is
+	// translated to. Required if the port has several fixed IPs.
+	FixedIP string `json:"fixed_ip,omitempty"`
+}
+
+// ToLocalIPAssociationCreateMap builds a request body from
+// CreateAssociationOpts.
+func (opts CreateAssociationOpts) ToLocalIPAssociationCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "port_association")
+}
+
+// CreateAssociation associates a port with a Local IP, so that the traffic
+// sent to the Local IP from the same host is delivered to the port.
+func CreateAssociation(ctx context.Context, c *gophercloud.ServiceClient, id string, opts CreateAssociationOptsBuilder) (r CreateAssociationResult) {
+	b, err := opts.ToLocalIPAssociationCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, associationsURL(c, id), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteAssociation dissociates a port from a Local IP.
+func DeleteAssociation(ctx context.Context, c *gophercloud.ServiceClient, id, portID string) (r DeleteAssociationResult) {
+	resp, err := c.Delete(ctx, associationURL(c, id, portID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
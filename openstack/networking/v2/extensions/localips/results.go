@@ -0,0 +1,206 @@
+package localips
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a Local IP
+// resource.
+func (r commonResult) Extract() (*LocalIP, error) {
+	var s LocalIP
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "local_ip")
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as a LocalIP.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a LocalIP.
+type GetResult struct {
+	commonResult
+}
+
+// UpdateResult represents the result of an update operation. Call its Extract
+// method to interpret it as a LocalIP.
+type UpdateResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// LocalIP represents a Local IP: a virtual IP reachable from the ports
+// associated with it on the same host, without going through a router.
+type LocalIP struct {
+	// UUID of the Local IP.
+	ID string `json:"id"`
+
+	// Name is a human-readable name of the Local IP.
+	Name string `json:"name"`
+
+	// Description of the Local IP.
+	Description string `json:"description"`
+
+	// ProjectID is the project owner of the Local IP.
+	ProjectID string `json:"project_id"`
+
+	// LocalPortID is the port holding the Local IP address.
+	LocalPortID string `json:"local_port_id"`
+
+	// NetworkID is the network of the port of the Local IP.
+	NetworkID string `json:"network_id"`
+
+	// LocalIPAddress is the address of the Local IP.
+	LocalIPAddress string `json:"local_ip_address"`
+
+	// IPMode is the mode of the Local IP.
+	IPMode IPMode `json:"ip_mode"`
+
+	// RevisionNumber optionally set via extensions/standard-attr-revisions
+	RevisionNumber int `json:"revision_number"`
+
+	// Timestamp when the Local IP was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Timestamp when the Local IP was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LocalIPPage is the page returned by a pager when traversing over a
+// collection of Local IPs.
+type LocalIPPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of Local IPs has reached
+// the end of a page and the pager seeks to traverse over a new one. In order
+// to do this, it needs to construct the next page's URL.
+func (r LocalIPPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"local_ips_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether a LocalIPPage struct is empty.
+func (r LocalIPPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractLocalIPs(r)
+	return len(is) == 0, err
+}
+
+// ExtractLocalIPs accepts a Page struct, specifically a LocalIPPage struct,
+// and extracts the elements into a slice of LocalIP structs.
+func ExtractLocalIPs(r pagination.Page) ([]LocalIP, error) {
+	var s []LocalIP
+	err := ExtractLocalIPsInto(r, &s)
+	return s, err
+}
+
+// ExtractLocalIPsInto extracts the elements into a slice of LocalIP structs.
+func ExtractLocalIPsInto(r pagination.Page, v any) error {
+	return r.(LocalIPPage).Result.ExtractIntoSlicePtr(v, "local_ips")
+}
+
+// Association represents the association of a port with a Local IP.
+type Association struct {
+	// LocalIPID is the UUID of the Local IP.
+	LocalIPID string `json:"local_ip_id"`
+
+	// LocalIPAddress is the address of the Local IP.
+	LocalIPAddress string `json:"local_ip_address"`
+
+	// FixedPortID is the associated port.
+	FixedPortID string `json:"fixed_port_id"`
+
+	// FixedIP is the fixed IP of the port the traffic to the Local IP is
+	// translated to.
+	FixedIP string `json:"fixed_ip"`
+
+	// Host is the host the associated port is bound to.
+	Host string `json:"host"`
+}
+
+// CreateAssociationResult represents the result of a create association
+// operation. Call its Extract method to interpret it as an Association.
+type CreateAssociationResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts an Association
+// resource.
+func (r CreateAssociationResult) Extract() (*Association, error) {
+	var s Association
+	err := r.ExtractIntoStructPtr(&s, "port_association")
+	return &s, err
+}
+
+// DeleteAssociationResult represents the result of a delete association
+// operation. Call its ExtractErr method to determine if the request succeeded
+// or failed.
+type DeleteAssociationResult struct {
+	gophercloud.ErrResult
+}
+
+// AssociationPage is the page returned by a pager when traversing over the
+// port associations of a Local IP.
+type AssociationPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of port associations has
+// reached the end of a page and the pager seeks to traverse over a new one.
+// In order to do this, it needs to construct the next page's URL.
+func (r AssociationPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"port_associations_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether an AssociationPage struct is empty.
+func (r AssociationPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractAssociations(r)
+	return len(is) == 0, err
+}
+
+// ExtractAssociations accepts a Page struct, specifically an AssociationPage
+// struct, and extracts the elements into a slice of Association structs.
+func ExtractAssociations(r pagination.Page) ([]Association, error) {
+	var s []Association
+	err := r.(AssociationPage).Result.ExtractIntoSlicePtr(&s, "port_associations")
+	return s, err
+}
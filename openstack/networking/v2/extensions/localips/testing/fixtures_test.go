@@ -0,0 +1,152 @@
+package testing
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/localips"
+)
+
+const (
+	LocalIPID   = "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b"
+	LocalPortID = "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11"
+	NetworkID   = "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	FixedPortID = "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01"
+)
+
+// LocalIPListResult is the response of a list request.
+const LocalIPListResult = `
+{
+	"local_ips": [
+		{
+			"id": "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b",
+			"name": "dns-cache",
+			"description": "",
+			"project_id": "45977fa2dbd7482098dd68d0d8970117",
+			"local_port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"local_ip_address": "10.0.0.53",
+			"ip_mode": "translate",
+			"revision_number": 0,
+			"created_at": "2023-03-01T10:00:00Z",
+			"updated_at": "2023-03-01T10:00:00Z"
+		}
+	]
+}
+`
+
+// LocalIPGetResult is the response of a get request.
+const LocalIPGetResult = `
+{
+	"local_ip": {
+		"id": "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b",
+		"name": "dns-cache",
+		"description": "",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"local_port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"local_ip_address": "10.0.0.53",
+		"ip_mode": "translate",
+		"revision_number": 0,
+		"created_at": "2023-03-01T10:00:00Z",
+		"updated_at": "2023-03-01T10:00:00Z"
+	}
+}
+`
+
+// LocalIPCreateRequest is the request of a create request.
+const LocalIPCreateRequest = `
+{
+	"local_ip": {
+		"name": "dns-cache",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"local_ip_address": "10.0.0.53"
+	}
+}
+`
+
+// LocalIPUpdateRequest is the request of an update request.
+const LocalIPUpdateRequest = `
+{
+	"local_ip": {
+		"description": "node-local DNS cache"
+	}
+}
+`
+
+// LocalIPUpdateResult is the response of an update request.
+const LocalIPUpdateResult = `
+{
+	"local_ip": {
+		"id": "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b",
+		"name": "dns-cache",
+		"description": "node-local DNS cache",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"local_port_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"local_ip_address": "10.0.0.53",
+		"ip_mode": "translate",
+		"revision_number": 1,
+		"created_at": "2023-03-01T10:00:00Z",
+		"updated_at": "2023-03-02T08:30:00Z"
+	}
+}
+`
+
+// AssociationListResult is the response of a list associations request.
+const AssociationListResult = `
+{
+	"port_associations": [
+		{
+			"local_ip_id": "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b",
+			"local_ip_address": "10.0.0.53",
+			"fixed_port_id": "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01",
+			"fixed_ip": "10.0.0.10",
+			"host": "compute1"
+		}
+	]
+}
+`
+
+// AssociationCreateRequest is the request of a create association request.
+const AssociationCreateRequest = `
+{
+	"port_association": {
+		"fixed_port_id": "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01"
+	}
+}
+`
+
+// AssociationCreateResult is the response of a create association request.
+const AssociationCreateResult = `
+{
+	"port_association": {
+		"local_ip_id": "3a1c2d4e-5f60-4718-8a9b-0c1d2e3f4a5b",
+		"local_ip_address": "10.0.0.53",
+		"fixed_port_id": "6c4a2f1e-0d3b-4c5a-8e7f-1a2b3c4d5e01",
+		"fixed_ip": "10.0.0.10",
+		"host": "compute1"
+	}
+}
+`
+
+// DNSCache is the Local IP of the responses.
+var DNSCache = localips.LocalIP{
+	ID:             LocalIPID,
+	Name:           "dns-cache",
+	ProjectID:      "45977fa2dbd7482098dd68d0d8970117",
+	LocalPortID:    LocalPortID,
+	NetworkID:      NetworkID,
+	LocalIPAddress: "10.0.0.53",
+	IPMode:         localips.IPModeTranslate,
+	CreatedAt:      time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+	UpdatedAt:      time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+}
+
+// Association is the port association of the responses.
+var Association = localips.Association{
+	LocalIPID:      LocalIPID,
+	LocalIPAddress: "10.0.0.53",
+	FixedPortID:    FixedPortID,
+	FixedIP:        "10.0.0.10",
+	Host:           "compute1",
+}
@@ -0,0 +1,192 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/localips"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"network_id": NetworkID})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LocalIPListResult)
+	})
+
+	count := 0
+	err := localips.List(fake.ServiceClient(), localips.ListOpts{NetworkID: NetworkID}).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := localips.ExtractLocalIPs(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []localips.LocalIP{DNSCache}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LocalIPGetResult)
+	})
+
+	localIP, err := localips.Get(context.TODO(), fake.ServiceClient(), LocalIPID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, DNSCache, *localIP)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, LocalIPCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, LocalIPGetResult)
+	})
+
+	localIP, err := localips.Create(context.TODO(), fake.ServiceClient(), localips.CreateOpts{
+		Name:           "dns-cache",
+		NetworkID:      NetworkID,
+		LocalIPAddress: "10.0.0.53",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, DNSCache, *localIP)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := localips.Create(context.TODO(), fake.ServiceClient(), localips.CreateOpts{Name: "dns-cache"})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, LocalIPUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LocalIPUpdateResult)
+	})
+
+	description := "node-local DNS cache"
+	localIP, err := localips.Update(context.TODO(), fake.ServiceClient(), LocalIPID, localips.UpdateOpts{
+		Description: &description,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "node-local DNS cache", localIP.Description)
+	th.AssertEquals(t, 1, localIP.RevisionNumber)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := localips.Delete(context.TODO(), fake.ServiceClient(), LocalIPID)
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestListAssociations(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID+"/port_associations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"host": "compute1"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, AssociationListResult)
+	})
+
+	allPages, err := localips.ListAssociations(fake.ServiceClient(), LocalIPID, localips.ListAssociationsOpts{Host: "compute1"}).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := localips.ExtractAssociations(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []localips.Association{Association}, actual)
+}
+
+func TestCreateAssociation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID+"/port_associations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, AssociationCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, AssociationCreateResult)
+	})
+
+	association, err := localips.CreateAssociation(context.TODO(), fake.ServiceClient(), LocalIPID, localips.CreateAssociationOpts{
+		FixedPortID: FixedPortID,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Association, *association)
+}
+
+func TestDeleteAssociation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/local_ips/"+LocalIPID+"/port_associations/"+FixedPortID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := localips.DeleteAssociation(context.TODO(), fake.ServiceClient(), LocalIPID, FixedPortID)
+	th.AssertNoErr(t, res.Err)
+}
@@ -0,0 +1,21 @@
+package localips
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+const resourcePath = "local_ips"
+
+func rootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(resourcePath)
+}
+
+func resourceURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL(resourcePath, id)
+}
+
+func associationsURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL(resourcePath, id, "port_associations")
+}
+
+func associationURL(c *gophercloud.ServiceClient, id, portID string) string {
+	return c.ServiceURL(resourcePath, id, "port_associations", portID)
+}
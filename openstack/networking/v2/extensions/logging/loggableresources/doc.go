@@ -0,0 +1,21 @@
+/*
+Package loggableresources lists the types of the resources the logging
+extension of the OpenStack Networking service can log.
+
+Example to List Loggable Resources
+
+	allPages, err := loggableresources.List(networkClient).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allResources, err := loggableresources.ExtractLoggableResources(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, resource := range allResources {
+		fmt.Println(resource.Type)
+	}
+*/
+package loggableresources
@@ -0,0 +1,40 @@
+package loggableresources
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// LoggableResource represents a type of resource logs can be created for.
+type LoggableResource struct {
+	// Type is the resource type, such as "security_group" or
+	// "firewall_group".
+	Type string `json:"type"`
+}
+
+// LoggableResourcePage is the page returned by a pager when traversing over
+// a collection of loggable resources.
+type LoggableResourcePage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty checks whether a LoggableResourcePage struct is empty.
+func (r LoggableResourcePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	v, err := ExtractLoggableResources(r)
+	return len(v) == 0, err
+}
+
+// ExtractLoggableResources accepts a Page struct, specifically a
+// LoggableResourcePage struct, and extracts the elements into a slice of
+// LoggableResource structs.
+func ExtractLoggableResources(r pagination.Page) ([]LoggableResource, error) {
+	var s struct {
+		LoggableResources []LoggableResource `json:"loggable_resources"`
+	}
+
+	err := (r.(LoggableResourcePage)).ExtractInto(&s)
+	return s.LoggableResources, err
+}
@@ -0,0 +1,2 @@
+// loggableresources unit tests
+package testing
@@ -0,0 +1,15 @@
+package testing
+
+// ListResponse is the response of a list request.
+const ListResponse = `
+{
+	"loggable_resources": [
+		{
+			"type": "security_group"
+		},
+		{
+			"type": "firewall_group"
+		}
+	]
+}
+`
@@ -0,0 +1,36 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/logging/loggableresources"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/loggable-resources", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, ListResponse)
+	})
+
+	allPages, err := loggableresources.List(fake.ServiceClient()).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := loggableresources.ExtractLoggableResources(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []loggableresources.LoggableResource{
+		{Type: "security_group"},
+		{Type: "firewall_group"},
+	}, actual)
+}
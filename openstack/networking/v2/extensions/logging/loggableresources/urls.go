@@ -0,0 +1,7 @@
+package loggableresources
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+func listURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("log", "loggable-resources")
+}
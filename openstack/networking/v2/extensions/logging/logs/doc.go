@@ -0,0 +1,76 @@
+/*
+Package logs provides information and interaction with the logging extension
+for the OpenStack Networking service.
+
+A log records the packets accepted or dropped by security groups or FWaaS v2
+firewall groups, for a group, for a port, or for all the groups of a project.
+The resource types the deployment can log are listed by the
+loggableresources package.
+
+Example to List Logs
+
+	listOpts := logs.ListOpts{
+		ResourceType: logs.ResourceTypeSecurityGroup,
+	}
+
+	allPages, err := logs.List(networkClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allLogs, err := logs.ExtractLogs(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, log := range allLogs {
+		fmt.Printf("%+v\n", log)
+	}
+
+Example to Log the Dropped Packets of a Security Group
+
+	createOpts := logs.CreateOpts{
+		Name:         "web-drops",
+		ResourceType: logs.ResourceTypeSecurityGroup,
+		ResourceID:   "85cc3048-abc3-43cc-89b3-377341426ac5",
+		Event:        logs.EventDrop,
+	}
+
+	log, err := logs.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Log All the Packets of a Port Evaluated by Firewall Groups
+
+	createOpts := logs.CreateOpts{
+		ResourceType: logs.ResourceTypeFirewallGroup,
+		TargetID:     "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+		Event:        logs.EventAll,
+	}
+
+	log, err := logs.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Disable a Log
+
+	enabled := false
+	updateOpts := logs.UpdateOpts{
+		Enabled: &enabled,
+	}
+
+	log, err := logs.Update(context.TODO(), networkClient, "2f245a7b-796b-4f26-9cf9-9e82d248fda7", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Log
+
+	err := logs.Delete(context.TODO(), networkClient, "2f245a7b-796b-4f26-9cf9-9e82d248fda7").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package logs
@@ -0,0 +1,207 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// ResourceType is the type of the resource a log is enabled for.
+type ResourceType string
+
+const (
+	// ResourceTypeSecurityGroup logs the traffic evaluated by security
+	// groups.
+	ResourceTypeSecurityGroup ResourceType = "security_group"
+
+	// ResourceTypeFirewallGroup logs the traffic evaluated by FWaaS v2
+	// firewall groups.
+	ResourceTypeFirewallGroup ResourceType = "firewall_group"
+)
+
+// Event is the type of the packets a log records.
+type Event string
+
+const (
+	// EventAccept records the accepted packets.
+	EventAccept Event = "ACCEPT"
+
+	// EventDrop records the dropped packets.
+	EventDrop Event = "DROP"
+
+	// EventAll records all the packets.
+	EventAll Event = "ALL"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToLogListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the log attributes you want to see returned. SortKey allows you to sort by a
+// particular log attribute. SortDir sets the direction, and is either `asc' or
+// `desc'. Marker and Limit are used for pagination.
+type ListOpts struct {
+	ID             string       `q:"id"`
+	Name           string       `q:"name"`
+	Description    string       `q:"description"`
+	ProjectID      string       `q:"project_id"`
+	ResourceType   ResourceType `q:"resource_type"`
+	ResourceID     string       `q:"resource_id"`
+	TargetID       string       `q:"target_id"`
+	Event          Event        `q:"event"`
+	Enabled        *bool        `q:"enabled"`
+	RevisionNumber *int         `q:"revision_number"`
+	Limit          int          `q:"limit"`
+	Marker         string       `q:"marker"`
+	SortKey        string       `q:"sort_key"`
+	SortDir        string       `q:"sort_dir"`
+}
+
+// ToLogListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToLogListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over a collection of
+// logs. It accepts a ListOpts struct, which allows you to filter and sort the
+// returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToLogListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return LogPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific log based on its unique ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToLogCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new log.
+//
+// ResourceID and TargetID select the logged traffic: ResourceID is a
+// security group or a firewall group, and TargetID is a port. Setting both
+// logs the traffic of the port evaluated by this group only, and setting
+// neither logs all the groups of the project of the ResourceType.
+type CreateOpts struct {
+	// Name is a human-readable name of the log.
+	Name string `json:"name,omitempty"`
+
+	// Description of the log.
+	Description string `json:"description,omitempty"`
+
+	// ProjectID is the project owner of the log. Only administrative users
+	// can specify a project other than their own.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// ResourceType is the type of the logged resource.
+	ResourceType ResourceType `json:"resource_type" required:"true"`
+
+	// ResourceID is the ID of the logged security group or firewall group.
+	ResourceID string `json:"resource_id,omitempty"`
+
+	// TargetID is the ID of the logged port.
+	TargetID string `json:"target_id,omitempty"`
+
+	// Event is the type of the logged packets. Defaults to EventAll.
+	Event Event `json:"event,omitempty"`
+
+	// Enabled enables the log. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ToLogCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToLogCreateMap() (map[string]any, error) {
+	switch opts.Event {
+	case "", EventAccept, EventDrop, EventAll:
+	default:
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "Event"
+		invalid.Value = opts.Event
+		invalid.Info = fmt.Sprintf("must be %s, %s or %s", EventAccept, EventDrop, EventAll)
+		return nil, invalid
+	}
+	return gophercloud.BuildRequestBody(opts, "log")
+}
+
+// Create accepts a CreateOpts struct and creates a new log using the values
+// provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToLogCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToLogUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts represents the attributes used when updating an existing log.
+// The logged resource and event can't be changed: create another log
+// instead.
+type UpdateOpts struct {
+	// Name is a human-readable name of the log.
+	Name *string `json:"name,omitempty"`
+
+	// Description of the log.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled enables or disables the log.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ToLogUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToLogUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "log")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing log using the
+// values provided.
+func Update(ctx context.Context, c *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToLogUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Put(ctx, resourceURL(c, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the log associated with it.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,131 @@
+package logs
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a log resource.
+func (r commonResult) Extract() (*Log, error) {
+	var s Log
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "log")
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as a Log.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Log.
+type GetResult struct {
+	commonResult
+}
+
+// UpdateResult represents the result of an update operation. Call its Extract
+// method to interpret it as a Log.
+type UpdateResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Log represents the logging of the packets evaluated by security groups or
+// firewall groups.
+type Log struct {
+	// UUID of the log.
+	ID string `json:"id"`
+
+	// Name is a human-readable name of the log.
+	Name string `json:"name"`
+
+	// Description of the log.
+	Description string `json:"description"`
+
+	// ProjectID is the project owner of the log.
+	ProjectID string `json:"project_id"`
+
+	// ResourceType is the type of the logged resource.
+	ResourceType ResourceType `json:"resource_type"`
+
+	// ResourceID is the ID of the logged security group or firewall group,
+	// if any.
+	ResourceID string `json:"resource_id"`
+
+	// TargetID is the ID of the logged port, if any.
+	TargetID string `json:"target_id"`
+
+	// Event is the type of the logged packets.
+	Event Event `json:"event"`
+
+	// Enabled tells whether the log is enabled.
+	Enabled bool `json:"enabled"`
+
+	// RevisionNumber optionally set via extensions/standard-attr-revisions
+	RevisionNumber int `json:"revision_number"`
+
+	// Timestamp when the log was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Timestamp when the log was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LogPage is the page returned by a pager when traversing over a collection
+// of logs.
+type LogPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of logs has reached the
+// end of a page and the pager seeks to traverse over a new one. In order to
+// do this, it needs to construct the next page's URL.
+func (r LogPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"logs_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether a LogPage struct is empty.
+func (r LogPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractLogs(r)
+	return len(is) == 0, err
+}
+
+// ExtractLogs accepts a Page struct, specifically a LogPage struct, and
+// extracts the elements into a slice of Log structs.
+func ExtractLogs(r pagination.Page) ([]Log, error) {
+	var s []Log
+	err := ExtractLogsInto(r, &s)
+	return s, err
+}
+
+// ExtractLogsInto extracts the elements into a slice of Log structs.
+func ExtractLogsInto(r pagination.Page, v any) error {
+	return r.(LogPage).Result.ExtractIntoSlicePtr(v, "logs")
+}
@@ -0,0 +1,2 @@
+// logs unit tests
+package testing
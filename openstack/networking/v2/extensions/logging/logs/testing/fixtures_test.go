@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"time"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/logging/logs"
+)
+
+const (
+	LogID           = "2f245a7b-796b-4f26-9cf9-9e82d248fda7"
+	SecurityGroupID = "85cc3048-abc3-43cc-89b3-377341426ac5"
+)
+
+// LogListResult is the response of a list request.
+const LogListResult = `
+{
+	"logs": [
+		{
+			"id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+			"name": "web-drops",
+			"description": "",
+			"project_id": "45977fa2dbd7482098dd68d0d8970117",
+			"resource_type": "security_group",
+			"resource_id": "85cc3048-abc3-43cc-89b3-377341426ac5",
+			"target_id": null,
+			"event": "DROP",
+			"enabled": true,
+			"revision_number": 0,
+			"created_at": "2023-04-10T09:00:00Z",
+			"updated_at": "2023-04-10T09:00:00Z"
+		}
+	]
+}
+`
+
+// LogGetResult is the response of a get request.
+const LogGetResult = `
+{
+	"log": {
+		"id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+		"name": "web-drops",
+		"description": "",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"resource_type": "security_group",
+		"resource_id": "85cc3048-abc3-43cc-89b3-377341426ac5",
+		"target_id": null,
+		"event": "DROP",
+		"enabled": true,
+		"revision_number": 0,
+		"created_at": "2023-04-10T09:00:00Z",
+		"updated_at": "2023-04-10T09:00:00Z"
+	}
+}
+`
+
+// LogCreateRequest is the request of a create request.
+const LogCreateRequest = `
+{
+	"log": {
+		"name": "web-drops",
+		"resource_type": "security_group",
+		"resource_id": "85cc3048-abc3-43cc-89b3-377341426ac5",
+		"event": "DROP"
+	}
+}
+`
+
+// LogUpdateRequest is the request of an update request.
+const LogUpdateRequest = `
+{
+	"log": {
+		"enabled": false
+	}
+}
+`
+
+// LogUpdateResult is the response of an update request.
+const LogUpdateResult = `
+{
+	"log": {
+		"id": "2f245a7b-796b-4f26-9cf9-9e82d248fda7",
+		"name": "web-drops",
+		"description": "",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"resource_type": "security_group",
+		"resource_id": "85cc3048-abc3-43cc-89b3-377341426ac5",
+		"target_id": null,
+		"event": "DROP",
+		"enabled": false,
+		"revision_number": 1,
+		"created_at": "2023-04-10T09:00:00Z",
+		"updated_at": "2023-04-11T16:20:00Z"
+	}
+}
+`
+
+// WebDrops is the log of the responses.
+var WebDrops = logs.Log{
+	ID:           LogID,
+	Name:         "web-drops",
+	ProjectID:    "45977fa2dbd7482098dd68d0d8970117",
+	ResourceType: logs.ResourceTypeSecurityGroup,
+	ResourceID:   SecurityGroupID,
+	Event:        logs.EventDrop,
+	Enabled:      true,
+	CreatedAt:    time.Date(2023, time.April, 10, 9, 0, 0, 0, time.UTC),
+	UpdatedAt:    time.Date(2023, time.April, 10, 9, 0, 0, 0, time.UTC),
+}
@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/logging/logs"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/logs", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"resource_type": "security_group",
+			"enabled":       "true",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LogListResult)
+	})
+
+	enabled := true
+	listOpts := logs.ListOpts{
+		ResourceType: logs.ResourceTypeSecurityGroup,
+		Enabled:      &enabled,
+	}
+
+	count := 0
+	err := logs.List(fake.ServiceClient(), listOpts).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := logs.ExtractLogs(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []logs.Log{WebDrops}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/logs/"+LogID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LogGetResult)
+	})
+
+	log, err := logs.Get(context.TODO(), fake.ServiceClient(), LogID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, WebDrops, *log)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/logs", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, LogCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, LogGetResult)
+	})
+
+	log, err := logs.Create(context.TODO(), fake.ServiceClient(), logs.CreateOpts{
+		Name:         "web-drops",
+		ResourceType: logs.ResourceTypeSecurityGroup,
+		ResourceID:   SecurityGroupID,
+		Event:        logs.EventDrop,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, WebDrops, *log)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := logs.Create(context.TODO(), fake.ServiceClient(), logs.CreateOpts{Name: "web-drops"})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+
+	res = logs.Create(context.TODO(), fake.ServiceClient(), logs.CreateOpts{
+		ResourceType: logs.ResourceTypeSecurityGroup,
+		Event:        "REJECT",
+	})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/logs/"+LogID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, LogUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, LogUpdateResult)
+	})
+
+	enabled := false
+	log, err := logs.Update(context.TODO(), fake.ServiceClient(), LogID, logs.UpdateOpts{
+		Enabled: &enabled,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, log.Enabled)
+	th.AssertEquals(t, 1, log.RevisionNumber)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/log/logs/"+LogID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := logs.Delete(context.TODO(), fake.ServiceClient(), LogID)
+	th.AssertNoErr(t, res.Err)
+}
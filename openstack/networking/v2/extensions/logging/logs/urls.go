@@ -0,0 +1,11 @@
+package logs
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+func rootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("log", "logs")
+}
+
+func resourceURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("log", "logs", id)
+}
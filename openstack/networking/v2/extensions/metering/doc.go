@@ -0,0 +1,4 @@
+// Package metering provides information and interaction with the metering
+// extension for the OpenStack Networking service. Metering labels and their
+// rules count the traffic forwarded by the routers of a project.
+package metering
@@ -0,0 +1,38 @@
+/*
+Package egress sets up the metering of the traffic of VPCs to the Internet,
+to bill it from the counters of the metering extension without a separate
+agent.
+
+The traffic of a VPC is counted by a metering label with an egress rule from
+the CIDR of the VPC to any destination, and excluded egress rules to the
+private address ranges, so that the traffic to peered networks or to other
+VPCs through the same router is not billed.
+
+Example to Set Up the Egress Accounting of a VPC
+
+	accounting, err := egress.Ensure(context.TODO(), networkClient, "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b", egress.Opts{})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("VPC traffic counted by metering label %s on routers %v\n", accounting.Label.ID, accounting.RouterIDs)
+
+Example to Also Not Bill the Traffic to a Partner Network
+
+	opts := egress.Opts{
+		ExcludedCIDRs: append([]string{"203.0.113.0/24"}, egress.DefaultExcludedCIDRs...),
+	}
+
+	accounting, err := egress.Ensure(context.TODO(), networkClient, "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b", opts)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Remove the Egress Accounting of a VPC
+
+	err := egress.Remove(context.TODO(), networkClient, "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b", egress.Opts{})
+	if err != nil {
+		panic(err)
+	}
+*/
+package egress
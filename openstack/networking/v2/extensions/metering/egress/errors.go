@@ -0,0 +1,20 @@
+package egress
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrNoRouter is returned by Ensure when no router with an external gateway
+// has an interface on the subnets of the VPC, so that no traffic of the VPC
+// would be counted.
+type ErrNoRouter struct {
+	gophercloud.BaseError
+
+	VPCID string
+}
+
+func (e ErrNoRouter) Error() string {
+	return fmt.Sprintf("no router with an external gateway has an interface on the subnets of VPC %s", e.VPCID)
+}
@@ -0,0 +1,290 @@
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/labels"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/deviceowners"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/subnets"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/vpcs"
+)
+
+// DefaultExcludedCIDRs are the destinations which are not the Internet: the
+// private, shared, link-local and unique local address ranges.
+var DefaultExcludedCIDRs = []string{
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// Opts configures Ensure and Remove.
+type Opts struct {
+	// Name is the name of the metering label. Defaults to
+	// "internet-egress-" followed by the ID of the VPC.
+	Name string
+
+	// ProjectID is the project of the metering label, which must be the
+	// project of the routers of the VPC. Defaults to the project of the
+	// client.
+	ProjectID string
+
+	// ExcludedCIDRs are the destinations whose traffic is not counted.
+	// Defaults to DefaultExcludedCIDRs.
+	ExcludedCIDRs []string
+}
+
+func (opts Opts) name(vpcID string) string {
+	if opts.Name != "" {
+		return opts.Name
+	}
+	return "internet-egress-" + vpcID
+}
+
+// Ensure sets up the metering of the traffic of a VPC leaving its routers
+// to the Internet: a metering label with an egress rule from the VPC to any
+// destination, and excluded egress rules to the ExcludedCIDRs.
+//
+// Ensure can be called again: the metering label is found by name, the
+// missing rules are created and the rules not matching the options are
+// deleted. It returns an ErrNoRouter if the VPC has no router with an
+// external gateway yet.
+//
+// Metering labels apply to all the routers of their project, so that the
+// rules match the source addresses of the VPC to count its traffic only.
+// The counters are reported by the metering agent to the telemetry service.
+func Ensure(ctx context.Context, client *gophercloud.ServiceClient, vpcID string, opts Opts) (*Accounting, error) {
+	if vpcID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "vpcID"}
+	}
+
+	excludedCIDRs := opts.ExcludedCIDRs
+	if excludedCIDRs == nil {
+		excludedCIDRs = DefaultExcludedCIDRs
+	}
+	excluded := make([]netip.Prefix, 0, len(excludedCIDRs))
+	for _, cidr := range excludedCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			invalid := gophercloud.ErrInvalidInput{}
+			invalid.Argument = "ExcludedCIDRs"
+			invalid.Value = cidr
+			invalid.Info = err.Error()
+			return nil, invalid
+		}
+		excluded = append(excluded, prefix.Masked())
+	}
+
+	vpc, err := vpcs.Get(ctx, client, vpcID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	allPages, err := subnets.List(client, subnets.ListOpts{VPCID: vpcID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vpcSubnets, err := subnets.ExtractSubnets(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	routerIDs, err := gatewayRouters(ctx, client, vpcSubnets)
+	if err != nil {
+		return nil, err
+	}
+	if len(routerIDs) == 0 {
+		return nil, ErrNoRouter{VPCID: vpcID}
+	}
+
+	var sources []netip.Prefix
+	if vpc.CIDR != "" {
+		prefix, err := netip.ParsePrefix(vpc.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, prefix.Masked())
+	} else {
+		for _, subnet := range vpcSubnets {
+			prefix, err := netip.ParsePrefix(subnet.CIDR)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, prefix.Masked())
+		}
+	}
+
+	accounting := &Accounting{VPCID: vpcID, RouterIDs: routerIDs}
+	wanted := make(map[rules.CreateOpts]bool)
+	var wantedOrder []rules.CreateOpts
+	for _, source := range sources {
+		accounting.SourceCIDRs = append(accounting.SourceCIDRs, source.String())
+
+		anywhere := "0.0.0.0/0"
+		if source.Addr().Is6() {
+			anywhere = "::/0"
+		}
+		ruleOpts := []rules.CreateOpts{{
+			Direction:           rules.DirEgress,
+			SourceIPPrefix:      source.String(),
+			DestinationIPPrefix: anywhere,
+		}}
+		for _, prefix := range excluded {
+			if prefix.Addr().Is6() != source.Addr().Is6() {
+				continue
+			}
+			ruleOpts = append(ruleOpts, rules.CreateOpts{
+				Direction:           rules.DirEgress,
+				SourceIPPrefix:      source.String(),
+				DestinationIPPrefix: prefix.String(),
+				Excluded:            true,
+			})
+		}
+		for _, o := range ruleOpts {
+			if !wanted[o] {
+				wanted[o] = true
+				wantedOrder = append(wantedOrder, o)
+			}
+		}
+	}
+
+	label, err := findLabel(ctx, client, opts.name(vpcID), opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		label, err = labels.Create(ctx, client, labels.CreateOpts{
+			Name:        opts.name(vpcID),
+			Description: fmt.Sprintf("Internet egress traffic of VPC %s", vpcID),
+			ProjectID:   opts.ProjectID,
+		}).Extract()
+		if err != nil {
+			return nil, err
+		}
+	}
+	accounting.Label = *label
+
+	allPages, err = rules.List(client, rules.ListOpts{MeteringLabelID: label.ID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := rules.ExtractRules(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[rules.CreateOpts]bool)
+	for _, rule := range existing {
+		key := rules.CreateOpts{
+			Direction:           rule.Direction,
+			SourceIPPrefix:      rule.SourceIPPrefix,
+			DestinationIPPrefix: rule.DestinationIPPrefix,
+			Excluded:            rule.Excluded,
+		}
+		if !wanted[key] || found[key] {
+			err := rules.Delete(ctx, client, rule.ID).ExtractErr()
+			if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return nil, err
+			}
+			continue
+		}
+		found[key] = true
+		accounting.Rules = append(accounting.Rules, rule)
+	}
+
+	for _, o := range wantedOrder {
+		if found[o] {
+			continue
+		}
+		o.MeteringLabelID = label.ID
+		rule, err := rules.Create(ctx, client, o).Extract()
+		if err != nil {
+			return nil, err
+		}
+		accounting.Rules = append(accounting.Rules, *rule)
+	}
+
+	return accounting, nil
+}
+
+// Remove deletes the metering label set up by Ensure for a VPC, along with
+// its rules. It does nothing if there is no such metering label.
+func Remove(ctx context.Context, client *gophercloud.ServiceClient, vpcID string, opts Opts) error {
+	if vpcID == "" {
+		return gophercloud.ErrMissingInput{Argument: "vpcID"}
+	}
+
+	label, err := findLabel(ctx, client, opts.name(vpcID), opts.ProjectID)
+	if err != nil || label == nil {
+		return err
+	}
+	err = labels.Delete(ctx, client, label.ID).ExtractErr()
+	if gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+// findLabel returns the metering label with a name, or nil if there is none.
+func findLabel(ctx context.Context, client *gophercloud.ServiceClient, name, projectID string) (*labels.Label, error) {
+	allPages, err := labels.List(client, labels.ListOpts{Name: name, ProjectID: projectID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allLabels, err := labels.ExtractLabels(allPages)
+	if err != nil {
+		return nil, err
+	}
+	for i := range allLabels {
+		if allLabels[i].Name == name {
+			return &allLabels[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// gatewayRouters returns the sorted IDs of the routers with an external
+// gateway and an interface on subnets.
+func gatewayRouters(ctx context.Context, client *gophercloud.ServiceClient, allSubnets []subnets.Subnet) ([]string, error) {
+	checked := make(map[string]bool)
+	var routerIDs []string
+	for _, subnet := range allSubnets {
+		allPages, err := ports.List(client, ports.ListOpts{
+			FixedIPs: []ports.FixedIPOpts{{SubnetID: subnet.ID}},
+		}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		subnetPorts, err := ports.ExtractPorts(allPages)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, port := range subnetPorts {
+			if !deviceowners.IsRouterInterface(port.DeviceOwner) || checked[port.DeviceID] {
+				continue
+			}
+			checked[port.DeviceID] = true
+
+			router, err := routers.Get(ctx, client, port.DeviceID).Extract()
+			if err != nil {
+				return nil, err
+			}
+			if router.GatewayInfo.NetworkID != "" {
+				routerIDs = append(routerIDs, router.ID)
+			}
+		}
+	}
+	sort.Strings(routerIDs)
+	return routerIDs, nil
+}
@@ -0,0 +1,26 @@
+package egress
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/labels"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/rules"
+)
+
+// Accounting is the metering of the Internet egress traffic of a VPC.
+type Accounting struct {
+	// VPCID is the ID of the metered VPC.
+	VPCID string
+
+	// SourceCIDRs are the CIDRs of the metered traffic: the CIDR of the VPC,
+	// or the CIDRs of its subnets if the VPC has none.
+	SourceCIDRs []string
+
+	// RouterIDs are the routers with an external gateway and an interface on
+	// the subnets of the VPC, sorted.
+	RouterIDs []string
+
+	// Label is the metering label counting the traffic.
+	Label labels.Label
+
+	// Rules are the rules of the metering label.
+	Rules []rules.Rule
+}
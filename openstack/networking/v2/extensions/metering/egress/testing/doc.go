@@ -0,0 +1,2 @@
+// egress unit tests
+package testing
@@ -0,0 +1,209 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	VPCID    = "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b"
+	SubnetID = "08eae331-0402-425a-923c-34f7cfe39c1b"
+	RouterID = "b1a5c2d3-0000-4000-8000-000000000001"
+	LabelID  = "a6700594-5b7a-4105-8bfe-723b346ce866"
+)
+
+// GetVPCResponse is the metered VPC.
+const GetVPCResponse = `
+{
+	"vpc": {
+		"id": "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b",
+		"name": "prod",
+		"cidr": "10.0.0.0/16",
+		"status": "ACTIVE"
+	}
+}
+`
+
+// ListSubnetsResponse is the subnet of the VPC.
+const ListSubnetsResponse = `
+{
+	"subnets": [
+		{
+			"id": "08eae331-0402-425a-923c-34f7cfe39c1b",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"cidr": "10.0.1.0/24",
+			"ip_version": 4,
+			"vpc_id": "5f2a3b4c-1d2e-4f50-8a9b-0c1d2e3f4a5b"
+		}
+	]
+}
+`
+
+// ListPortsResponse is the router interface and a VM port of the subnet.
+const ListPortsResponse = `
+{
+	"ports": [
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000001",
+			"device_id": "b1a5c2d3-0000-4000-8000-000000000001",
+			"device_owner": "network:router_interface",
+			"fixed_ips": [{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "10.0.1.1"}]
+		},
+		{
+			"id": "c0d1e2f3-0000-4000-8000-000000000002",
+			"device_id": "9e4f8b1a-0000-4000-8000-000000000002",
+			"device_owner": "compute:nova",
+			"fixed_ips": [{"subnet_id": "08eae331-0402-425a-923c-34f7cfe39c1b", "ip_address": "10.0.1.10"}]
+		}
+	]
+}
+`
+
+// GetRouterResponse is the router of the VPC, with an external gateway.
+const GetRouterResponse = `
+{
+	"router": {
+		"id": "b1a5c2d3-0000-4000-8000-000000000001",
+		"name": "prod-router",
+		"status": "ACTIVE",
+		"external_gateway_info": {
+			"network_id": "8ca37218-28ff-41cb-9b10-039601ea7e6b"
+		}
+	}
+}
+`
+
+// Metering is a fake of the metering API, which stores the metering labels
+// and rules created by the requests.
+type Metering struct {
+	Labels []map[string]any
+	Rules  []map[string]any
+
+	// DeletedLabels and DeletedRules are the IDs of the deleted metering
+	// labels and rules.
+	DeletedLabels []string
+	DeletedRules  []string
+
+	nextID int
+}
+
+func (m *Metering) id() string {
+	m.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", m.nextID)
+}
+
+// HandleVPC serves the VPC, its subnet, the ports of the subnet and its
+// router. The router interface is omitted if withRouter is false.
+func HandleVPC(t *testing.T, withRouter bool) {
+	th.Mux.HandleFunc("/v2.0/vpcs/"+VPCID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, GetVPCResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/subnets", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestFormValues(t, r, map[string]string{"vpc_id": VPCID})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ListSubnetsResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestFormValues(t, r, map[string]string{"fixed_ips": "subnet_id=" + SubnetID})
+
+		w.Header().Add("Content-Type", "application/json")
+		if !withRouter {
+			fmt.Fprint(w, `{"ports": []}`)
+			return
+		}
+		fmt.Fprint(w, ListPortsResponse)
+	})
+
+	th.Mux.HandleFunc("/v2.0/routers/"+RouterID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, GetRouterResponse)
+	})
+}
+
+// HandleMetering serves the metering labels and rules of m.
+func HandleMetering(t *testing.T, m *Metering) {
+	th.Mux.HandleFunc("/v2.0/metering/metering-labels", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case "GET":
+			listed := []map[string]any{}
+			for _, label := range m.Labels {
+				if name := r.URL.Query().Get("name"); name == "" || label["name"] == name {
+					listed = append(listed, label)
+				}
+			}
+			th.AssertNoErr(t, json.NewEncoder(w).Encode(map[string]any{"metering_labels": listed}))
+		case "POST":
+			var body struct {
+				Label map[string]any `json:"metering_label"`
+			}
+			th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+			body.Label["id"] = m.id()
+			m.Labels = append(m.Labels, body.Label)
+
+			w.WriteHeader(http.StatusCreated)
+			th.AssertNoErr(t, json.NewEncoder(w).Encode(map[string]any{"metering_label": body.Label}))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-labels/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		m.DeletedLabels = append(m.DeletedLabels, strings.TrimPrefix(r.URL.Path, "/v2.0/metering/metering-labels/"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case "GET":
+			listed := []map[string]any{}
+			for _, rule := range m.Rules {
+				if rule["metering_label_id"] == r.URL.Query().Get("metering_label_id") {
+					listed = append(listed, rule)
+				}
+			}
+			th.AssertNoErr(t, json.NewEncoder(w).Encode(map[string]any{"metering_label_rules": listed}))
+		case "POST":
+			var body struct {
+				Rule map[string]any `json:"metering_label_rule"`
+			}
+			th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+			body.Rule["id"] = m.id()
+			m.Rules = append(m.Rules, body.Rule)
+
+			w.WriteHeader(http.StatusCreated)
+			th.AssertNoErr(t, json.NewEncoder(w).Encode(map[string]any{"metering_label_rule": body.Rule}))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		m.DeletedRules = append(m.DeletedRules, strings.TrimPrefix(r.URL.Path, "/v2.0/metering/metering-label-rules/"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
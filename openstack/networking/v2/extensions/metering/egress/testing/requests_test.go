@@ -0,0 +1,129 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/egress"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/rules"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+// ruleKeys returns the direction, prefixes and exclusion of rules.
+func ruleKeys(allRules []rules.Rule) []string {
+	keys := make([]string, 0, len(allRules))
+	for _, rule := range allRules {
+		key := string(rule.Direction) + " " + rule.SourceIPPrefix + " -> " + rule.DestinationIPPrefix
+		if rule.Excluded {
+			key += " excluded"
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestEnsure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	m := &Metering{}
+	HandleVPC(t, true)
+	HandleMetering(t, m)
+
+	accounting, err := egress.Ensure(context.TODO(), fake.ServiceClient(), VPCID, egress.Opts{})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(m.Labels))
+	th.AssertEquals(t, "internet-egress-"+VPCID, accounting.Label.Name)
+	th.CheckDeepEquals(t, []string{"10.0.0.0/16"}, accounting.SourceCIDRs)
+	th.CheckDeepEquals(t, []string{RouterID}, accounting.RouterIDs)
+	th.CheckDeepEquals(t, []string{
+		"egress 10.0.0.0/16 -> 0.0.0.0/0",
+		"egress 10.0.0.0/16 -> 10.0.0.0/8 excluded",
+		"egress 10.0.0.0/16 -> 100.64.0.0/10 excluded",
+		"egress 10.0.0.0/16 -> 169.254.0.0/16 excluded",
+		"egress 10.0.0.0/16 -> 172.16.0.0/12 excluded",
+		"egress 10.0.0.0/16 -> 192.168.0.0/16 excluded",
+	}, ruleKeys(accounting.Rules))
+	for _, rule := range accounting.Rules {
+		th.AssertEquals(t, accounting.Label.ID, rule.MeteringLabelID)
+	}
+}
+
+func TestEnsureExisting(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	m := &Metering{
+		Labels: []map[string]any{
+			{"id": LabelID, "name": "vpc-egress"},
+		},
+		Rules: []map[string]any{
+			{"id": "r1", "metering_label_id": LabelID, "direction": "egress", "source_ip_prefix": "10.0.0.0/16", "destination_ip_prefix": "0.0.0.0/0", "excluded": false},
+			{"id": "r2", "metering_label_id": LabelID, "direction": "egress", "source_ip_prefix": "10.0.0.0/16", "destination_ip_prefix": "8.8.8.8/32", "excluded": true},
+		},
+	}
+	HandleVPC(t, true)
+	HandleMetering(t, m)
+
+	accounting, err := egress.Ensure(context.TODO(), fake.ServiceClient(), VPCID, egress.Opts{
+		Name:          "vpc-egress",
+		ExcludedCIDRs: []string{"192.168.0.0/16", "fc00::/7"},
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(m.Labels))
+	th.AssertEquals(t, LabelID, accounting.Label.ID)
+	th.CheckDeepEquals(t, []string{"r2"}, m.DeletedRules)
+	th.CheckDeepEquals(t, []string{
+		"egress 10.0.0.0/16 -> 0.0.0.0/0",
+		"egress 10.0.0.0/16 -> 192.168.0.0/16 excluded",
+	}, ruleKeys(accounting.Rules))
+	th.AssertEquals(t, "r1", accounting.Rules[0].ID)
+}
+
+func TestEnsureInvalidExcludedCIDR(t *testing.T) {
+	_, err := egress.Ensure(context.TODO(), fake.ServiceClient(), VPCID, egress.Opts{
+		ExcludedCIDRs: []string{"192.168.0.0"},
+	})
+	th.AssertErr(t, err)
+}
+
+func TestEnsureNoRouter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	m := &Metering{}
+	HandleVPC(t, false)
+	HandleMetering(t, m)
+
+	_, err := egress.Ensure(context.TODO(), fake.ServiceClient(), VPCID, egress.Opts{})
+	var noRouter egress.ErrNoRouter
+	if !errors.As(err, &noRouter) {
+		t.Fatalf("expected an ErrNoRouter, got %v", err)
+	}
+	th.AssertEquals(t, VPCID, noRouter.VPCID)
+	th.AssertEquals(t, 0, len(m.Labels))
+}
+
+func TestRemove(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	m := &Metering{
+		Labels: []map[string]any{
+			{"id": LabelID, "name": "internet-egress-" + VPCID},
+		},
+	}
+	HandleMetering(t, m)
+
+	err := egress.Remove(context.TODO(), fake.ServiceClient(), VPCID, egress.Opts{})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{LabelID}, m.DeletedLabels)
+
+	err = egress.Remove(context.TODO(), fake.ServiceClient(), "another-vpc", egress.Opts{})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{LabelID}, m.DeletedLabels)
+}
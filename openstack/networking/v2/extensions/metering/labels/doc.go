@@ -0,0 +1,44 @@
+/*
+Package labels provides information and interaction with the metering
+labels of the metering extension for the OpenStack Networking service.
+
+A metering label counts the traffic of all the routers of its project, or of
+all the projects if it is shared, matching its rules. Metering labels can't
+be updated.
+
+Example to List Metering Labels
+
+	allPages, err := labels.List(networkClient, labels.ListOpts{}).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allLabels, err := labels.ExtractLabels(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, label := range allLabels {
+		fmt.Printf("%+v\n", label)
+	}
+
+Example to Create a Metering Label
+
+	createOpts := labels.CreateOpts{
+		Name:        "internet-egress",
+		Description: "Traffic to the Internet",
+	}
+
+	label, err := labels.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Metering Label
+
+	err := labels.Delete(context.TODO(), networkClient, "a6700594-5b7a-4105-8bfe-723b346ce866").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package labels
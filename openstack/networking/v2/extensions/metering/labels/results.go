@@ -0,0 +1,103 @@
+package labels
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a metering label
+// resource.
+func (r commonResult) Extract() (*Label, error) {
+	var s Label
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "metering_label")
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as a Label.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Label.
+type GetResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Label represents a metering label, which counts the traffic of the routers
+// of its project matching its rules.
+type Label struct {
+	// UUID of the metering label.
+	ID string `json:"id"`
+
+	// Name is a human-readable name of the metering label.
+	Name string `json:"name"`
+
+	// Description of the metering label.
+	Description string `json:"description"`
+
+	// ProjectID is the project owner of the metering label.
+	ProjectID string `json:"project_id"`
+
+	// Shared tells whether the metering label applies to the routers of all
+	// the projects.
+	Shared bool `json:"shared"`
+}
+
+// LabelPage is the page returned by a pager when traversing over a
+// collection of metering labels.
+type LabelPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of metering labels has
+// reached the end of a page and the pager seeks to traverse over a new one.
+// In order to do this, it needs to construct the next page's URL.
+func (r LabelPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"metering_labels_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether a LabelPage struct is empty.
+func (r LabelPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractLabels(r)
+	return len(is) == 0, err
+}
+
+// ExtractLabels accepts a Page struct, specifically a LabelPage struct, and
+// extracts the elements into a slice of Label structs.
+func ExtractLabels(r pagination.Page) ([]Label, error) {
+	var s []Label
+	err := ExtractLabelsInto(r, &s)
+	return s, err
+}
+
+// ExtractLabelsInto extracts the elements into a slice of Label structs.
+func ExtractLabelsInto(r pagination.Page, v any) error {
+	return r.(LabelPage).Result.ExtractIntoSlicePtr(v, "metering_labels")
+}
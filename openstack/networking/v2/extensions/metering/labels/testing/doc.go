@@ -0,0 +1,2 @@
+// labels unit tests
+package testing
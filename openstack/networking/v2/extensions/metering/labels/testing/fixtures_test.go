@@ -0,0 +1,53 @@
+package testing
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/labels"
+)
+
+const LabelID = "a6700594-5b7a-4105-8bfe-723b346ce866"
+
+// LabelListResult is the response of a list request.
+const LabelListResult = `
+{
+	"metering_labels": [
+		{
+			"id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+			"name": "internet-egress",
+			"description": "Traffic to the Internet",
+			"project_id": "45977fa2dbd7482098dd68d0d8970117",
+			"shared": false
+		}
+	]
+}
+`
+
+// LabelGetResult is the response of a get request.
+const LabelGetResult = `
+{
+	"metering_label": {
+		"id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+		"name": "internet-egress",
+		"description": "Traffic to the Internet",
+		"project_id": "45977fa2dbd7482098dd68d0d8970117",
+		"shared": false
+	}
+}
+`
+
+// LabelCreateRequest is the request of a create request.
+const LabelCreateRequest = `
+{
+	"metering_label": {
+		"name": "internet-egress",
+		"description": "Traffic to the Internet"
+	}
+}
+`
+
+// InternetEgress is the metering label of the responses.
+var InternetEgress = labels.Label{
+	ID:          LabelID,
+	Name:        "internet-egress",
+	Description: "Traffic to the Internet",
+	ProjectID:   "45977fa2dbd7482098dd68d0d8970117",
+}
@@ -0,0 +1,13 @@
+package labels
+
+import "github.com/vnpaycloud-console/gophercloud/v2"
+
+const resourcePath = "metering-labels"
+
+func rootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("metering", resourcePath)
+}
+
+func resourceURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("metering", resourcePath, id)
+}
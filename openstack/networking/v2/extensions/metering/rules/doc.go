@@ -0,0 +1,65 @@
+/*
+Package rules provides information and interaction with the metering label
+rules of the metering extension for the OpenStack Networking service.
+
+The traffic counted by a metering label is the traffic matching one of its
+rules, unless it matches one of its excluded rules. Metering label rules
+can't be updated.
+
+Example to List the Rules of a Metering Label
+
+	listOpts := rules.ListOpts{
+		MeteringLabelID: "a6700594-5b7a-4105-8bfe-723b346ce866",
+	}
+
+	allPages, err := rules.List(networkClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allRules, err := rules.ExtractRules(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rule := range allRules {
+		fmt.Printf("%+v\n", rule)
+	}
+
+Example to Count the Traffic of a Subnet to the Internet
+
+	createOpts := rules.CreateOpts{
+		MeteringLabelID:     "a6700594-5b7a-4105-8bfe-723b346ce866",
+		Direction:           rules.DirEgress,
+		SourceIPPrefix:      "10.0.0.0/24",
+		DestinationIPPrefix: "0.0.0.0/0",
+	}
+
+	rule, err := rules.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Exclude the Traffic to Private Addresses
+
+	createOpts := rules.CreateOpts{
+		MeteringLabelID:     "a6700594-5b7a-4105-8bfe-723b346ce866",
+		Direction:           rules.DirEgress,
+		SourceIPPrefix:      "10.0.0.0/24",
+		DestinationIPPrefix: "192.168.0.0/16",
+		Excluded:            true,
+	}
+
+	rule, err := rules.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Metering Label Rule
+
+	err := rules.Delete(context.TODO(), networkClient, "9536641a-7d14-4dc5-afaf-93a973ce0eb8").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package rules
@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+// Direction is the direction of the traffic a metering label rule counts,
+// relative to the router.
+type Direction string
+
+const (
+	// DirIngress counts the traffic entering the router from its external
+	// gateway.
+	DirIngress Direction = "ingress"
+
+	// DirEgress counts the traffic leaving the router through its external
+	// gateway.
+	DirEgress Direction = "egress"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToRuleListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through
+// the API. Filtering is achieved by passing in struct field values that map to
+// the metering label rule attributes you want to see returned. SortKey allows
+// you to sort by a particular metering label rule attribute. SortDir sets the
+// direction, and is either `asc' or `desc'. Marker and Limit are used for
+// pagination.
+type ListOpts struct {
+	ID                  string    `q:"id"`
+	MeteringLabelID     string    `q:"metering_label_id"`
+	Direction           Direction `q:"direction"`
+	SourceIPPrefix      string    `q:"source_ip_prefix"`
+	DestinationIPPrefix string    `q:"destination_ip_prefix"`
+	Excluded            *bool     `q:"excluded"`
+	ProjectID           string    `q:"project_id"`
+	Limit               int       `q:"limit"`
+	Marker              string    `q:"marker"`
+	SortKey             string    `q:"sort_key"`
+	SortDir             string    `q:"sort_dir"`
+}
+
+// ToRuleListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToRuleListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns a Pager which allows you to iterate over a collection of
+// metering label rules. It accepts a ListOpts struct, which allows you to
+// filter and sort the returned collection for greater efficiency.
+func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(c)
+	if opts != nil {
+		query, err := opts.ToRuleListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return RulePage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific metering label rule based on its unique ID.
+func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := c.Get(ctx, resourceURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToRuleCreateMap() (map[string]any, error)
+}
+
+// CreateOpts represents the attributes used when creating a new metering
+// label rule. A rule without SourceIPPrefix nor DestinationIPPrefix counts
+// all the traffic in its direction.
+type CreateOpts struct {
+	// MeteringLabelID is the metering label the rule belongs to.
+	MeteringLabelID string `json:"metering_label_id" required:"true"`
+
+	// Direction is the direction of the counted traffic.
+	Direction Direction `json:"direction" required:"true"`
+
+	// SourceIPPrefix is the CIDR the source address of the counted traffic
+	// is in.
+	SourceIPPrefix string `json:"source_ip_prefix,omitempty"`
+
+	// DestinationIPPrefix is the CIDR the destination address of the
+	// counted traffic is in.
+	DestinationIPPrefix string `json:"destination_ip_prefix,omitempty"`
+
+	// Excluded excludes the matching traffic from the traffic counted by the
+	// other rules of the metering label.
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+// ToRuleCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToRuleCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "metering_label_rule")
+}
+
+// Create accepts a CreateOpts struct and creates a new metering label rule
+// using the values provided.
+func Create(ctx context.Context, c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToRuleCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := c.Post(ctx, rootURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete accepts a unique ID and deletes the metering label rule associated
+// with it.
+func Delete(ctx context.Context, c *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := c.Delete(ctx, resourceURL(c, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
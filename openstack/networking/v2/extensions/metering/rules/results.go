@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a metering label
+// rule resource.
+func (r commonResult) Extract() (*Rule, error) {
+	var s Rule
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v any) error {
+	return r.Result.ExtractIntoStructPtr(v, "metering_label_rule")
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as a Rule.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Rule.
+type GetResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Rule represents a metering label rule, which selects the traffic counted
+// by its metering label.
+type Rule struct {
+	// UUID of the metering label rule.
+	ID string `json:"id"`
+
+	// MeteringLabelID is the metering label the rule belongs to.
+	MeteringLabelID string `json:"metering_label_id"`
+
+	// Direction is the direction of the counted traffic.
+	Direction Direction `json:"direction"`
+
+	// RemoteIPPrefix is the deprecated equivalent of SourceIPPrefix for
+	// ingress rules and of DestinationIPPrefix for egress rules.
+	RemoteIPPrefix string `json:"remote_ip_prefix"`
+
+	// SourceIPPrefix is the CIDR the source address of the counted traffic
+	// is in.
+	SourceIPPrefix string `json:"source_ip_prefix"`
+
+	// DestinationIPPrefix is the CIDR the destination address of the
+	// counted traffic is in.
+	DestinationIPPrefix string `json:"destination_ip_prefix"`
+
+	// Excluded tells whether the matching traffic is excluded from the
+	// traffic counted by the other rules of the metering label.
+	Excluded bool `json:"excluded"`
+
+	// ProjectID is the project owner of the metering label rule.
+	ProjectID string `json:"project_id"`
+}
+
+// RulePage is the page returned by a pager when traversing over a collection
+// of metering label rules.
+type RulePage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of metering label rules
+// has reached the end of a page and the pager seeks to traverse over a new
+// one. In order to do this, it needs to construct the next page's URL.
+func (r RulePage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"metering_label_rules_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether a RulePage struct is empty.
+func (r RulePage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	is, err := ExtractRules(r)
+	return len(is) == 0, err
+}
+
+// ExtractRules accepts a Page struct, specifically a RulePage struct, and
+// extracts the elements into a slice of Rule structs.
+func ExtractRules(r pagination.Page) ([]Rule, error) {
+	var s []Rule
+	err := ExtractRulesInto(r, &s)
+	return s, err
+}
+
+// ExtractRulesInto extracts the elements into a slice of Rule structs.
+func ExtractRulesInto(r pagination.Page, v any) error {
+	return r.(RulePage).Result.ExtractIntoSlicePtr(v, "metering_label_rules")
+}
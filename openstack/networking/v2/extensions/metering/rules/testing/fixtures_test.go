@@ -0,0 +1,68 @@
+package testing
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/rules"
+)
+
+const (
+	RuleID  = "9536641a-7d14-4dc5-afaf-93a973ce0eb8"
+	LabelID = "a6700594-5b7a-4105-8bfe-723b346ce866"
+)
+
+// RuleListResult is the response of a list request.
+const RuleListResult = `
+{
+	"metering_label_rules": [
+		{
+			"id": "9536641a-7d14-4dc5-afaf-93a973ce0eb8",
+			"metering_label_id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+			"direction": "egress",
+			"remote_ip_prefix": null,
+			"source_ip_prefix": "10.0.0.0/24",
+			"destination_ip_prefix": "192.168.0.0/16",
+			"excluded": true,
+			"project_id": "45977fa2dbd7482098dd68d0d8970117"
+		}
+	]
+}
+`
+
+// RuleGetResult is the response of a get request.
+const RuleGetResult = `
+{
+	"metering_label_rule": {
+		"id": "9536641a-7d14-4dc5-afaf-93a973ce0eb8",
+		"metering_label_id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+		"direction": "egress",
+		"remote_ip_prefix": null,
+		"source_ip_prefix": "10.0.0.0/24",
+		"destination_ip_prefix": "192.168.0.0/16",
+		"excluded": true,
+		"project_id": "45977fa2dbd7482098dd68d0d8970117"
+	}
+}
+`
+
+// RuleCreateRequest is the request of a create request.
+const RuleCreateRequest = `
+{
+	"metering_label_rule": {
+		"metering_label_id": "a6700594-5b7a-4105-8bfe-723b346ce866",
+		"direction": "egress",
+		"source_ip_prefix": "10.0.0.0/24",
+		"destination_ip_prefix": "192.168.0.0/16",
+		"excluded": true
+	}
+}
+`
+
+// ExcludedPrivate is the metering label rule of the responses.
+var ExcludedPrivate = rules.Rule{
+	ID:                  RuleID,
+	MeteringLabelID:     LabelID,
+	Direction:           rules.DirEgress,
+	SourceIPPrefix:      "10.0.0.0/24",
+	DestinationIPPrefix: "192.168.0.0/16",
+	Excluded:            true,
+	ProjectID:           "45977fa2dbd7482098dd68d0d8970117",
+}
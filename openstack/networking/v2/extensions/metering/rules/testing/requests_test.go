@@ -0,0 +1,121 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/metering/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"metering_label_id": LabelID,
+			"excluded":          "true",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, RuleListResult)
+	})
+
+	excluded := true
+	listOpts := rules.ListOpts{
+		MeteringLabelID: LabelID,
+		Excluded:        &excluded,
+	}
+
+	count := 0
+	err := rules.List(fake.ServiceClient(), listOpts).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := rules.ExtractRules(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []rules.Rule{ExcludedPrivate}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules/"+RuleID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, RuleGetResult)
+	})
+
+	rule, err := rules.Get(context.TODO(), fake.ServiceClient(), RuleID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExcludedPrivate, *rule)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, RuleCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, RuleGetResult)
+	})
+
+	rule, err := rules.Create(context.TODO(), fake.ServiceClient(), rules.CreateOpts{
+		MeteringLabelID:     LabelID,
+		Direction:           rules.DirEgress,
+		SourceIPPrefix:      "10.0.0.0/24",
+		DestinationIPPrefix: "192.168.0.0/16",
+		Excluded:            true,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExcludedPrivate, *rule)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := rules.Create(context.TODO(), fake.ServiceClient(), rules.CreateOpts{Direction: rules.DirEgress})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+	res = rules.Create(context.TODO(), fake.ServiceClient(), rules.CreateOpts{MeteringLabelID: LabelID})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/metering/metering-label-rules/"+RuleID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := rules.Delete(context.TODO(), fake.ServiceClient(), RuleID)
+	th.AssertNoErr(t, res.Err)
+}
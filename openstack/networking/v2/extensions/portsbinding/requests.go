@@ -1,9 +1,43 @@
 package portsbinding
 
 import (
+	"net/url"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
 )
 
+// ListOptsExt adds port binding options to the base ports.ListOpts.
+type ListOptsExt struct {
+	ports.ListOptsBuilder
+
+	// HostID lists the ports bound to a host.
+	HostID string `q:"binding:host_id"`
+
+	// VNICType lists the ports with a vNIC type.
+	VNICType string `q:"binding:vnic_type"`
+}
+
+// ToPortListQuery adds the port binding options to the base port list
+// options.
+func (opts ListOptsExt) ToPortListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts.ListOptsBuilder)
+	if err != nil {
+		return "", err
+	}
+
+	params := q.Query()
+	if opts.HostID != "" {
+		params.Add("binding:host_id", opts.HostID)
+	}
+	if opts.VNICType != "" {
+		params.Add("binding:vnic_type", opts.VNICType)
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+	return q.String(), err
+}
+
 // CreateOptsExt adds port binding options to the base ports.CreateOpts.
 type CreateOptsExt struct {
 	// CreateOptsBuilder is the interface options structs have to satisfy in order
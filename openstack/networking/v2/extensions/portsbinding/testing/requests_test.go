@@ -187,3 +187,16 @@ func TestUpdate(t *testing.T) {
 	th.AssertEquals(t, s.HostID, "HOST1")
 	th.AssertEquals(t, s.VNICType, "normal")
 }
+
+func TestListOptsExt(t *testing.T) {
+	listOpts := portsbinding.ListOptsExt{
+		ListOptsBuilder: ports.ListOpts{
+			NetworkID: "70c1db1f-b701-45bd-96e0-a313ee3430b3",
+		},
+		HostID: "devstack",
+	}
+
+	actual, err := listOpts.ToPortListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?binding%3Ahost_id=devstack&network_id=70c1db1f-b701-45bd-96e0-a313ee3430b3", actual)
+}
@@ -164,6 +164,66 @@ func TestCreate(t *testing.T) {
 	th.AssertNoErr(t, err)
 }
 
+func TestCreateRemoteAddressGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/security-group-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, `
+{
+    "security_group_rule": {
+        "direction": "ingress",
+        "port_range_min": 443,
+        "ethertype": "IPv4",
+        "port_range_max": 443,
+        "protocol": "tcp",
+        "remote_address_group_id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+        "security_group_id": "a7734e61-b545-452d-a3cd-0189cbd9747a"
+    }
+}
+      `)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, `
+{
+    "security_group_rule": {
+        "description": "",
+        "direction": "ingress",
+        "ethertype": "IPv4",
+        "id": "4e3c0a61-1d3f-4b8a-a0c4-7e5b2d9f1a33",
+        "port_range_max": 443,
+        "port_range_min": 443,
+        "protocol": "tcp",
+        "remote_group_id": null,
+        "remote_ip_prefix": null,
+        "remote_address_group_id": "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+        "security_group_id": "a7734e61-b545-452d-a3cd-0189cbd9747a",
+        "tenant_id": "e4f50856753b4dc6afee5fa6b9b6c550"
+    }
+}
+    `)
+	})
+
+	opts := rules.CreateOpts{
+		Direction:            "ingress",
+		PortRangeMin:         443,
+		EtherType:            rules.EtherType4,
+		PortRangeMax:         443,
+		Protocol:             "tcp",
+		RemoteAddressGroupID: "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11",
+		SecGroupID:           "a7734e61-b545-452d-a3cd-0189cbd9747a",
+	}
+	rule, err := rules.Create(context.TODO(), fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11", rule.RemoteAddressGroupID)
+}
+
 func TestCreateAnyProtocol(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
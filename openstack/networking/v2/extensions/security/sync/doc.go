@@ -0,0 +1,51 @@
+/*
+Package sync reconciles the rules of a security group with a desired set of
+rules, creating the missing rules and deleting the others, instead of
+recreating all the rules.
+
+Rules are compared once normalized, so that equivalent rules are kept: for
+example, a TCP rule matching ports 1 to 65535 from 0.0.0.0/0 is equivalent to
+a TCP rule without ports nor remote prefix. The rules the networking service
+adds to new security groups are deleted unless they are desired; see
+DefaultEgressRules.
+
+Example to Compute the Changes to a Security Group
+
+	desired := append(sync.DefaultEgressRules(),
+		sync.Rule{
+			Direction:    rules.DirIngress,
+			Protocol:     rules.ProtocolTCP,
+			PortRangeMin: 443,
+			PortRangeMax: 443,
+		},
+		sync.Rule{
+			Direction:    rules.DirIngress,
+			Protocol:     rules.ProtocolTCP,
+			PortRangeMin: 5432,
+			PortRangeMax: 5432,
+			RemoteGroup:  "web",
+		},
+	)
+
+	plan, err := sync.Diff(context.TODO(), networkClient, "security-group-id", desired, sync.DiffOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, rule := range plan.Create {
+		fmt.Printf("+ %+v\n", rule)
+	}
+	for _, rule := range plan.Delete {
+		fmt.Printf("- %+v\n", rule)
+	}
+
+Example to Synchronize a Security Group
+
+	report, err := sync.Sync(context.TODO(), networkClient, "security-group-id", desired, sync.SyncOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%d rules created, %d rules deleted\n", len(report.Created), len(report.Deleted))
+*/
+package sync
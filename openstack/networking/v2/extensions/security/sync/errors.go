@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrAmbiguousRemoteGroup is returned when the name of a remote group matches
+// several security groups.
+type ErrAmbiguousRemoteGroup struct {
+	gophercloud.BaseError
+
+	Name string
+	IDs  []string
+}
+
+func (e ErrAmbiguousRemoteGroup) Error() string {
+	return fmt.Sprintf("%d security groups are named %q", len(e.IDs), e.Name)
+}
+
+// ErrRemoteGroupNotFound is returned when no security group has the name of a
+// remote group.
+type ErrRemoteGroupNotFound struct {
+	gophercloud.BaseError
+
+	Name string
+}
+
+func (e ErrRemoteGroupNotFound) Error() string {
+	return fmt.Sprintf("no security group is named %q", e.Name)
+}
+
+// ErrDeleteFailed is returned by Apply when some rules could not be deleted.
+// The other rules were deleted.
+type ErrDeleteFailed struct {
+	gophercloud.BaseError
+
+	// Errors maps the IDs of the rules which could not be deleted to the
+	// error.
+	Errors map[string]error
+}
+
+func (e ErrDeleteFailed) Error() string {
+	return fmt.Sprintf("%d security group rules could not be deleted", len(e.Errors))
+}
@@ -0,0 +1,376 @@
+package sync
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/groups"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/protocols"
+)
+
+// Rule is a desired security group rule.
+type Rule struct {
+	// Direction is "ingress" or "egress".
+	Direction rules.RuleDirection
+
+	// EtherType is "IPv4" or "IPv6". Defaults to the family of RemoteIPPrefix,
+	// or to IPv4.
+	EtherType rules.RuleEtherType
+
+	// Protocol is the protocol matched by the rule. Any protocol is matched
+	// if it is empty.
+	Protocol rules.RuleProtocol
+
+	// PortRangeMin and PortRangeMax are the range of ports matched by the
+	// rule, or the ICMP type and code. All ports are matched if both are 0.
+	PortRangeMin int
+	PortRangeMax int
+
+	// RemoteIPPrefix is the CIDR matched by the rule. A single address is
+	// matched if the prefix length is omitted.
+	RemoteIPPrefix string
+
+	// RemoteGroup is the name of the security group matched by the rule. It
+	// is resolved to an ID by Diff.
+	RemoteGroup string
+
+	// RemoteGroupID is the ID of the security group matched by the rule.
+	RemoteGroupID string
+
+	// RemoteAddressGroupID is the ID of the address group matched by the
+	// rule.
+	RemoteAddressGroupID string
+
+	// Description is the description of the created rule. It is not compared
+	// with the description of existing rules, which can't be updated.
+	Description string
+}
+
+// DefaultEgressRules are the rules the networking service adds to new
+// security groups, allowing all egress traffic. Include them in the desired
+// rules to keep them.
+func DefaultEgressRules() []Rule {
+	return []Rule{
+		{Direction: rules.DirEgress, EtherType: rules.EtherType4},
+		{Direction: rules.DirEgress, EtherType: rules.EtherType6},
+	}
+}
+
+// ruleKey is the normalized form of a rule, which is equal for equivalent
+// rules.
+type ruleKey struct {
+	direction            string
+	etherType            string
+	protocol             string
+	portRangeMin         int
+	portRangeMax         int
+	remoteIPPrefix       string
+	remoteGroupID        string
+	remoteAddressGroupID string
+}
+
+// normalize returns the normalized form of a rule:
+//   - the protocol is named, "any" being no protocol, and ICMP is
+//     "ipv6-icmp" for IPv6,
+//   - the ports are ignored for protocols without ports, and the full range
+//     of ports is no range,
+//   - the remote prefixes are canonical CIDRs, and the prefixes matching all
+//     addresses are no prefix.
+func normalize(direction, etherType, protocol string, portRangeMin, portRangeMax int, remoteIPPrefix, remoteGroupID, remoteAddressGroupID string) (ruleKey, error) {
+	key := ruleKey{
+		direction:            strings.ToLower(direction),
+		etherType:            etherType,
+		remoteGroupID:        remoteGroupID,
+		remoteAddressGroupID: remoteAddressGroupID,
+	}
+
+	if remoteIPPrefix != "" {
+		prefix := remoteIPPrefix
+		if !strings.Contains(prefix, "/") {
+			if strings.Contains(prefix, ":") {
+				prefix += "/128"
+			} else {
+				prefix += "/32"
+			}
+		}
+		_, cidr, err := net.ParseCIDR(prefix)
+		if err != nil {
+			invalid := gophercloud.ErrInvalidInput{}
+			invalid.Argument = "RemoteIPPrefix"
+			invalid.Value = remoteIPPrefix
+			return key, invalid
+		}
+		if key.etherType == "" {
+			key.etherType = string(rules.EtherType4)
+			if cidr.IP.To4() == nil {
+				key.etherType = string(rules.EtherType6)
+			}
+		}
+		if ones, _ := cidr.Mask.Size(); ones > 0 {
+			key.remoteIPPrefix = cidr.String()
+		}
+	}
+	if key.etherType == "" {
+		key.etherType = string(rules.EtherType4)
+	}
+
+	version := 4
+	if key.etherType == string(rules.EtherType6) {
+		version = 6
+	}
+	key.protocol = protocols.Normalize(protocol, version)
+
+	switch {
+	case key.protocol == "icmp" || key.protocol == "ipv6-icmp":
+		key.portRangeMin, key.portRangeMax = portRangeMin, portRangeMax
+	case protocols.HasPorts(key.protocol):
+		if !(portRangeMin <= 1 && portRangeMax == 65535) {
+			key.portRangeMin, key.portRangeMax = portRangeMin, portRangeMax
+		}
+	}
+
+	return key, nil
+}
+
+// DiffOpts configures Diff.
+type DiffOpts struct {
+	// ProjectID restricts the security groups the names of remote groups are
+	// resolved among. This is useful to administrators, who see the security
+	// groups of all projects.
+	ProjectID string
+}
+
+// Diff compares the rules of a security group with the desired rules, and
+// returns the rules to create and to delete.
+//
+// Rules are compared once normalized, so that rules differing by their
+// defaults only, such as the "any" protocol and no protocol, or the full
+// range of ports and no range, are equivalent.
+func Diff(ctx context.Context, client *gophercloud.ServiceClient, secGroupID string, desired []Rule, opts DiffOpts) (*Plan, error) {
+	if secGroupID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "secGroupID"}
+	}
+
+	allPages, err := rules.List(client, rules.ListOpts{SecGroupID: secGroupID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := rules.ExtractRules(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{SecGroupID: secGroupID}
+	wanted := make(map[ruleKey]bool, len(desired))
+	groupIDs := make(map[string]string)
+	var toCreate []ruleKey
+	for _, rule := range desired {
+		createOpts, key, err := desiredRule(ctx, client, secGroupID, rule, opts.ProjectID, groupIDs)
+		if err != nil {
+			return nil, err
+		}
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		toCreate = append(toCreate, key)
+		plan.Create = append(plan.Create, createOpts)
+	}
+
+	found := make(map[ruleKey]bool, len(existing))
+	for _, rule := range existing {
+		key, err := normalize(rule.Direction, rule.EtherType, rule.Protocol, rule.PortRangeMin, rule.PortRangeMax, rule.RemoteIPPrefix, rule.RemoteGroupID, rule.RemoteAddressGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if wanted[key] && !found[key] {
+			found[key] = true
+			plan.Unchanged = append(plan.Unchanged, rule)
+		} else {
+			plan.Delete = append(plan.Delete, rule)
+		}
+	}
+
+	create := plan.Create[:0]
+	for i, key := range toCreate {
+		if !found[key] {
+			create = append(create, plan.Create[i])
+		}
+	}
+	plan.Create = create
+	if len(plan.Create) == 0 {
+		plan.Create = nil
+	}
+
+	return plan, nil
+}
+
+// desiredRule validates a desired rule, and returns its creation options and
+// its normalized form.
+func desiredRule(ctx context.Context, client *gophercloud.ServiceClient, secGroupID string, rule Rule, projectID string, groupIDs map[string]string) (rules.CreateOpts, ruleKey, error) {
+	if rule.Direction == "" {
+		return rules.CreateOpts{}, ruleKey{}, gophercloud.ErrMissingInput{Argument: "Direction"}
+	}
+	if rule.RemoteIPPrefix != "" && (rule.RemoteGroup != "" || rule.RemoteGroupID != "") {
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "RemoteIPPrefix"
+		invalid.Value = rule.RemoteIPPrefix
+		invalid.Info = "RemoteIPPrefix and RemoteGroup are mutually exclusive"
+		return rules.CreateOpts{}, ruleKey{}, invalid
+	}
+	if rule.RemoteAddressGroupID != "" && (rule.RemoteIPPrefix != "" || rule.RemoteGroup != "" || rule.RemoteGroupID != "") {
+		invalid := gophercloud.ErrInvalidInput{}
+		invalid.Argument = "RemoteAddressGroupID"
+		invalid.Value = rule.RemoteAddressGroupID
+		invalid.Info = "RemoteAddressGroupID, RemoteIPPrefix and RemoteGroup are mutually exclusive"
+		return rules.CreateOpts{}, ruleKey{}, invalid
+	}
+
+	remoteGroupID := rule.RemoteGroupID
+	if remoteGroupID == "" && rule.RemoteGroup != "" {
+		id, ok := groupIDs[rule.RemoteGroup]
+		if !ok {
+			var err error
+			id, err = resolveGroup(ctx, client, rule.RemoteGroup, projectID)
+			if err != nil {
+				return rules.CreateOpts{}, ruleKey{}, err
+			}
+			groupIDs[rule.RemoteGroup] = id
+		}
+		remoteGroupID = id
+	}
+
+	key, err := normalize(string(rule.Direction), string(rule.EtherType), string(rule.Protocol), rule.PortRangeMin, rule.PortRangeMax, rule.RemoteIPPrefix, remoteGroupID, rule.RemoteAddressGroupID)
+	if err != nil {
+		return rules.CreateOpts{}, ruleKey{}, err
+	}
+
+	return rules.CreateOpts{
+		Direction:            rules.RuleDirection(key.direction),
+		Description:          rule.Description,
+		EtherType:            rules.RuleEtherType(key.etherType),
+		SecGroupID:           secGroupID,
+		PortRangeMin:         key.portRangeMin,
+		PortRangeMax:         key.portRangeMax,
+		Protocol:             rules.RuleProtocol(key.protocol),
+		RemoteGroupID:        key.remoteGroupID,
+		RemoteIPPrefix:       key.remoteIPPrefix,
+		RemoteAddressGroupID: key.remoteAddressGroupID,
+	}, key, nil
+}
+
+// resolveGroup returns the ID of the security group with the given name.
+func resolveGroup(ctx context.Context, client *gophercloud.ServiceClient, name, projectID string) (string, error) {
+	allPages, err := groups.List(client, groups.ListOpts{Name: name, ProjectID: projectID}).AllPages(ctx)
+	if err != nil {
+		return "", err
+	}
+	found, err := groups.ExtractGroups(allPages)
+	if err != nil {
+		return "", err
+	}
+	switch len(found) {
+	case 0:
+		return "", ErrRemoteGroupNotFound{Name: name}
+	case 1:
+		return found[0].ID, nil
+	}
+	ids := make([]string, 0, len(found))
+	for _, group := range found {
+		ids = append(ids, group.ID)
+	}
+	return "", ErrAmbiguousRemoteGroup{Name: name, IDs: ids}
+}
+
+// ApplyOpts configures Apply.
+type ApplyOpts struct {
+	// DryRun returns the report without changing the security group.
+	DryRun bool
+
+	// Concurrency is the number of rules deleted in parallel. Defaults to 4.
+	Concurrency int
+}
+
+// Apply applies a plan: the rules to create are created at once, then the
+// rules to delete are deleted in parallel, so that the traffic allowed by both
+// the old and the new rules is never interrupted. The rules already deleted
+// are ignored.
+//
+// If some rules can't be deleted, the others are still deleted, and an
+// ErrDeleteFailed is returned with the report.
+func Apply(ctx context.Context, client *gophercloud.ServiceClient, plan Plan, opts ApplyOpts) (*Report, error) {
+	report := &Report{Plan: plan, DryRun: opts.DryRun}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if len(plan.Create) > 0 {
+		created, err := rules.CreateBulk(ctx, client, plan.Create).Extract()
+		if err != nil {
+			return report, err
+		}
+		report.Created = created
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+		sem    = make(chan struct{}, concurrency)
+	)
+	deleted := make([]bool, len(plan.Delete))
+	for i, rule := range plan.Delete {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := rules.Delete(ctx, client, rule.ID).ExtractErr()
+			if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				mu.Lock()
+				failed[rule.ID] = err
+				mu.Unlock()
+				return
+			}
+			deleted[i] = true
+		}()
+	}
+	wg.Wait()
+
+	for i, rule := range plan.Delete {
+		if deleted[i] {
+			report.Deleted = append(report.Deleted, rule.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return report, ErrDeleteFailed{Errors: failed}
+	}
+	return report, nil
+}
+
+// SyncOpts configures Sync.
+type SyncOpts struct {
+	DiffOpts
+	ApplyOpts
+}
+
+// Sync makes the rules of a security group the desired ones. It computes the
+// plan with Diff, and applies it with Apply.
+func Sync(ctx context.Context, client *gophercloud.ServiceClient, secGroupID string, desired []Rule, opts SyncOpts) (*Report, error) {
+	plan, err := Diff(ctx, client, secGroupID, desired, opts.DiffOpts)
+	if err != nil {
+		return nil, err
+	}
+	return Apply(ctx, client, *plan, opts.ApplyOpts)
+}
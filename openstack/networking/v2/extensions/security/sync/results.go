@@ -0,0 +1,40 @@
+package sync
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+)
+
+// Plan is the difference between the rules of a security group and the
+// desired ones.
+type Plan struct {
+	// SecGroupID is the ID of the security group.
+	SecGroupID string `json:"security_group_id"`
+
+	// Create are the rules to create.
+	Create []rules.CreateOpts `json:"create,omitempty"`
+
+	// Delete are the rules to delete.
+	Delete []rules.SecGroupRule `json:"delete,omitempty"`
+
+	// Unchanged are the existing rules matching a desired rule.
+	Unchanged []rules.SecGroupRule `json:"unchanged,omitempty"`
+}
+
+// Empty tells whether the security group already has the desired rules.
+func (p Plan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Delete) == 0
+}
+
+// Report is the outcome of Apply.
+type Report struct {
+	Plan Plan `json:"plan"`
+
+	// DryRun is true if the plan was not applied.
+	DryRun bool `json:"dry_run"`
+
+	// Created are the created rules.
+	Created []rules.SecGroupRule `json:"created,omitempty"`
+
+	// Deleted are the IDs of the deleted rules.
+	Deleted []string `json:"deleted,omitempty"`
+}
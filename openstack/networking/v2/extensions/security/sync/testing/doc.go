@@ -0,0 +1,2 @@
+// sync unit tests
+package testing
@@ -0,0 +1,227 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const SecGroupID = "85cc3048-abc3-43cc-89b3-377341426ac5"
+
+const WebGroupID = "7f0b1e7a-2c4d-4e5f-8a9b-0c1d2e3f4a5b"
+
+// ListRulesOutput are the rules of the security group.
+const ListRulesOutput = `
+{
+	"security_group_rules": [
+		{
+			"id": "3c0e45ff-adaf-4124-b083-bf390e5482ff",
+			"direction": "egress",
+			"ethertype": "IPv6",
+			"port_range_max": null,
+			"port_range_min": null,
+			"protocol": null,
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "93aa42e5-80db-4581-9391-3a608bd0e448",
+			"direction": "egress",
+			"ethertype": "IPv4",
+			"port_range_max": null,
+			"port_range_min": null,
+			"protocol": null,
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "0b2f5a3c-1d4e-4f6a-8b9c-0d1e2f3a4b5c",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 22,
+			"port_range_min": 22,
+			"protocol": "tcp",
+			"remote_group_id": null,
+			"remote_ip_prefix": "0.0.0.0/0",
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "1c3a6b4d-2e5f-4a7b-9c0d-1e2f3a4b5c6d",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 65535,
+			"port_range_min": 1,
+			"protocol": "6",
+			"remote_group_id": null,
+			"remote_ip_prefix": "10.0.0.0/8",
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "2d4b7c5e-3f6a-4b8c-8d1e-2f3a4b5c6d7e",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": null,
+			"port_range_min": null,
+			"protocol": "icmp",
+			"remote_group_id": "7f0b1e7a-2c4d-4e5f-8a9b-0c1d2e3f4a5b",
+			"remote_ip_prefix": null,
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "3e5c8d6f-4a7b-4c9d-9e2f-3a4b5c6d7e8f",
+			"direction": "ingress",
+			"ethertype": "IPv4",
+			"port_range_max": 53,
+			"port_range_min": 53,
+			"protocol": "udp",
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		},
+		{
+			"id": "4f6d9e7a-5b8c-4d0e-8f3a-4b5c6d7e8f90",
+			"direction": "ingress",
+			"ethertype": "IPv6",
+			"port_range_max": 80,
+			"port_range_min": 80,
+			"protocol": "tcp",
+			"remote_group_id": null,
+			"remote_ip_prefix": "::/0",
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		}
+	]
+}
+`
+
+// CreateBulkRequest creates the missing rule.
+const CreateBulkRequest = `
+{
+	"security_group_rules": [
+		{
+			"direction": "ingress",
+			"description": "HTTPS",
+			"ethertype": "IPv4",
+			"port_range_max": 443,
+			"port_range_min": 443,
+			"protocol": "tcp",
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		}
+	]
+}
+`
+
+const CreateBulkResponse = `
+{
+	"security_group_rules": [
+		{
+			"id": "5a7e0f8b-6c9d-4e1f-9a4b-5c6d7e8f90a1",
+			"direction": "ingress",
+			"description": "HTTPS",
+			"ethertype": "IPv4",
+			"port_range_max": 443,
+			"port_range_min": 443,
+			"protocol": "tcp",
+			"remote_group_id": null,
+			"remote_ip_prefix": null,
+			"security_group_id": "85cc3048-abc3-43cc-89b3-377341426ac5"
+		}
+	]
+}
+`
+
+// HandleGroups sets up the test server to list the given number of security
+// groups named "web".
+func HandleGroups(t *testing.T, webGroups int) {
+	th.Mux.HandleFunc("/v2.0/security-groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"name": "web"})
+
+		groups := make([]string, 0, webGroups)
+		for i := 0; i < webGroups; i++ {
+			id := WebGroupID
+			if i > 0 {
+				id = fmt.Sprintf("8a1c2f8b-3d5e-4f6a-9b0c-1d2e3f4a5b%02d", i)
+			}
+			groups = append(groups, fmt.Sprintf(`{"id": "%s", "name": "web", "security_group_rules": []}`, id))
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"security_groups": [%s]}`, strings.Join(groups, ","))
+	})
+}
+
+// Calls records the changes made to the rules.
+type Calls struct {
+	mu      sync.Mutex
+	created int
+	deleted []string
+}
+
+// Created returns the number of bulk creations.
+func (c *Calls) Created() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.created
+}
+
+// Deleted returns the IDs of the deleted rules, sorted.
+func (c *Calls) Deleted() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	deleted := append([]string(nil), c.deleted...)
+	sort.Strings(deleted)
+	return deleted
+}
+
+// HandleRules sets up the test server to list the rules of the security
+// group, create the missing rule and delete the other rules. The deletion of
+// the IPv6 rule responds with the given status.
+func HandleRules(t *testing.T, ipv6DeleteStatus int) *Calls {
+	calls := new(Calls)
+
+	th.Mux.HandleFunc("/v2.0/security-group-rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+			th.TestFormValues(t, r, map[string]string{"security_group_id": SecGroupID})
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprint(w, ListRulesOutput)
+		case "POST":
+			th.TestJSONRequest(t, r, CreateBulkRequest)
+			calls.mu.Lock()
+			calls.created++
+			calls.mu.Unlock()
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, CreateBulkResponse)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/v2.0/security-group-rules/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		id := strings.TrimPrefix(r.URL.Path, "/v2.0/security-group-rules/")
+		if id == "4f6d9e7a-5b8c-4d0e-8f3a-4b5c6d7e8f90" && ipv6DeleteStatus != http.StatusNoContent {
+			w.WriteHeader(ipv6DeleteStatus)
+			return
+		}
+		calls.mu.Lock()
+		calls.deleted = append(calls.deleted, id)
+		calls.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return calls
+}
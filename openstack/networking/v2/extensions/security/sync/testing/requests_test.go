@@ -0,0 +1,155 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/rules"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/security/sync"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+// desired are equivalent to the rules of the security group, except for the
+// UDP and IPv6 rules, which are missing, and the HTTPS rule, which is new.
+var desired = append(sync.DefaultEgressRules(),
+	sync.Rule{Direction: rules.DirIngress, Protocol: rules.ProtocolTCP, PortRangeMin: 22, PortRangeMax: 22},
+	sync.Rule{Direction: rules.DirIngress, Protocol: rules.ProtocolTCP, RemoteIPPrefix: "10.1.2.3/8"},
+	sync.Rule{Direction: rules.DirIngress, Protocol: "1", RemoteGroup: "web"},
+	sync.Rule{Direction: rules.DirIngress, Protocol: rules.ProtocolTCP, PortRangeMin: 443, PortRangeMax: 443, Description: "HTTPS"},
+	// A duplicate.
+	sync.Rule{Direction: rules.DirIngress, Protocol: rules.ProtocolTCP, PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+)
+
+func ids(rules []rules.SecGroupRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+func TestDiff(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroups(t, 1)
+	HandleRules(t, http.StatusNoContent)
+
+	plan, err := sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, desired, sync.DiffOpts{})
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []rules.CreateOpts{
+		{
+			Direction:    rules.DirIngress,
+			Description:  "HTTPS",
+			EtherType:    rules.EtherType4,
+			SecGroupID:   SecGroupID,
+			PortRangeMin: 443,
+			PortRangeMax: 443,
+			Protocol:     rules.ProtocolTCP,
+		},
+	}, plan.Create)
+	th.CheckDeepEquals(t, []string{
+		"3e5c8d6f-4a7b-4c9d-9e2f-3a4b5c6d7e8f",
+		"4f6d9e7a-5b8c-4d0e-8f3a-4b5c6d7e8f90",
+	}, ids(plan.Delete))
+	th.CheckDeepEquals(t, []string{
+		"3c0e45ff-adaf-4124-b083-bf390e5482ff",
+		"93aa42e5-80db-4581-9391-3a608bd0e448",
+		"0b2f5a3c-1d4e-4f6a-8b9c-0d1e2f3a4b5c",
+		"1c3a6b4d-2e5f-4a7b-9c0d-1e2f3a4b5c6d",
+		"2d4b7c5e-3f6a-4b8c-8d1e-2f3a4b5c6d7e",
+	}, ids(plan.Unchanged))
+	th.CheckEquals(t, false, plan.Empty())
+}
+
+func TestDiffAmbiguousRemoteGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroups(t, 2)
+	HandleRules(t, http.StatusNoContent)
+
+	_, err := sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, desired, sync.DiffOpts{})
+	var ambiguous sync.ErrAmbiguousRemoteGroup
+	th.AssertEquals(t, true, errors.As(err, &ambiguous))
+	th.CheckEquals(t, "web", ambiguous.Name)
+	th.CheckEquals(t, 2, len(ambiguous.IDs))
+}
+
+func TestDiffInvalidRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRules(t, http.StatusNoContent)
+
+	_, err := sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, []sync.Rule{
+		{Direction: rules.DirIngress, RemoteIPPrefix: "10.0.0.0/8", RemoteGroupID: WebGroupID},
+	}, sync.DiffOpts{})
+	th.AssertErr(t, err)
+
+	_, err = sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, []sync.Rule{
+		{Direction: rules.DirIngress, RemoteIPPrefix: "10.0.0.0/33"},
+	}, sync.DiffOpts{})
+	th.AssertErr(t, err)
+
+	_, err = sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, []sync.Rule{
+		{Direction: rules.DirIngress, RemoteIPPrefix: "10.0.0.0/8", RemoteAddressGroupID: "9f3a4b1e-6e1b-4c8b-9a52-7f0d8a3f7c11"},
+	}, sync.DiffOpts{})
+	th.AssertErr(t, err)
+}
+
+func TestSync(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroups(t, 1)
+	calls := HandleRules(t, http.StatusNotFound)
+
+	report, err := sync.Sync(context.TODO(), fake.ServiceClient(), SecGroupID, desired, sync.SyncOpts{})
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, false, report.DryRun)
+	th.CheckDeepEquals(t, []string{"5a7e0f8b-6c9d-4e1f-9a4b-5c6d7e8f90a1"}, ids(report.Created))
+	// The IPv6 rule was deleted already.
+	th.CheckDeepEquals(t, []string{
+		"3e5c8d6f-4a7b-4c9d-9e2f-3a4b5c6d7e8f",
+		"4f6d9e7a-5b8c-4d0e-8f3a-4b5c6d7e8f90",
+	}, report.Deleted)
+	th.CheckEquals(t, 1, calls.Created())
+	th.CheckDeepEquals(t, []string{"3e5c8d6f-4a7b-4c9d-9e2f-3a4b5c6d7e8f"}, calls.Deleted())
+}
+
+func TestSyncDryRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroups(t, 1)
+	calls := HandleRules(t, http.StatusNoContent)
+
+	report, err := sync.Sync(context.TODO(), fake.ServiceClient(), SecGroupID, desired, sync.SyncOpts{
+		ApplyOpts: sync.ApplyOpts{DryRun: true},
+	})
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, true, report.DryRun)
+	th.CheckEquals(t, 1, len(report.Plan.Create))
+	th.CheckEquals(t, 2, len(report.Plan.Delete))
+	th.CheckEquals(t, 0, calls.Created())
+	th.CheckEquals(t, 0, len(calls.Deleted()))
+}
+
+func TestApplyDeleteFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroups(t, 1)
+	HandleRules(t, http.StatusConflict)
+
+	plan, err := sync.Diff(context.TODO(), fake.ServiceClient(), SecGroupID, desired, sync.DiffOpts{})
+	th.AssertNoErr(t, err)
+
+	report, err := sync.Apply(context.TODO(), fake.ServiceClient(), *plan, sync.ApplyOpts{Concurrency: 1})
+	var failed sync.ErrDeleteFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.CheckEquals(t, 1, len(failed.Errors))
+	th.AssertEquals(t, true, failed.Errors["4f6d9e7a-5b8c-4d0e-8f3a-4b5c6d7e8f90"] != nil)
+	th.CheckDeepEquals(t, []string{"3e5c8d6f-4a7b-4c9d-9e2f-3a4b5c6d7e8f"}, report.Deleted)
+}
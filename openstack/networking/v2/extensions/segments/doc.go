@@ -0,0 +1,85 @@
+/*
+Package segments provides information and interaction with the network
+segments extension for the OpenStack Networking service.
+
+A segment is a part of a network, such as a VLAN of a physical network. On
+routed provider networks, each subnet is associated with a segment (see the
+SegmentID of subnets), and only reachable from the hosts connected to this
+segment.
+
+Example to List Segments of a Network
+
+	listOpts := segments.ListOpts{
+		NetworkID: "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+	}
+
+	allPages, err := segments.List(networkClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allSegments, err := segments.ExtractSegments(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, segment := range allSegments {
+		fmt.Printf("%+v\n", segment)
+	}
+
+Example to Create a Segment
+
+	createOpts := segments.CreateOpts{
+		NetworkID:       "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		NetworkType:     "vlan",
+		PhysicalNetwork: "rack1",
+		SegmentationID:  2016,
+		Name:            "rack1",
+	}
+
+	segment, err := segments.Create(context.TODO(), networkClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Subnet on a Segment
+
+	subnetOpts := subnets.CreateOpts{
+		NetworkID: "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		SegmentID: segment.ID,
+		IPVersion: 4,
+		CIDR:      "10.1.0.0/24",
+	}
+
+	subnet, err := subnets.Create(context.TODO(), networkClient, subnetOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Segment
+
+	name := "rack1-vlan"
+	updateOpts := segments.UpdateOpts{
+		Name: &name,
+	}
+
+	segment, err := segments.Update(context.TODO(), networkClient, "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Segment
+
+	err := segments.Delete(context.TODO(), networkClient, "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Find the Subnets Reachable from a Compute Host
+
+	hostSubnets, err := segments.ListHostSubnets(context.TODO(), networkClient, "d32019d3-bc6e-4319-9c1d-6722fc136a22", "compute-rack1-01")
+	if err != nil {
+		panic(err)
+	}
+*/
+package segments
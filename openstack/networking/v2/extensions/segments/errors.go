@@ -0,0 +1,35 @@
+package segments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrNoSegment is returned by FindByHost when no port of the network bound to
+// the host is in a subnet with a segment.
+type ErrNoSegment struct {
+	gophercloud.BaseError
+
+	NetworkID string
+	Host      string
+}
+
+func (e ErrNoSegment) Error() string {
+	return fmt.Sprintf("no segment of network %s found for host %s", e.NetworkID, e.Host)
+}
+
+// ErrMultipleSegments is returned by FindByHost when the ports of the network
+// bound to the host are in subnets of several segments.
+type ErrMultipleSegments struct {
+	gophercloud.BaseError
+
+	NetworkID  string
+	Host       string
+	SegmentIDs []string
+}
+
+func (e ErrMultipleSegments) Error() string {
+	return fmt.Sprintf("host %s is connected to segments %s of network %s", e.Host, strings.Join(e.SegmentIDs, ", "), e.NetworkID)
+}
@@ -0,0 +1,215 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/segments"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	NetworkID  = "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	Segment1ID = "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2"
+	Segment2ID = "053b3c3b-28a6-4b8e-8f0e-4b5e0b6e7f81"
+	Subnet1ID  = "7e3a1c5d-2b0f-4c8e-9d6a-1f2e3d4c5b6a"
+	Subnet2ID  = "f1c0b2a3-9d8e-4f7a-8b6c-5d4e3f2a1b0c"
+)
+
+// SegmentListResult is the response of a list request.
+const SegmentListResult = `
+{
+	"segments": [
+		{
+			"id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"name": "rack1",
+			"description": "",
+			"network_type": "vlan",
+			"physical_network": "rack1",
+			"segmentation_id": 2016,
+			"revision_number": 1,
+			"created_at": "2023-03-01T10:00:00Z",
+			"updated_at": "2023-03-01T10:00:00Z"
+		},
+		{
+			"id": "053b3c3b-28a6-4b8e-8f0e-4b5e0b6e7f81",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"name": "rack2",
+			"description": "",
+			"network_type": "vlan",
+			"physical_network": "rack2",
+			"segmentation_id": 2017,
+			"revision_number": 1,
+			"created_at": "2023-03-01T10:00:05Z",
+			"updated_at": "2023-03-01T10:00:05Z"
+		}
+	]
+}
+`
+
+// SegmentGetResult is the response of a get request.
+const SegmentGetResult = `
+{
+	"segment": {
+		"id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"name": "rack1",
+		"description": "",
+		"network_type": "vlan",
+		"physical_network": "rack1",
+		"segmentation_id": 2016,
+		"revision_number": 1,
+		"created_at": "2023-03-01T10:00:00Z",
+		"updated_at": "2023-03-01T10:00:00Z"
+	}
+}
+`
+
+// SegmentCreateRequest is the request of a create request.
+const SegmentCreateRequest = `
+{
+	"segment": {
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"network_type": "vlan",
+		"physical_network": "rack1",
+		"segmentation_id": 2016,
+		"name": "rack1"
+	}
+}
+`
+
+// SegmentUpdateRequest is the request of an update request.
+const SegmentUpdateRequest = `
+{
+	"segment": {
+		"name": "rack1-vlan",
+		"description": "first rack"
+	}
+}
+`
+
+// SegmentUpdateResult is the response of an update request.
+const SegmentUpdateResult = `
+{
+	"segment": {
+		"id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2",
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"name": "rack1-vlan",
+		"description": "first rack",
+		"network_type": "vlan",
+		"physical_network": "rack1",
+		"segmentation_id": 2016,
+		"revision_number": 2,
+		"created_at": "2023-03-01T10:00:00Z",
+		"updated_at": "2023-03-02T08:30:00Z"
+	}
+}
+`
+
+// Segment1 is the first segment of the list.
+var Segment1 = segments.Segment{
+	ID:              Segment1ID,
+	NetworkID:       NetworkID,
+	Name:            "rack1",
+	NetworkType:     "vlan",
+	PhysicalNetwork: "rack1",
+	SegmentationID:  2016,
+	RevisionNumber:  1,
+	CreatedAt:       time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+	UpdatedAt:       time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC),
+}
+
+// Segment2 is the second segment of the list.
+var Segment2 = segments.Segment{
+	ID:              Segment2ID,
+	NetworkID:       NetworkID,
+	Name:            "rack2",
+	NetworkType:     "vlan",
+	PhysicalNetwork: "rack2",
+	SegmentationID:  2017,
+	RevisionNumber:  1,
+	CreatedAt:       time.Date(2023, time.March, 1, 10, 0, 5, 0, time.UTC),
+	UpdatedAt:       time.Date(2023, time.March, 1, 10, 0, 5, 0, time.UTC),
+}
+
+// NetworkSubnetsResult are the subnets of the routed network, one per
+// segment.
+const NetworkSubnetsResult = `
+{
+	"subnets": [
+		{
+			"id": "7e3a1c5d-2b0f-4c8e-9d6a-1f2e3d4c5b6a",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"ip_version": 4,
+			"cidr": "10.1.0.0/24",
+			"segment_id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2"
+		},
+		{
+			"id": "f1c0b2a3-9d8e-4f7a-8b6c-5d4e3f2a1b0c",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"ip_version": 4,
+			"cidr": "10.2.0.0/24",
+			"segment_id": "053b3c3b-28a6-4b8e-8f0e-4b5e0b6e7f81"
+		}
+	]
+}
+`
+
+// HostPortsResult returns the ports of the network bound to compute1, with
+// fixed IPs in subnets.
+func HostPortsResult(subnetIDs ...string) string {
+	var ports string
+	for i, subnetID := range subnetIDs {
+		if i > 0 {
+			ports += ","
+		}
+		ports += fmt.Sprintf(`
+		{
+			"id": "c0d1e2f3-0000-4000-8000-00000000000%d",
+			"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+			"binding:host_id": "compute1",
+			"fixed_ips": [{"subnet_id": %q, "ip_address": "10.0.0.%d"}]
+		}`, i+1, subnetID, i+10)
+	}
+	return fmt.Sprintf(`{"ports": [%s]}`, ports)
+}
+
+// HandleHost handles the requests of FindByHost, where the ports of the
+// network bound to compute1 have fixed IPs in subnets.
+func HandleHost(t *testing.T, subnetIDs ...string) {
+	th.Mux.HandleFunc("/v2.0/ports", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"network_id":      NetworkID,
+			"binding:host_id": "compute1",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, HostPortsResult(subnetIDs...))
+	})
+
+	th.Mux.HandleFunc("/v2.0/subnets", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		if r.URL.Query().Get("segment_id") == Segment1ID {
+			fmt.Fprint(w, `{"subnets": [{"id": "7e3a1c5d-2b0f-4c8e-9d6a-1f2e3d4c5b6a", "cidr": "10.1.0.0/24", "segment_id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2"}]}`)
+			return
+		}
+		fmt.Fprint(w, NetworkSubnetsResult)
+	})
+
+	th.Mux.HandleFunc("/v2.0/segments/"+Segment1ID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, SegmentGetResult)
+	})
+}
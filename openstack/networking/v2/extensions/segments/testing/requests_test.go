@@ -0,0 +1,179 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/segments"
+	"github.com/vnpaycloud-console/gophercloud/v2/pagination"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/segments", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"network_id": NetworkID})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, SegmentListResult)
+	})
+
+	count := 0
+	err := segments.List(fake.ServiceClient(), segments.ListOpts{NetworkID: NetworkID}).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+		actual, err := segments.ExtractSegments(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []segments.Segment{Segment1, Segment2}, actual)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/segments/"+Segment1ID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, SegmentGetResult)
+	})
+
+	s, err := segments.Get(context.TODO(), fake.ServiceClient(), Segment1ID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Segment1, *s)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/segments", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, SegmentCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, SegmentGetResult)
+	})
+
+	s, err := segments.Create(context.TODO(), fake.ServiceClient(), segments.CreateOpts{
+		NetworkID:       NetworkID,
+		NetworkType:     "vlan",
+		PhysicalNetwork: "rack1",
+		SegmentationID:  2016,
+		Name:            "rack1",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Segment1, *s)
+}
+
+func TestRequiredCreateOpts(t *testing.T) {
+	res := segments.Create(context.TODO(), fake.ServiceClient(), segments.CreateOpts{NetworkID: NetworkID})
+	if res.Err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/segments/"+Segment1ID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, SegmentUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, SegmentUpdateResult)
+	})
+
+	name, description := "rack1-vlan", "first rack"
+	s, err := segments.Update(context.TODO(), fake.ServiceClient(), Segment1ID, segments.UpdateOpts{
+		Name:        &name,
+		Description: &description,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "rack1-vlan", s.Name)
+	th.AssertEquals(t, "first rack", s.Description)
+	th.AssertEquals(t, 2, s.RevisionNumber)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/segments/"+Segment1ID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := segments.Delete(context.TODO(), fake.ServiceClient(), Segment1ID)
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestFindByHost(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleHost(t, Subnet1ID, Subnet1ID)
+
+	s, err := segments.FindByHost(context.TODO(), fake.ServiceClient(), NetworkID, "compute1")
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Segment1, *s)
+}
+
+func TestFindByHostNoSegment(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleHost(t)
+
+	_, err := segments.FindByHost(context.TODO(), fake.ServiceClient(), NetworkID, "compute1")
+	_, ok := err.(segments.ErrNoSegment)
+	th.AssertEquals(t, true, ok)
+}
+
+func TestFindByHostMultipleSegments(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleHost(t, Subnet1ID, Subnet2ID)
+
+	_, err := segments.FindByHost(context.TODO(), fake.ServiceClient(), NetworkID, "compute1")
+	multiple, ok := err.(segments.ErrMultipleSegments)
+	th.AssertEquals(t, true, ok)
+	th.CheckDeepEquals(t, []string{Segment2ID, Segment1ID}, multiple.SegmentIDs)
+}
+
+func TestListHostSubnets(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleHost(t, Subnet1ID)
+
+	hostSubnets, err := segments.ListHostSubnets(context.TODO(), fake.ServiceClient(), NetworkID, "compute1")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(hostSubnets))
+	th.AssertEquals(t, Subnet1ID, hostSubnets[0].ID)
+	th.AssertEquals(t, Segment1ID, hostSubnets[0].SegmentID)
+}
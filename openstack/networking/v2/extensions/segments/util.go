@@ -0,0 +1,99 @@
+package segments
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/portsbinding"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/ports"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/subnets"
+)
+
+// FindByHost returns the segment of a routed provider network a compute host
+// is connected to.
+//
+// The networking service doesn't expose the mapping of hosts to segments, so
+// it is inferred from the ports of the network bound to the host: the
+// segment is the one of the subnets of their fixed IPs. It returns an
+// ErrNoSegment if no port of the network is bound to the host, and an
+// ErrMultipleSegments if the ports are in subnets of different segments.
+// Listing the ports of other projects requires administrative privileges.
+func FindByHost(ctx context.Context, client *gophercloud.ServiceClient, networkID, host string) (*Segment, error) {
+	if networkID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "networkID"}
+	}
+	if host == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "host"}
+	}
+
+	allPages, err := ports.List(client, portsbinding.ListOptsExt{
+		ListOptsBuilder: ports.ListOpts{NetworkID: networkID},
+		HostID:          host,
+	}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hostPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err = subnets.List(client, subnets.ListOpts{NetworkID: networkID}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	networkSubnets, err := subnets.ExtractSubnets(allPages)
+	if err != nil {
+		return nil, err
+	}
+	subnetSegments := make(map[string]string, len(networkSubnets))
+	for _, subnet := range networkSubnets {
+		subnetSegments[subnet.ID] = subnet.SegmentID
+	}
+
+	found := make(map[string]bool)
+	for _, port := range hostPorts {
+		for _, ip := range port.FixedIPs {
+			if segmentID := subnetSegments[ip.SubnetID]; segmentID != "" {
+				found[segmentID] = true
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, ErrNoSegment{NetworkID: networkID, Host: host}
+	case 1:
+		for segmentID := range found {
+			return Get(ctx, client, segmentID).Extract()
+		}
+	}
+
+	segmentIDs := make([]string, 0, len(found))
+	for segmentID := range found {
+		segmentIDs = append(segmentIDs, segmentID)
+	}
+	sort.Strings(segmentIDs)
+	return nil, ErrMultipleSegments{NetworkID: networkID, Host: host, SegmentIDs: segmentIDs}
+}
+
+// ListHostSubnets returns the subnets of a routed provider network reachable
+// from a compute host, which are the subnets of the segment returned by
+// FindByHost. The fixed IPs of a port to bind to the host must be in these
+// subnets.
+func ListHostSubnets(ctx context.Context, client *gophercloud.ServiceClient, networkID, host string) ([]subnets.Subnet, error) {
+	segment, err := FindByHost(ctx, client, networkID, host)
+	if err != nil {
+		return nil, err
+	}
+
+	allPages, err := subnets.List(client, subnets.ListOpts{
+		NetworkID: networkID,
+		SegmentID: segment.ID,
+	}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return subnets.ExtractSubnets(allPages)
+}
@@ -0,0 +1,47 @@
+/*
+Package tunnels creates site-to-site IPsec tunnels from a single spec: the
+IKE policy, the IPsec policy, the VPN service of the router, the local and
+peer endpoint groups, and the site connection.
+
+Each resource is reused if it exists, after checking that it matches the
+spec, or created. If creating the tunnel fails, the resources created are
+deleted in reverse order.
+
+Example to Create a Tunnel
+
+	tunnel, err := tunnels.Create(context.TODO(), networkClient, tunnels.Spec{
+		Name:     "office",
+		RouterID: "66e3b16c-8ce5-40fb-bb49-ab6d8dc3f2aa",
+		IKEPolicy: ikepolicies.CreateOpts{
+			EncryptionAlgorithm: ikepolicies.EncryptionAlgorithmAES256,
+			Lifetime: &ikepolicies.LifetimeCreateOpts{
+				Units: ikepolicies.UnitSeconds,
+				Value: 28800,
+			},
+		},
+		IPSecPolicy: ipsecpolicies.CreateOpts{
+			EncryptionAlgorithm: ipsecpolicies.EncryptionAlgorithmAES256,
+			Lifetime: &ipsecpolicies.LifetimeCreateOpts{
+				Units: ipsecpolicies.UnitSeconds,
+				Value: 3600,
+			},
+		},
+		LocalSubnetIDs: []string{"9e2a2a33-8f62-4bdb-9c39-c2a6d5ac5b34"},
+		PeerCIDRs:      []string{"192.168.10.0/24"},
+		PeerAddress:    "203.0.113.10",
+		PSK:            "secret",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Tunnel
+
+	err := tunnels.Delete(context.TODO(), networkClient, tunnel, tunnels.DeleteOpts{
+		CreatedOnly: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package tunnels
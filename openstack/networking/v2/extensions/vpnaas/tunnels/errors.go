@@ -0,0 +1,84 @@
+package tunnels
+
+import (
+	"fmt"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+// ErrMismatch is returned by Create when a reused resource doesn't match
+// the spec. Want and Got are empty for secret fields, such as psk.
+type ErrMismatch struct {
+	gophercloud.BaseError
+
+	Resource Resource
+	Field    string
+	Want     string
+	Got      string
+}
+
+func (e ErrMismatch) Error() string {
+	if e.Want == "" && e.Got == "" {
+		return fmt.Sprintf("%s %s has a different %s", e.Resource.Kind, e.Resource.ID, e.Field)
+	}
+	if e.Want == "" {
+		return fmt.Sprintf("%s %s has unexpected %s %q", e.Resource.Kind, e.Resource.ID, e.Field, e.Got)
+	}
+	return fmt.Sprintf("%s %s has %s %q instead of %q", e.Resource.Kind, e.Resource.ID, e.Field, e.Got, e.Want)
+}
+
+// ErrConnectionFailed is returned by Create when the site connection goes
+// in ERROR status.
+type ErrConnectionFailed struct {
+	gophercloud.BaseError
+
+	Connection siteconnections.Connection
+}
+
+func (e ErrConnectionFailed) Error() string {
+	return fmt.Sprintf("ipsec-site-connection %s is in status %s", e.Connection.ID, e.Connection.Status)
+}
+
+// ErrRollbackFailed is returned by Create when it failed, and some of the
+// resources it created could not be deleted.
+type ErrRollbackFailed struct {
+	gophercloud.BaseError
+
+	// Err is the error Create failed with.
+	Err error
+
+	// Leaked are the resources which could not be deleted.
+	Leaked []Resource
+
+	// RollbackErr is the error the first deletion failed with.
+	RollbackErr error
+}
+
+func (e ErrRollbackFailed) Error() string {
+	return fmt.Sprintf("%v; %d tunnel resources could not be deleted: %v", e.Err, len(e.Leaked), e.RollbackErr)
+}
+
+func (e ErrRollbackFailed) Unwrap() error {
+	return e.Err
+}
+
+// ErrDeleteFailed is returned by Delete when some resources could not be
+// deleted.
+type ErrDeleteFailed struct {
+	gophercloud.BaseError
+
+	// Leaked are the resources which could not be deleted.
+	Leaked []Resource
+
+	// Err is the error the first deletion failed with.
+	Err error
+}
+
+func (e ErrDeleteFailed) Error() string {
+	return fmt.Sprintf("%d tunnel resources could not be deleted: %v", len(e.Leaked), e.Err)
+}
+
+func (e ErrDeleteFailed) Unwrap() error {
+	return e.Err
+}
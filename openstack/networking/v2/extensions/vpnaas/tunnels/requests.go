@@ -0,0 +1,527 @@
+package tunnels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/services"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+// Spec describes a site-to-site IPsec tunnel.
+type Spec struct {
+	// Name is the name of the site connection. The other resources are
+	// named after it, unless their name is given.
+	Name string
+
+	// RouterID is the router of the VPN service. Required unless
+	// VPNServiceID is given.
+	RouterID string
+
+	// VPNServiceID is an existing VPN service to use. By default, a VPN
+	// service of the router without subnet is reused, or one is created.
+	VPNServiceID string
+
+	// IKEPolicyID is an existing IKE policy to use. By default, the IKE
+	// policy named IKEPolicy.Name, or Name suffixed by "-ike", is reused, or
+	// created from IKEPolicy.
+	IKEPolicyID string
+	IKEPolicy   ikepolicies.CreateOpts
+
+	// IPSecPolicyID is an existing IPsec policy to use. By default, the
+	// IPsec policy named IPSecPolicy.Name, or Name suffixed by "-ipsec", is
+	// reused, or created from IPSecPolicy.
+	IPSecPolicyID string
+	IPSecPolicy   ipsecpolicies.CreateOpts
+
+	// LocalSubnetIDs are the local subnets reachable through the tunnel.
+	LocalSubnetIDs []string
+
+	// PeerCIDRs are the remote CIDRs reachable through the tunnel.
+	PeerCIDRs []string
+
+	// PeerAddress is the public address of the peer gateway.
+	PeerAddress string
+
+	// PeerID is the identity of the peer. Defaults to PeerAddress.
+	PeerID string
+
+	// PSK is the pre-shared key.
+	PSK string
+
+	LocalID      string
+	Initiator    siteconnections.Initiator
+	DPD          *siteconnections.DPDCreateOpts
+	MTU          int
+	AdminStateUp *bool
+}
+
+// builder creates or reuses the resources of a tunnel.
+type builder struct {
+	client *gophercloud.ServiceClient
+	spec   Spec
+	tunnel *Tunnel
+}
+
+// Create creates the resources of a tunnel, in dependency order, reusing
+// the ones which exist already, then waits until the site connection is
+// ACTIVE or DOWN. It returns an ErrMismatch if a reused resource doesn't
+// match the spec.
+//
+// If a step fails, or the site connection goes in ERROR status, the
+// resources created by this call are deleted in reverse order. If they
+// can't all be deleted, an ErrRollbackFailed is returned.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, spec Spec) (*Tunnel, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	if spec.PeerID == "" {
+		spec.PeerID = spec.PeerAddress
+	}
+
+	b := &builder{client: client, spec: spec, tunnel: &Tunnel{}}
+	steps := []func(context.Context) error{
+		b.ikePolicy,
+		b.ipsecPolicy,
+		b.service,
+		b.localEndpointGroup,
+		b.peerEndpointGroup,
+		b.connection,
+		b.wait,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			if leaked, rollbackErr := deleteResources(context.WithoutCancel(ctx), client, b.tunnel.Created, false); rollbackErr != nil {
+				return nil, ErrRollbackFailed{Err: err, Leaked: leaked, RollbackErr: rollbackErr}
+			}
+			return nil, err
+		}
+	}
+
+	return b.tunnel, nil
+}
+
+func (spec Spec) validate() error {
+	if spec.Name == "" {
+		return gophercloud.ErrMissingInput{Argument: "Name"}
+	}
+	if spec.RouterID == "" && spec.VPNServiceID == "" {
+		return gophercloud.ErrMissingInput{Argument: "RouterID"}
+	}
+	if len(spec.LocalSubnetIDs) == 0 {
+		return gophercloud.ErrMissingInput{Argument: "LocalSubnetIDs"}
+	}
+	if len(spec.PeerCIDRs) == 0 {
+		return gophercloud.ErrMissingInput{Argument: "PeerCIDRs"}
+	}
+	if spec.PeerAddress == "" {
+		return gophercloud.ErrMissingInput{Argument: "PeerAddress"}
+	}
+	if spec.PSK == "" {
+		return gophercloud.ErrMissingInput{Argument: "PSK"}
+	}
+	return nil
+}
+
+func (b *builder) created(kind ResourceKind, id string) {
+	b.tunnel.Created = append(b.tunnel.Created, Resource{Kind: kind, ID: id})
+}
+
+func (b *builder) ikePolicy(ctx context.Context) error {
+	want := b.spec.IKEPolicy
+	if want.Name == "" {
+		want.Name = b.spec.Name + "-ike"
+	}
+
+	var policy *ikepolicies.Policy
+	if b.spec.IKEPolicyID != "" {
+		p, err := ikepolicies.Get(ctx, b.client, b.spec.IKEPolicyID).Extract()
+		if err != nil {
+			return err
+		}
+		policy = p
+	} else {
+		allPages, err := ikepolicies.List(b.client, ikepolicies.ListOpts{Name: want.Name}).AllPages(ctx)
+		if err != nil {
+			return err
+		}
+		policies, err := ikepolicies.ExtractPolicies(allPages)
+		if err != nil {
+			return err
+		}
+		if len(policies) > 0 {
+			policy = &policies[0]
+		}
+	}
+
+	if policy == nil {
+		p, err := ikepolicies.Create(ctx, b.client, want).Extract()
+		if err != nil {
+			return err
+		}
+		b.created(KindIKEPolicy, p.ID)
+		b.tunnel.IKEPolicy = *p
+		return nil
+	}
+
+	resource := Resource{Kind: KindIKEPolicy, ID: policy.ID}
+	if err := compare(resource, []field{
+		{"auth_algorithm", string(want.AuthAlgorithm), policy.AuthAlgorithm},
+		{"encryption_algorithm", string(want.EncryptionAlgorithm), policy.EncryptionAlgorithm},
+		{"pfs", string(want.PFS), policy.PFS},
+		{"phase1_negotiation_mode", string(want.Phase1NegotiationMode), policy.Phase1NegotiationMode},
+		{"ike_version", string(want.IKEVersion), policy.IKEVersion},
+	}); err != nil {
+		return err
+	}
+	if want.Lifetime != nil {
+		if err := compare(resource, []field{
+			{"lifetime.units", string(want.Lifetime.Units), policy.Lifetime.Units},
+			{"lifetime.value", itoa(want.Lifetime.Value), strconv.Itoa(policy.Lifetime.Value)},
+		}); err != nil {
+			return err
+		}
+	}
+	b.tunnel.IKEPolicy = *policy
+	return nil
+}
+
+func (b *builder) ipsecPolicy(ctx context.Context) error {
+	want := b.spec.IPSecPolicy
+	if want.Name == "" {
+		want.Name = b.spec.Name + "-ipsec"
+	}
+
+	var policy *ipsecpolicies.Policy
+	if b.spec.IPSecPolicyID != "" {
+		p, err := ipsecpolicies.Get(ctx, b.client, b.spec.IPSecPolicyID).Extract()
+		if err != nil {
+			return err
+		}
+		policy = p
+	} else {
+		allPages, err := ipsecpolicies.List(b.client, ipsecpolicies.ListOpts{Name: want.Name}).AllPages(ctx)
+		if err != nil {
+			return err
+		}
+		policies, err := ipsecpolicies.ExtractPolicies(allPages)
+		if err != nil {
+			return err
+		}
+		if len(policies) > 0 {
+			policy = &policies[0]
+		}
+	}
+
+	if policy == nil {
+		p, err := ipsecpolicies.Create(ctx, b.client, want).Extract()
+		if err != nil {
+			return err
+		}
+		b.created(KindIPSecPolicy, p.ID)
+		b.tunnel.IPSecPolicy = *p
+		return nil
+	}
+
+	resource := Resource{Kind: KindIPSecPolicy, ID: policy.ID}
+	if err := compare(resource, []field{
+		{"auth_algorithm", string(want.AuthAlgorithm), policy.AuthAlgorithm},
+		{"encapsulation_mode", string(want.EncapsulationMode), policy.EncapsulationMode},
+		{"encryption_algorithm", string(want.EncryptionAlgorithm), policy.EncryptionAlgorithm},
+		{"pfs", string(want.PFS), policy.PFS},
+		{"transform_protocol", string(want.TransformProtocol), policy.TransformProtocol},
+	}); err != nil {
+		return err
+	}
+	if want.Lifetime != nil {
+		if err := compare(resource, []field{
+			{"lifetime.units", string(want.Lifetime.Units), policy.Lifetime.Units},
+			{"lifetime.value", itoa(want.Lifetime.Value), strconv.Itoa(policy.Lifetime.Value)},
+		}); err != nil {
+			return err
+		}
+	}
+	b.tunnel.IPSecPolicy = *policy
+	return nil
+}
+
+func (b *builder) service(ctx context.Context) error {
+	if b.spec.VPNServiceID != "" {
+		service, err := services.Get(ctx, b.client, b.spec.VPNServiceID).Extract()
+		if err != nil {
+			return err
+		}
+		if service.SubnetID != "" {
+			return ErrMismatch{Resource: Resource{Kind: KindVPNService, ID: service.ID}, Field: "subnet_id", Got: service.SubnetID}
+		}
+		b.tunnel.Service = *service
+		return nil
+	}
+
+	// Services with a subnet predate endpoint groups, and can't be used
+	// with them.
+	allPages, err := services.List(b.client, services.ListOpts{RouterID: b.spec.RouterID}).AllPages(ctx)
+	if err != nil {
+		return err
+	}
+	routerServices, err := services.ExtractServices(allPages)
+	if err != nil {
+		return err
+	}
+	for _, service := range routerServices {
+		if service.SubnetID == "" {
+			b.tunnel.Service = service
+			return nil
+		}
+	}
+
+	service, err := services.Create(ctx, b.client, services.CreateOpts{
+		Name:         b.spec.Name,
+		RouterID:     b.spec.RouterID,
+		AdminStateUp: gophercloud.Enabled,
+	}).Extract()
+	if err != nil {
+		return err
+	}
+	b.created(KindVPNService, service.ID)
+	b.tunnel.Service = *service
+	return nil
+}
+
+func (b *builder) localEndpointGroup(ctx context.Context) error {
+	group, err := b.endpointGroup(ctx, b.spec.Name+"-local", endpointgroups.TypeSubnet, b.spec.LocalSubnetIDs)
+	if err != nil {
+		return err
+	}
+	b.tunnel.LocalEndpointGroup = *group
+	return nil
+}
+
+func (b *builder) peerEndpointGroup(ctx context.Context) error {
+	group, err := b.endpointGroup(ctx, b.spec.Name+"-peer", endpointgroups.TypeCIDR, b.spec.PeerCIDRs)
+	if err != nil {
+		return err
+	}
+	b.tunnel.PeerEndpointGroup = *group
+	return nil
+}
+
+// endpointGroup reuses the endpoint group with a name, or creates it.
+func (b *builder) endpointGroup(ctx context.Context, name string, endpointType endpointgroups.EndpointType, endpoints []string) (*endpointgroups.EndpointGroup, error) {
+	allPages, err := endpointgroups.List(b.client, endpointgroups.ListOpts{Name: name}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) > 0 {
+		group := &groups[0]
+		resource := Resource{Kind: KindEndpointGroup, ID: group.ID}
+		if group.Type != string(endpointType) {
+			return nil, ErrMismatch{Resource: resource, Field: "type", Want: string(endpointType), Got: group.Type}
+		}
+		if !sameSet(group.Endpoints, endpoints) {
+			return nil, ErrMismatch{Resource: resource, Field: "endpoints", Want: fmt.Sprint(endpoints), Got: fmt.Sprint(group.Endpoints)}
+		}
+		return group, nil
+	}
+
+	group, err := endpointgroups.Create(ctx, b.client, endpointgroups.CreateOpts{
+		Name:      name,
+		Type:      endpointType,
+		Endpoints: endpoints,
+	}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	b.created(KindEndpointGroup, group.ID)
+	return group, nil
+}
+
+func (b *builder) connection(ctx context.Context) error {
+	t := b.tunnel
+	allPages, err := siteconnections.List(b.client, siteconnections.ListOpts{
+		Name:         b.spec.Name,
+		VPNServiceID: t.Service.ID,
+	}).AllPages(ctx)
+	if err != nil {
+		return err
+	}
+	connections, err := siteconnections.ExtractConnections(allPages)
+	if err != nil {
+		return err
+	}
+
+	if len(connections) > 0 {
+		connection := connections[0]
+		resource := Resource{Kind: KindSiteConnection, ID: connection.ID}
+		// The PSK is a secret, it is left out of the error.
+		if connection.PSK != b.spec.PSK {
+			return ErrMismatch{Resource: resource, Field: "psk"}
+		}
+		if err := compare(resource, []field{
+			{"ikepolicy_id", t.IKEPolicy.ID, connection.IKEPolicyID},
+			{"ipsecpolicy_id", t.IPSecPolicy.ID, connection.IPSecPolicyID},
+			{"local_ep_group_id", t.LocalEndpointGroup.ID, connection.LocalEPGroupID},
+			{"peer_ep_group_id", t.PeerEndpointGroup.ID, connection.PeerEPGroupID},
+			{"peer_address", b.spec.PeerAddress, connection.PeerAddress},
+			{"peer_id", b.spec.PeerID, connection.PeerID},
+			{"local_id", b.spec.LocalID, connection.LocalID},
+			{"initiator", string(b.spec.Initiator), connection.Initiator},
+			{"mtu", itoa(b.spec.MTU), strconv.Itoa(connection.MTU)},
+		}); err != nil {
+			return err
+		}
+		t.Connection = connection
+		return nil
+	}
+
+	connection, err := siteconnections.Create(ctx, b.client, siteconnections.CreateOpts{
+		Name:           b.spec.Name,
+		VPNServiceID:   t.Service.ID,
+		IKEPolicyID:    t.IKEPolicy.ID,
+		IPSecPolicyID:  t.IPSecPolicy.ID,
+		LocalEPGroupID: t.LocalEndpointGroup.ID,
+		PeerEPGroupID:  t.PeerEndpointGroup.ID,
+		PeerAddress:    b.spec.PeerAddress,
+		PeerID:         b.spec.PeerID,
+		PSK:            b.spec.PSK,
+		LocalID:        b.spec.LocalID,
+		Initiator:      b.spec.Initiator,
+		DPD:            b.spec.DPD,
+		MTU:            b.spec.MTU,
+		AdminStateUp:   b.spec.AdminStateUp,
+	}).Extract()
+	if err != nil {
+		return err
+	}
+	b.created(KindSiteConnection, connection.ID)
+	t.Connection = *connection
+	return nil
+}
+
+// wait waits until the site connection is ACTIVE or DOWN. A DOWN connection
+// is configured, but the peer is not connected yet.
+func (b *builder) wait(ctx context.Context) error {
+	return gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		connection, err := siteconnections.Get(ctx, b.client, b.tunnel.Connection.ID).Extract()
+		if err != nil {
+			return false, err
+		}
+		b.tunnel.Connection = *connection
+
+		switch connection.Status {
+		case "ACTIVE", "DOWN":
+			return true, nil
+		case "ERROR":
+			return false, ErrConnectionFailed{Connection: *connection}
+		}
+		return false, nil
+	})
+}
+
+// DeleteOpts configures Delete.
+type DeleteOpts struct {
+	// CreatedOnly only deletes the resources created by Create, keeping the
+	// reused ones.
+	CreatedOnly bool
+}
+
+// Delete deletes the resources of a tunnel, in reverse dependency order:
+// the site connection, the endpoint groups, the VPN service, then the
+// policies. Resources already deleted are ignored. Unless
+// DeleteOpts.CreatedOnly is set, the resources still used by other site
+// connections are kept.
+func Delete(ctx context.Context, client *gophercloud.ServiceClient, tunnel *Tunnel, opts DeleteOpts) error {
+	resources := tunnel.Created
+	if !opts.CreatedOnly {
+		resources = []Resource{
+			{Kind: KindIKEPolicy, ID: tunnel.IKEPolicy.ID},
+			{Kind: KindIPSecPolicy, ID: tunnel.IPSecPolicy.ID},
+			{Kind: KindVPNService, ID: tunnel.Service.ID},
+			{Kind: KindEndpointGroup, ID: tunnel.LocalEndpointGroup.ID},
+			{Kind: KindEndpointGroup, ID: tunnel.PeerEndpointGroup.ID},
+			{Kind: KindSiteConnection, ID: tunnel.Connection.ID},
+		}
+	}
+
+	leaked, err := deleteResources(ctx, client, resources, !opts.CreatedOnly)
+	if err != nil {
+		return ErrDeleteFailed{Leaked: leaked, Err: err}
+	}
+	return nil
+}
+
+// deleteResources deletes resources in reverse order, and returns the ones
+// which could not be deleted on error. If keepInUse is set, the resources
+// still in use, for which the deletion conflicts, are kept.
+func deleteResources(ctx context.Context, client *gophercloud.ServiceClient, resources []Resource, keepInUse bool) ([]Resource, error) {
+	for i := len(resources) - 1; i >= 0; i-- {
+		r := resources[i]
+		if r.ID == "" {
+			continue
+		}
+
+		var err error
+		switch r.Kind {
+		case KindSiteConnection:
+			err = siteconnections.Delete(ctx, client, r.ID).ExtractErr()
+		case KindEndpointGroup:
+			err = endpointgroups.Delete(ctx, client, r.ID).ExtractErr()
+		case KindVPNService:
+			err = services.Delete(ctx, client, r.ID).ExtractErr()
+		case KindIPSecPolicy:
+			err = ipsecpolicies.Delete(ctx, client, r.ID).ExtractErr()
+		case KindIKEPolicy:
+			err = ikepolicies.Delete(ctx, client, r.ID).ExtractErr()
+		}
+		if gophercloud.ResponseCodeIs(err, http.StatusNotFound) || (keepInUse && gophercloud.ResponseCodeIs(err, http.StatusConflict)) {
+			continue
+		}
+		if err != nil {
+			return slices.Clone(resources[:i+1]), err
+		}
+	}
+	return nil, nil
+}
+
+// field is a field of a reused resource, as wanted and as got.
+type field struct {
+	name      string
+	want, got string
+}
+
+// compare returns an ErrMismatch for the first field with an unexpected
+// value. Fields not wanted are not compared.
+func compare(resource Resource, fields []field) error {
+	for _, f := range fields {
+		if f.want != "" && f.want != f.got {
+			return ErrMismatch{Resource: resource, Field: f.name, Want: f.want, Got: f.got}
+		}
+	}
+	return nil
+}
+
+func itoa(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
@@ -0,0 +1,41 @@
+package tunnels
+
+import (
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/services"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+// ResourceKind is the kind of a resource of a tunnel.
+type ResourceKind string
+
+const (
+	KindIKEPolicy      ResourceKind = "ikepolicy"
+	KindIPSecPolicy    ResourceKind = "ipsecpolicy"
+	KindVPNService     ResourceKind = "vpnservice"
+	KindEndpointGroup  ResourceKind = "endpoint-group"
+	KindSiteConnection ResourceKind = "ipsec-site-connection"
+)
+
+// Resource is a resource of a tunnel.
+type Resource struct {
+	Kind ResourceKind
+	ID   string
+}
+
+// Tunnel is a site-to-site IPsec tunnel, made of the resources created or
+// reused by Create.
+type Tunnel struct {
+	IKEPolicy          ikepolicies.Policy
+	IPSecPolicy        ipsecpolicies.Policy
+	Service            services.Service
+	LocalEndpointGroup endpointgroups.EndpointGroup
+	PeerEndpointGroup  endpointgroups.EndpointGroup
+	Connection         siteconnections.Connection
+
+	// Created are the resources created by Create, as opposed to reused, in
+	// creation order.
+	Created []Resource
+}
@@ -0,0 +1,2 @@
+// tunnels unit tests
+package testing
@@ -0,0 +1,248 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+const (
+	RouterID            = "66e3b16c-8ce5-40fb-bb49-ab6d8dc3f2aa"
+	SubnetID            = "9e2a2a33-8f62-4bdb-9c39-c2a6d5ac5b34"
+	IKEPolicyID         = "9b00d6b0-6c93-4ca5-9747-b8ade7bb514f"
+	IPSecPolicyID       = "e6e23d0c-9519-4d52-8ea4-5b1f96d857b1"
+	ServiceID           = "5c561d9d-eaea-45f6-ae3e-08d1a7080828"
+	LocalEPGroupID      = "3e1815dd-e212-43d0-8f13-b494fa553e68"
+	PeerEPGroupID       = "9ad5a7e0-6dac-41b4-b20d-a7b8645fddf1"
+	ConnectionID        = "851f280f-5639-4ea3-81aa-e298525ab74b"
+	ExistingIKEPolicyID = "5522aff7-1b3c-48dd-9c3c-b50f016b73db"
+)
+
+// IKEPolicy is the IKE policy of the tunnel.
+const IKEPolicy = `
+{
+	"id": "9b00d6b0-6c93-4ca5-9747-b8ade7bb514f",
+	"name": "office-ike",
+	"auth_algorithm": "sha1",
+	"encryption_algorithm": "aes-256",
+	"pfs": "group5",
+	"phase1_negotiation_mode": "main",
+	"ike_version": "v1",
+	"lifetime": {"units": "seconds", "value": 28800}
+}`
+
+// ExistingIKEPolicy is an IKE policy named like the IKE policy of the
+// tunnel, with another encryption algorithm.
+const ExistingIKEPolicy = `
+{
+	"id": "5522aff7-1b3c-48dd-9c3c-b50f016b73db",
+	"name": "office-ike",
+	"auth_algorithm": "sha1",
+	"encryption_algorithm": "aes-128",
+	"pfs": "group5",
+	"phase1_negotiation_mode": "main",
+	"ike_version": "v1",
+	"lifetime": {"units": "seconds", "value": 3600}
+}`
+
+// IPSecPolicy is the IPsec policy of the tunnel.
+const IPSecPolicy = `
+{
+	"id": "e6e23d0c-9519-4d52-8ea4-5b1f96d857b1",
+	"name": "office-ipsec",
+	"auth_algorithm": "sha1",
+	"encapsulation_mode": "tunnel",
+	"encryption_algorithm": "aes-256",
+	"pfs": "group5",
+	"transform_protocol": "esp",
+	"lifetime": {"units": "seconds", "value": 3600}
+}`
+
+// Service is the VPN service of the tunnel.
+const Service = `
+{
+	"id": "5c561d9d-eaea-45f6-ae3e-08d1a7080828",
+	"name": "office",
+	"router_id": "66e3b16c-8ce5-40fb-bb49-ab6d8dc3f2aa",
+	"subnet_id": "",
+	"admin_state_up": true,
+	"status": "PENDING_CREATE",
+	"external_v4_ip": "172.24.4.10"
+}`
+
+// LegacyService is a VPN service of the router with a subnet, which can't be
+// used with endpoint groups.
+const LegacyService = `
+{
+	"id": "2ac9d8b5-4d35-4fd3-9f53-3b4a1d0e1f23",
+	"name": "legacy",
+	"router_id": "66e3b16c-8ce5-40fb-bb49-ab6d8dc3f2aa",
+	"subnet_id": "0f3e5f7c-2b9b-4c2e-8a8e-9d6f1a6c1b11",
+	"admin_state_up": true,
+	"status": "ACTIVE"
+}`
+
+// LocalEndpointGroup is the local endpoint group of the tunnel.
+const LocalEndpointGroup = `
+{
+	"id": "3e1815dd-e212-43d0-8f13-b494fa553e68",
+	"name": "office-local",
+	"type": "subnet",
+	"endpoints": ["9e2a2a33-8f62-4bdb-9c39-c2a6d5ac5b34"]
+}`
+
+// PeerEndpointGroup is the peer endpoint group of the tunnel.
+const PeerEndpointGroup = `
+{
+	"id": "9ad5a7e0-6dac-41b4-b20d-a7b8645fddf1",
+	"name": "office-peer",
+	"type": "cidr",
+	"endpoints": ["192.168.10.0/24"]
+}`
+
+// Connection returns the site connection of the tunnel in a status.
+func Connection(status string) string {
+	return fmt.Sprintf(`
+{
+	"id": "851f280f-5639-4ea3-81aa-e298525ab74b",
+	"name": "office",
+	"status": %q,
+	"vpnservice_id": "5c561d9d-eaea-45f6-ae3e-08d1a7080828",
+	"ikepolicy_id": "9b00d6b0-6c93-4ca5-9747-b8ade7bb514f",
+	"ipsecpolicy_id": "e6e23d0c-9519-4d52-8ea4-5b1f96d857b1",
+	"local_ep_group_id": "3e1815dd-e212-43d0-8f13-b494fa553e68",
+	"peer_ep_group_id": "9ad5a7e0-6dac-41b4-b20d-a7b8645fddf1",
+	"peer_address": "203.0.113.10",
+	"peer_id": "203.0.113.10",
+	"psk": "secret",
+	"initiator": "bi-directional",
+	"admin_state_up": true,
+	"mtu": 1500
+}`, status)
+}
+
+// Collection is a fake collection of VPNaaS resources.
+type Collection struct {
+	// Path is the path of the collection, such as "vpn/ikepolicies".
+	Path string
+
+	// Key is the key of a resource in a response, such as "ikepolicy".
+	Key string
+
+	// Listed are the resources returned by the list requests, filtered by
+	// name.
+	Listed []string
+
+	// Created are the resources returned by the create requests, in order.
+	Created []string
+
+	// Get is the resource returned by the get requests, if any.
+	Get string
+
+	// CreateRequest is the expected create request, if any.
+	CreateRequest string
+
+	// DeleteStatus is the status of the delete responses. Defaults to 204.
+	DeleteStatus int
+}
+
+// HandleCollections handles the requests to collections, and records the
+// deletions as "path/id".
+func HandleCollections(t *testing.T, collections []Collection) *[]string {
+	deleted := new([]string)
+	for _, c := range collections {
+		c := c
+		created := 0
+		th.Mux.HandleFunc("/v2.0/"+c.Path, func(w http.ResponseWriter, r *http.Request) {
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			w.Header().Add("Content-Type", "application/json")
+
+			switch r.Method {
+			case "GET":
+				var listed []string
+				for _, resource := range c.Listed {
+					var named struct {
+						Name string `json:"name"`
+					}
+					th.AssertNoErr(t, json.Unmarshal([]byte(resource), &named))
+					if name := r.URL.Query().Get("name"); name == "" || name == named.Name {
+						listed = append(listed, resource)
+					}
+				}
+				fmt.Fprintf(w, `{"%s": [%s]}`, plural(c.Key), strings.Join(listed, ","))
+			case "POST":
+				if created == len(c.Created) {
+					t.Errorf("unexpected creation in %s", c.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				if c.CreateRequest != "" {
+					th.TestJSONRequest(t, r, c.CreateRequest)
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"%s": %s}`, c.Key, c.Created[created])
+				created++
+			default:
+				t.Errorf("unexpected method %s on %s", r.Method, c.Path)
+			}
+		})
+		th.Mux.HandleFunc("/v2.0/"+c.Path+"/", func(w http.ResponseWriter, r *http.Request) {
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			id := strings.TrimPrefix(r.URL.Path, "/v2.0/"+c.Path+"/")
+
+			switch r.Method {
+			case "GET":
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"%s": %s}`, c.Key, c.Get)
+			case "DELETE":
+				*deleted = append(*deleted, c.Path+"/"+id)
+				status := c.DeleteStatus
+				if status == 0 {
+					status = http.StatusNoContent
+				}
+				w.WriteHeader(status)
+			default:
+				t.Errorf("unexpected method %s on %s", r.Method, c.Path)
+			}
+		})
+	}
+	return deleted
+}
+
+// NewTunnelCollections returns the collections of a tunnel to create, where
+// the site connection goes in a status.
+func NewTunnelCollections(status string) []Collection {
+	return []Collection{
+		{Path: "vpn/ikepolicies", Key: "ikepolicy", Created: []string{IKEPolicy}, CreateRequest: `
+{
+	"ikepolicy": {
+		"name": "office-ike",
+		"encryption_algorithm": "aes-256",
+		"lifetime": {"units": "seconds", "value": 28800}
+	}
+}`},
+		{Path: "vpn/ipsecpolicies", Key: "ipsecpolicy", Created: []string{IPSecPolicy}},
+		{Path: "vpn/vpnservices", Key: "vpnservice", Listed: []string{LegacyService}, Created: []string{Service}, CreateRequest: `
+{
+	"vpnservice": {
+		"name": "office",
+		"router_id": "66e3b16c-8ce5-40fb-bb49-ab6d8dc3f2aa",
+		"admin_state_up": true
+	}
+}`},
+		{Path: "vpn/endpoint-groups", Key: "endpoint_group", Created: []string{LocalEndpointGroup, PeerEndpointGroup}},
+		{Path: "vpn/ipsec-site-connections", Key: "ipsec_site_connection", Created: []string{Connection("PENDING_CREATE")}, Get: Connection(status)},
+	}
+}
+
+func plural(key string) string {
+	if strings.HasSuffix(key, "y") {
+		return strings.TrimSuffix(key, "y") + "ies"
+	}
+	return key + "s"
+}
@@ -0,0 +1,235 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	fake "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/common"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/vpnaas/tunnels"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func spec() tunnels.Spec {
+	return tunnels.Spec{
+		Name:     "office",
+		RouterID: RouterID,
+		IKEPolicy: ikepolicies.CreateOpts{
+			EncryptionAlgorithm: ikepolicies.EncryptionAlgorithmAES256,
+			Lifetime: &ikepolicies.LifetimeCreateOpts{
+				Units: ikepolicies.UnitSeconds,
+				Value: 28800,
+			},
+		},
+		IPSecPolicy: ipsecpolicies.CreateOpts{
+			EncryptionAlgorithm: ipsecpolicies.EncryptionAlgorithmAES256,
+		},
+		LocalSubnetIDs: []string{SubnetID},
+		PeerCIDRs:      []string{"192.168.10.0/24"},
+		PeerAddress:    "203.0.113.10",
+		PSK:            "secret",
+	}
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	deleted := HandleCollections(t, NewTunnelCollections("DOWN"))
+
+	tunnel, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, IKEPolicyID, tunnel.IKEPolicy.ID)
+	th.AssertEquals(t, IPSecPolicyID, tunnel.IPSecPolicy.ID)
+	th.AssertEquals(t, ServiceID, tunnel.Service.ID)
+	th.AssertEquals(t, LocalEPGroupID, tunnel.LocalEndpointGroup.ID)
+	th.AssertEquals(t, PeerEPGroupID, tunnel.PeerEndpointGroup.ID)
+	th.AssertEquals(t, "DOWN", tunnel.Connection.Status)
+	th.CheckDeepEquals(t, []tunnels.Resource{
+		{Kind: tunnels.KindIKEPolicy, ID: IKEPolicyID},
+		{Kind: tunnels.KindIPSecPolicy, ID: IPSecPolicyID},
+		{Kind: tunnels.KindVPNService, ID: ServiceID},
+		{Kind: tunnels.KindEndpointGroup, ID: LocalEPGroupID},
+		{Kind: tunnels.KindEndpointGroup, ID: PeerEPGroupID},
+		{Kind: tunnels.KindSiteConnection, ID: ConnectionID},
+	}, tunnel.Created)
+	th.AssertEquals(t, 0, len(*deleted))
+}
+
+func TestCreateReuse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	deleted := HandleCollections(t, []Collection{
+		{Path: "vpn/ikepolicies", Key: "ikepolicy", Listed: []string{IKEPolicy}},
+		{Path: "vpn/ipsecpolicies", Key: "ipsecpolicy", Listed: []string{IPSecPolicy}},
+		{Path: "vpn/vpnservices", Key: "vpnservice", Listed: []string{LegacyService, Service}},
+		{Path: "vpn/endpoint-groups", Key: "endpoint_group", Listed: []string{LocalEndpointGroup}, Created: []string{PeerEndpointGroup}},
+		{Path: "vpn/ipsec-site-connections", Key: "ipsec_site_connection", Created: []string{Connection("PENDING_CREATE")}, Get: Connection("ACTIVE")},
+	})
+
+	tunnel, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, ServiceID, tunnel.Service.ID)
+	th.AssertEquals(t, LocalEPGroupID, tunnel.LocalEndpointGroup.ID)
+	th.AssertEquals(t, "ACTIVE", tunnel.Connection.Status)
+	th.CheckDeepEquals(t, []tunnels.Resource{
+		{Kind: tunnels.KindEndpointGroup, ID: PeerEPGroupID},
+		{Kind: tunnels.KindSiteConnection, ID: ConnectionID},
+	}, tunnel.Created)
+
+	err = tunnels.Delete(context.TODO(), fake.ServiceClient(), tunnel, tunnels.DeleteOpts{CreatedOnly: true})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{
+		"vpn/ipsec-site-connections/" + ConnectionID,
+		"vpn/endpoint-groups/" + PeerEPGroupID,
+	}, *deleted)
+}
+
+func TestCreateMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	collections := NewTunnelCollections("ACTIVE")
+	collections[0].Listed = []string{ExistingIKEPolicy}
+	collections[0].Created = nil
+	HandleCollections(t, collections)
+
+	_, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	var mismatch tunnels.ErrMismatch
+	th.AssertEquals(t, true, errors.As(err, &mismatch))
+	th.AssertEquals(t, ExistingIKEPolicyID, mismatch.Resource.ID)
+	th.AssertEquals(t, "encryption_algorithm", mismatch.Field)
+	th.AssertEquals(t, "aes-256", mismatch.Want)
+	th.AssertEquals(t, "aes-128", mismatch.Got)
+}
+
+func TestCreateConnectionMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	deleted := HandleCollections(t, []Collection{
+		{Path: "vpn/ikepolicies", Key: "ikepolicy", Listed: []string{IKEPolicy}},
+		{Path: "vpn/ipsecpolicies", Key: "ipsecpolicy", Listed: []string{IPSecPolicy}},
+		{Path: "vpn/vpnservices", Key: "vpnservice", Listed: []string{Service}},
+		{Path: "vpn/endpoint-groups", Key: "endpoint_group", Listed: []string{LocalEndpointGroup, PeerEndpointGroup}},
+		{Path: "vpn/ipsec-site-connections", Key: "ipsec_site_connection", Listed: []string{Connection("DOWN")}},
+	})
+
+	s := spec()
+	s.PSK = "other-secret"
+
+	_, err := tunnels.Create(context.TODO(), fake.ServiceClient(), s)
+	var mismatch tunnels.ErrMismatch
+	th.AssertEquals(t, true, errors.As(err, &mismatch))
+	th.AssertEquals(t, ConnectionID, mismatch.Resource.ID)
+	th.AssertEquals(t, "psk", mismatch.Field)
+	th.AssertEquals(t, "", mismatch.Want)
+	th.AssertEquals(t, "", mismatch.Got)
+	th.AssertEquals(t, false, strings.Contains(err.Error(), "secret"))
+	th.AssertEquals(t, 0, len(*deleted))
+
+	s = spec()
+	s.MTU = 1400
+	_, err = tunnels.Create(context.TODO(), fake.ServiceClient(), s)
+	th.AssertEquals(t, true, errors.As(err, &mismatch))
+	th.AssertEquals(t, "mtu", mismatch.Field)
+	th.AssertEquals(t, "1400", mismatch.Want)
+	th.AssertEquals(t, "1500", mismatch.Got)
+}
+
+func TestCreateEqualLifetimes(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCollections(t, NewTunnelCollections("ACTIVE"))
+
+	s := spec()
+	s.IPSecPolicy.Lifetime = &ipsecpolicies.LifetimeCreateOpts{
+		Units: ipsecpolicies.UnitSeconds,
+		Value: 28800,
+	}
+
+	_, err := tunnels.Create(context.TODO(), fake.ServiceClient(), s)
+	th.AssertNoErr(t, err)
+}
+
+func TestCreateRollback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	deleted := HandleCollections(t, NewTunnelCollections("ERROR"))
+
+	_, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	var failed tunnels.ErrConnectionFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.AssertEquals(t, ConnectionID, failed.Connection.ID)
+
+	th.CheckDeepEquals(t, []string{
+		"vpn/ipsec-site-connections/" + ConnectionID,
+		"vpn/endpoint-groups/" + PeerEPGroupID,
+		"vpn/endpoint-groups/" + LocalEPGroupID,
+		"vpn/vpnservices/" + ServiceID,
+		"vpn/ipsecpolicies/" + IPSecPolicyID,
+		"vpn/ikepolicies/" + IKEPolicyID,
+	}, *deleted)
+}
+
+func TestCreateRollbackInUse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	collections := NewTunnelCollections("ERROR")
+	collections[3].DeleteStatus = http.StatusConflict
+	HandleCollections(t, collections)
+
+	_, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	var rollbackFailed tunnels.ErrRollbackFailed
+	th.AssertEquals(t, true, errors.As(err, &rollbackFailed))
+	th.AssertEquals(t, true, errors.As(err, &tunnels.ErrConnectionFailed{}))
+	th.CheckDeepEquals(t, []tunnels.Resource{
+		{Kind: tunnels.KindIKEPolicy, ID: IKEPolicyID},
+		{Kind: tunnels.KindIPSecPolicy, ID: IPSecPolicyID},
+		{Kind: tunnels.KindVPNService, ID: ServiceID},
+		{Kind: tunnels.KindEndpointGroup, ID: LocalEPGroupID},
+		{Kind: tunnels.KindEndpointGroup, ID: PeerEPGroupID},
+	}, rollbackFailed.Leaked)
+}
+
+func TestDeleteInUse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	collections := NewTunnelCollections("ACTIVE")
+	collections[1].DeleteStatus = http.StatusConflict
+	collections[2].DeleteStatus = http.StatusNotFound
+	deleted := HandleCollections(t, collections)
+
+	tunnel, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	th.AssertNoErr(t, err)
+
+	err = tunnels.Delete(context.TODO(), fake.ServiceClient(), tunnel, tunnels.DeleteOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 6, len(*deleted))
+	th.AssertEquals(t, "vpn/ikepolicies/"+IKEPolicyID, (*deleted)[5])
+}
+
+func TestDeleteFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	collections := NewTunnelCollections("ACTIVE")
+	collections[3].DeleteStatus = http.StatusInternalServerError
+	HandleCollections(t, collections)
+
+	tunnel, err := tunnels.Create(context.TODO(), fake.ServiceClient(), spec())
+	th.AssertNoErr(t, err)
+
+	err = tunnels.Delete(context.TODO(), fake.ServiceClient(), tunnel, tunnels.DeleteOpts{})
+	var failed tunnels.ErrDeleteFailed
+	th.AssertEquals(t, true, errors.As(err, &failed))
+	th.CheckDeepEquals(t, []tunnels.Resource{
+		{Kind: tunnels.KindIKEPolicy, ID: IKEPolicyID},
+		{Kind: tunnels.KindIPSecPolicy, ID: IPSecPolicyID},
+		{Kind: tunnels.KindVPNService, ID: ServiceID},
+		{Kind: tunnels.KindEndpointGroup, ID: LocalEPGroupID},
+		{Kind: tunnels.KindEndpointGroup, ID: PeerEPGroupID},
+	}, failed.Leaked)
+}
@@ -0,0 +1,16 @@
+// Package deviceowners classifies ports of the networking service by their
+// device owner.
+package deviceowners
+
+// routerInterfaces are the device owners of router interfaces.
+var routerInterfaces = map[string]bool{
+	"network:router_interface":               true,
+	"network:router_interface_distributed":   true,
+	"network:ha_router_replicated_interface": true,
+}
+
+// IsRouterInterface tells whether a port with the given device owner is an
+// interface of a router on a tenant network.
+func IsRouterInterface(owner string) bool {
+	return routerInterfaces[owner]
+}
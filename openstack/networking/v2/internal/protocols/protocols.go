@@ -0,0 +1,67 @@
+// Package protocols normalizes the IP protocols of the rules of the
+// networking service, which accept both names and numbers.
+package protocols
+
+import "strings"
+
+// names maps the protocol numbers accepted by the networking service to their
+// names.
+var names = map[string]string{
+	"1":   "icmp",
+	"2":   "igmp",
+	"4":   "ipip",
+	"6":   "tcp",
+	"8":   "egp",
+	"17":  "udp",
+	"33":  "dccp",
+	"41":  "ipv6-encap",
+	"43":  "ipv6-route",
+	"44":  "ipv6-frag",
+	"46":  "rsvp",
+	"47":  "gre",
+	"50":  "esp",
+	"51":  "ah",
+	"58":  "ipv6-icmp",
+	"59":  "ipv6-nonxt",
+	"60":  "ipv6-opts",
+	"89":  "ospf",
+	"103": "pim",
+	"112": "vrrp",
+	"113": "pgm",
+	"132": "sctp",
+	"136": "udplite",
+}
+
+// withPorts are the protocols whose rules match ports.
+var withPorts = map[string]bool{
+	"tcp":     true,
+	"udp":     true,
+	"udplite": true,
+	"sctp":    true,
+	"dccp":    true,
+}
+
+// Normalize returns the name of a protocol given by name or number, "" for
+// any protocol, and "ipv6-icmp" for ICMP over IPv6, when version is 6.
+func Normalize(protocol string, version int) string {
+	protocol = strings.ToLower(protocol)
+	if name, ok := names[protocol]; ok {
+		protocol = name
+	}
+	switch protocol {
+	case "any":
+		return ""
+	case "icmpv6":
+		return "ipv6-icmp"
+	case "icmp":
+		if version == 6 {
+			return "ipv6-icmp"
+		}
+	}
+	return protocol
+}
+
+// HasPorts tells whether the rules of a normalized protocol match ports.
+func HasPorts(protocol string) bool {
+	return withPorts[protocol]
+}
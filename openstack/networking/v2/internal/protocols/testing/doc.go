@@ -0,0 +1,2 @@
+// protocols unit tests
+package testing
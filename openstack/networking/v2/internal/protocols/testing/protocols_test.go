@@ -0,0 +1,36 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/internal/protocols"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestNormalize(t *testing.T) {
+	for _, c := range []struct {
+		protocol string
+		version  int
+		want     string
+	}{
+		{"TCP", 4, "tcp"},
+		{"6", 4, "tcp"},
+		{"2", 4, "igmp"},
+		{"89", 4, "ospf"},
+		{"any", 4, ""},
+		{"", 4, ""},
+		{"icmp", 4, "icmp"},
+		{"icmp", 6, "ipv6-icmp"},
+		{"1", 6, "ipv6-icmp"},
+		{"icmpv6", 6, "ipv6-icmp"},
+		{"58", 6, "ipv6-icmp"},
+	} {
+		th.CheckEquals(t, c.want, protocols.Normalize(c.protocol, c.version))
+	}
+}
+
+func TestHasPorts(t *testing.T) {
+	th.CheckEquals(t, true, protocols.HasPorts("udplite"))
+	th.CheckEquals(t, false, protocols.HasPorts("icmp"))
+	th.CheckEquals(t, false, protocols.HasPorts(""))
+}
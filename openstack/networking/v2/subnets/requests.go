@@ -34,6 +34,7 @@ type ListOpts struct {
 	IPv6RAMode        string `q:"ipv6_ra_mode"`
 	ID                string `q:"id"`
 	SubnetPoolID      string `q:"subnetpool_id"`
+	SegmentID         string `q:"segment_id"`
 	Limit             int    `q:"limit"`
 	Marker            string `q:"marker"`
 	SortKey           string `q:"sort_key"`
@@ -148,6 +149,10 @@ type CreateOpts struct {
 	// overwrite the "default_prefixlen" value of the referenced subnetpool.
 	Prefixlen int `json:"prefixlen,omitempty"`
 
+	// SegmentID is the id of the network segment the subnet is associated
+	// to, on routed provider networks. See extensions/segments.
+	SegmentID string `json:"segment_id,omitempty"`
+
 	VPCID string `json:"vpc_id,omitempty"`
 }
 
@@ -217,6 +222,10 @@ type UpdateOpts struct {
 	// EnableDHCP will either enable to disable the DHCP service.
 	EnableDHCP *bool `json:"enable_dhcp,omitempty"`
 
+	// SegmentID associates a subnet without segment with a network segment,
+	// when converting a network to a routed provider network.
+	SegmentID *string `json:"segment_id,omitempty"`
+
 	// RevisionNumber implements extension:standard-attr-revisions. If != "" it
 	// will set revision_number=%s. If the revision number does not match, the
 	// update will fail.
@@ -376,6 +376,41 @@ const SubnetCreateRequestWithPrefixlen = `
 }
 `
 
+const SubnetCreateWithSegmentRequest = `
+{
+	"subnet": {
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"ip_version": 4,
+		"cidr": "10.1.0.0/24",
+		"segment_id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2"
+	}
+}
+`
+
+const SubnetCreateWithSegmentResult = `
+{
+	"subnet": {
+		"name": "",
+		"enable_dhcp": true,
+		"network_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"tenant_id": "4fd44f30292945e481c7b8a0c8908869",
+		"dns_nameservers": [],
+		"allocation_pools": [
+			{
+				"start": "10.1.0.2",
+				"end": "10.1.0.254"
+			}
+		],
+		"host_routes": [],
+		"ip_version": 4,
+		"gateway_ip": "10.1.0.1",
+		"cidr": "10.1.0.0/24",
+		"id": "7e3a1c5d-2b0f-4c8e-9d6a-1f2e3d4c5b6a",
+		"segment_id": "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2"
+	}
+}
+`
+
 const SubnetUpdateRequest = `
 {
 	"subnet": {
@@ -392,6 +392,36 @@ func TestCreateWithPrefixlen(t *testing.T) {
 	th.AssertEquals(t, s.SubnetPoolID, "b80340c7-9960-4f67-a99c-02501656284b")
 }
 
+func TestCreateWithSegment(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/subnets", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, SubnetCreateWithSegmentRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprint(w, SubnetCreateWithSegmentResult)
+	})
+
+	opts := subnets.CreateOpts{
+		NetworkID: "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		IPVersion: 4,
+		CIDR:      "10.1.0.0/24",
+		SegmentID: "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2",
+	}
+	s, err := subnets.Create(context.TODO(), fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, s.ID, "7e3a1c5d-2b0f-4c8e-9d6a-1f2e3d4c5b6a")
+	th.AssertEquals(t, s.SegmentID, "a0a2c4b8-5e27-4a55-a8f6-67c3a9d1c1b2")
+}
+
 func TestRequiredCreateOpts(t *testing.T) {
 	res := subnets.Create(context.TODO(), fake.ServiceClient(), subnets.CreateOpts{})
 	if res.Err == nil {
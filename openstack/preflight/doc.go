@@ -0,0 +1,37 @@
+/*
+Package preflight checks that a set of resources fits in the quotas of a
+project before any of them is created.
+
+The quotas are read from the compute limits or quota sets, the block storage
+limits or quota sets, the networking quotas with their usage, and the load
+balancer quotas. Each quota the plan depends on is reported with its limit,
+its usage and what the plan requests, so that a deployment can be refused
+upfront with the quota to raise and by how much.
+
+Example to Check a Plan
+
+	report, err := preflight.Check(context.TODO(), preflight.Clients{
+		Compute:      computeClient,
+		BlockStorage: blockStorageClient,
+		Network:      networkClient,
+		LoadBalancer: loadBalancerClient,
+	}, projectID, preflight.Plan{
+		Servers: []preflight.ServerPlan{
+			{FlavorID: "3", Count: 3},
+		},
+		Volumes: []preflight.VolumePlan{
+			{Size: 40, Count: 3},
+		},
+		Ports:         3,
+		FloatingIPs:   1,
+		LoadBalancers: 1,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, failure := range report.Failures() {
+		fmt.Printf("%s: short by %d\n", failure, failure.Shortfall())
+	}
+*/
+package preflight
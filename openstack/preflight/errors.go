@@ -0,0 +1,25 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+)
+
+// ErrQuotaExceeded is returned by Report.Err when a plan does not fit in the
+// quotas of the project.
+type ErrQuotaExceeded struct {
+	gophercloud.BaseError
+
+	// Failures are the quotas the plan does not fit in.
+	Failures []QuotaCheck
+}
+
+func (e ErrQuotaExceeded) Error() string {
+	var failures []string
+	for _, c := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s %s short by %d", c.Service, c.Resource, c.Shortfall()))
+	}
+	return "quota exceeded: " + strings.Join(failures, ", ")
+}
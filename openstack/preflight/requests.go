@@ -0,0 +1,274 @@
+package preflight
+
+import (
+	"context"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	blocklimits "github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/limits"
+	blockquotasets "github.com/vnpaycloud-console/gophercloud/v2/openstack/blockstorage/v3/quotasets"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/flavors"
+	computelimits "github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/limits"
+	computequotasets "github.com/vnpaycloud-console/gophercloud/v2/openstack/compute/v2/quotasets"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/loadbalancer/v2/loadbalancers"
+	lbquotas "github.com/vnpaycloud-console/gophercloud/v2/openstack/loadbalancer/v2/quotas"
+	networkquotas "github.com/vnpaycloud-console/gophercloud/v2/openstack/networking/v2/extensions/quotas"
+)
+
+// Clients are the service clients used to read the quotas. Only the clients
+// of the services a plan uses are required.
+type Clients struct {
+	Compute      *gophercloud.ServiceClient
+	BlockStorage *gophercloud.ServiceClient
+	Network      *gophercloud.ServiceClient
+	LoadBalancer *gophercloud.ServiceClient
+}
+
+// ServerPlan is a number of servers of the same flavor.
+type ServerPlan struct {
+	// FlavorID is the ID of the flavor of the servers.
+	FlavorID string
+
+	// Count is the number of servers. Defaults to 1.
+	Count int
+}
+
+// VolumePlan is a number of volumes of the same size.
+type VolumePlan struct {
+	// Size is the size of each volume, in GB.
+	Size int
+
+	// Count is the number of volumes. Defaults to 1.
+	Count int
+}
+
+// Plan is a set of resources about to be created in a project.
+type Plan struct {
+	Servers []ServerPlan
+
+	// Volumes include the boot volumes of the servers.
+	Volumes []VolumePlan
+
+	Ports              int
+	FloatingIPs        int
+	Networks           int
+	Subnets            int
+	Routers            int
+	SecurityGroups     int
+	SecurityGroupRules int
+	LoadBalancers      int
+}
+
+func count(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+func (p Plan) usesNetwork() bool {
+	return p.Ports > 0 || p.FloatingIPs > 0 || p.Networks > 0 || p.Subnets > 0 ||
+		p.Routers > 0 || p.SecurityGroups > 0 || p.SecurityGroupRules > 0
+}
+
+// Check compares a plan with the quotas of a project.
+//
+// The quotas of the compute and block storage services are read from their
+// quota sets when projectID is set, and from the limits of the current
+// project otherwise. The networking and load balancer services require
+// projectID.
+//
+// Check returns an error when the quotas can't be read. Use Report.Fits, or
+// Report.Err, to know whether the plan fits.
+func Check(ctx context.Context, clients Clients, projectID string, plan Plan) (*Report, error) {
+	report := new(Report)
+
+	if len(plan.Servers) > 0 {
+		checks, err := checkCompute(ctx, clients.Compute, projectID, plan.Servers)
+		if err != nil {
+			return nil, err
+		}
+		report.Checks = append(report.Checks, checks...)
+	}
+
+	if len(plan.Volumes) > 0 {
+		checks, err := checkBlockStorage(ctx, clients.BlockStorage, projectID, plan.Volumes)
+		if err != nil {
+			return nil, err
+		}
+		report.Checks = append(report.Checks, checks...)
+	}
+
+	if plan.usesNetwork() {
+		checks, err := checkNetwork(ctx, clients.Network, projectID, plan)
+		if err != nil {
+			return nil, err
+		}
+		report.Checks = append(report.Checks, checks...)
+	}
+
+	if plan.LoadBalancers > 0 {
+		check, err := checkLoadBalancer(ctx, clients.LoadBalancer, projectID, plan.LoadBalancers)
+		if err != nil {
+			return nil, err
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	return report, nil
+}
+
+func checkCompute(ctx context.Context, client *gophercloud.ServiceClient, projectID string, servers []ServerPlan) ([]QuotaCheck, error) {
+	if client == nil {
+		return nil, gophercloud.ErrMissingInput{Argument: "Clients.Compute"}
+	}
+
+	instances := QuotaCheck{Service: ServiceCompute, Resource: "instances"}
+	cores := QuotaCheck{Service: ServiceCompute, Resource: "cores"}
+	ram := QuotaCheck{Service: ServiceCompute, Resource: "ram"}
+
+	found := make(map[string]*flavors.Flavor)
+	for _, s := range servers {
+		if s.FlavorID == "" {
+			return nil, gophercloud.ErrMissingInput{Argument: "ServerPlan.FlavorID"}
+		}
+		flavor, ok := found[s.FlavorID]
+		if !ok {
+			var err error
+			flavor, err = flavors.Get(ctx, client, s.FlavorID).Extract()
+			if err != nil {
+				return nil, err
+			}
+			found[s.FlavorID] = flavor
+		}
+		instances.Requested += count(s.Count)
+		cores.Requested += count(s.Count) * flavor.VCPUs
+		ram.Requested += count(s.Count) * flavor.RAM
+	}
+
+	if projectID != "" {
+		quotas, err := computequotasets.GetDetail(ctx, client, projectID).Extract()
+		if err != nil {
+			return nil, err
+		}
+		instances.Limit, instances.InUse, instances.Reserved = quotas.Instances.Limit, quotas.Instances.InUse, quotas.Instances.Reserved
+		cores.Limit, cores.InUse, cores.Reserved = quotas.Cores.Limit, quotas.Cores.InUse, quotas.Cores.Reserved
+		ram.Limit, ram.InUse, ram.Reserved = quotas.RAM.Limit, quotas.RAM.InUse, quotas.RAM.Reserved
+	} else {
+		limits, err := computelimits.Get(ctx, client, nil).Extract()
+		if err != nil {
+			return nil, err
+		}
+		instances.Limit, instances.InUse = limits.Absolute.MaxTotalInstances, limits.Absolute.TotalInstancesUsed
+		cores.Limit, cores.InUse = limits.Absolute.MaxTotalCores, limits.Absolute.TotalCoresUsed
+		ram.Limit, ram.InUse = limits.Absolute.MaxTotalRAMSize, limits.Absolute.TotalRAMUsed
+	}
+
+	return []QuotaCheck{instances, cores, ram}, nil
+}
+
+func checkBlockStorage(ctx context.Context, client *gophercloud.ServiceClient, projectID string, volumes []VolumePlan) ([]QuotaCheck, error) {
+	if client == nil {
+		return nil, gophercloud.ErrMissingInput{Argument: "Clients.BlockStorage"}
+	}
+
+	volumesCheck := QuotaCheck{Service: ServiceBlockStorage, Resource: "volumes"}
+	gigabytes := QuotaCheck{Service: ServiceBlockStorage, Resource: "gigabytes"}
+	// The size of each volume is checked against per_volume_gigabytes, which
+	// is not a usage: only the largest volume matters.
+	perVolume := QuotaCheck{Service: ServiceBlockStorage, Resource: "per_volume_gigabytes"}
+	for _, v := range volumes {
+		volumesCheck.Requested += count(v.Count)
+		gigabytes.Requested += count(v.Count) * v.Size
+		perVolume.Requested = max(perVolume.Requested, v.Size)
+	}
+
+	if projectID == "" {
+		limits, err := blocklimits.Get(ctx, client).Extract()
+		if err != nil {
+			return nil, err
+		}
+		volumesCheck.Limit, volumesCheck.InUse = limits.Absolute.MaxTotalVolumes, limits.Absolute.TotalVolumesUsed
+		gigabytes.Limit, gigabytes.InUse = limits.Absolute.MaxTotalVolumeGigabytes, limits.Absolute.TotalGigabytesUsed
+		return []QuotaCheck{volumesCheck, gigabytes}, nil
+	}
+
+	usage, err := blockquotasets.GetUsage(ctx, client, projectID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	volumesCheck.Limit, volumesCheck.InUse, volumesCheck.Reserved = usage.Volumes.Limit, usage.Volumes.InUse, usage.Volumes.Reserved
+	gigabytes.Limit, gigabytes.InUse, gigabytes.Reserved = usage.Gigabytes.Limit, usage.Gigabytes.InUse, usage.Gigabytes.Reserved
+	perVolume.Limit = usage.PerVolumeGigabytes.Limit
+	return []QuotaCheck{volumesCheck, gigabytes, perVolume}, nil
+}
+
+func checkNetwork(ctx context.Context, client *gophercloud.ServiceClient, projectID string, plan Plan) ([]QuotaCheck, error) {
+	if client == nil {
+		return nil, gophercloud.ErrMissingInput{Argument: "Clients.Network"}
+	}
+	if projectID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "projectID"}
+	}
+
+	quotas, err := networkquotas.GetDetail(ctx, client, projectID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []QuotaCheck
+	for _, q := range []struct {
+		resource  string
+		requested int
+		detail    networkquotas.QuotaDetail
+	}{
+		{"port", plan.Ports, quotas.Port},
+		{"floatingip", plan.FloatingIPs, quotas.FloatingIP},
+		{"network", plan.Networks, quotas.Network},
+		{"subnet", plan.Subnets, quotas.Subnet},
+		{"router", plan.Routers, quotas.Router},
+		{"security_group", plan.SecurityGroups, quotas.SecurityGroup},
+		{"security_group_rule", plan.SecurityGroupRules, quotas.SecurityGroupRule},
+	} {
+		if q.requested == 0 {
+			continue
+		}
+		checks = append(checks, QuotaCheck{
+			Service:   ServiceNetwork,
+			Resource:  q.resource,
+			Limit:     q.detail.Limit,
+			InUse:     q.detail.Used,
+			Reserved:  q.detail.Reserved,
+			Requested: q.requested,
+		})
+	}
+	return checks, nil
+}
+
+// checkLoadBalancer checks the load balancer quota. Octavia does not report
+// the usage, which is counted from the load balancers of the project.
+func checkLoadBalancer(ctx context.Context, client *gophercloud.ServiceClient, projectID string, requested int) (QuotaCheck, error) {
+	check := QuotaCheck{Service: ServiceLoadBalancer, Resource: "loadbalancer", Requested: requested}
+	if client == nil {
+		return check, gophercloud.ErrMissingInput{Argument: "Clients.LoadBalancer"}
+	}
+	if projectID == "" {
+		return check, gophercloud.ErrMissingInput{Argument: "projectID"}
+	}
+
+	quota, err := lbquotas.Get(ctx, client, projectID).Extract()
+	if err != nil {
+		return check, err
+	}
+	check.Limit = quota.Loadbalancer
+
+	allPages, err := loadbalancers.List(client, loadbalancers.ListOpts{ProjectID: projectID}).AllPages(ctx)
+	if err != nil {
+		return check, err
+	}
+	lbs, err := loadbalancers.ExtractLoadBalancers(allPages)
+	if err != nil {
+		return check, err
+	}
+	check.InUse = len(lbs)
+	return check, nil
+}
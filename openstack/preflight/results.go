@@ -0,0 +1,100 @@
+package preflight
+
+import (
+	"fmt"
+)
+
+// Unlimited is the limit of the quotas without a limit.
+const Unlimited = -1
+
+// Service is the service enforcing a quota.
+type Service string
+
+const (
+	ServiceCompute      Service = "compute"
+	ServiceBlockStorage Service = "block-storage"
+	ServiceNetwork      Service = "network"
+	ServiceLoadBalancer Service = "load-balancer"
+)
+
+// QuotaCheck compares what a plan requests with what a quota allows.
+type QuotaCheck struct {
+	// Service is the service enforcing the quota.
+	Service Service
+
+	// Resource is the name of the quota in the service, for example "cores"
+	// or "gigabytes".
+	Resource string
+
+	// Limit is the quota, or Unlimited.
+	Limit int
+
+	// InUse is the current usage of the project.
+	InUse int
+
+	// Reserved is the usage of the resources being created. It is only known
+	// when the quota details of the project are available.
+	Reserved int
+
+	// Requested is the usage the plan adds.
+	Requested int
+}
+
+// Available returns what remains of the quota, or Unlimited.
+func (c QuotaCheck) Available() int {
+	if c.Limit < 0 {
+		return Unlimited
+	}
+	return max(c.Limit-c.InUse-c.Reserved, 0)
+}
+
+// Fits tells whether the requested usage fits in the quota.
+func (c QuotaCheck) Fits() bool {
+	return c.Limit < 0 || c.Requested <= c.Available()
+}
+
+// Shortfall returns by how much the requested usage exceeds the quota.
+func (c QuotaCheck) Shortfall() int {
+	if c.Fits() {
+		return 0
+	}
+	return c.Requested - c.Available()
+}
+
+func (c QuotaCheck) String() string {
+	if c.Limit < 0 {
+		return fmt.Sprintf("%s %s: %d requested, unlimited", c.Service, c.Resource, c.Requested)
+	}
+	return fmt.Sprintf("%s %s: %d requested, %d available (limit %d, %d in use, %d reserved)",
+		c.Service, c.Resource, c.Requested, c.Available(), c.Limit, c.InUse, c.Reserved)
+}
+
+// Report is the result of Check.
+type Report struct {
+	// Checks are the quotas the plan depends on.
+	Checks []QuotaCheck
+}
+
+// Fits tells whether the plan fits in all the quotas.
+func (r Report) Fits() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the quotas the plan does not fit in.
+func (r Report) Failures() []QuotaCheck {
+	var failures []QuotaCheck
+	for _, c := range r.Checks {
+		if !c.Fits() {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// Err returns an ErrQuotaExceeded if the plan does not fit, or nil.
+func (r Report) Err() error {
+	if failures := r.Failures(); len(failures) > 0 {
+		return ErrQuotaExceeded{Failures: failures}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/preflight"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+	"github.com/vnpaycloud-console/gophercloud/v2/testhelper/client"
+)
+
+const ProjectID = "15153a0979884b59b0592248ef947921"
+
+const FlavorOutput = `
+{
+	"flavor": {
+		"id": "%s",
+		"name": "m1.%s",
+		"vcpus": %d,
+		"ram": %d,
+		"disk": 40
+	}
+}
+`
+
+const ComputeQuotaDetailOutput = `
+{
+	"quota_set": {
+		"id": "15153a0979884b59b0592248ef947921",
+		"instances": {"in_use": 5, "limit": 10, "reserved": 1},
+		"cores": {"in_use": 12, "limit": 20, "reserved": 2},
+		"ram": {"in_use": 24576, "limit": -1, "reserved": 0}
+	}
+}
+`
+
+const ComputeLimitsOutput = `
+{
+	"limits": {
+		"rate": [],
+		"absolute": {
+			"maxTotalInstances": 10,
+			"totalInstancesUsed": 5,
+			"maxTotalCores": 20,
+			"totalCoresUsed": 12,
+			"maxTotalRAMSize": 51200,
+			"totalRAMUsed": 24576
+		}
+	}
+}
+`
+
+const BlockStorageQuotaUsageOutput = `
+{
+	"quota_set": {
+		"id": "15153a0979884b59b0592248ef947921",
+		"volumes": {"in_use": 8, "limit": 10, "reserved": 0, "allocated": 0},
+		"gigabytes": {"in_use": 900, "limit": 1000, "reserved": 0, "allocated": 0},
+		"per_volume_gigabytes": {"in_use": 0, "limit": 500, "reserved": 0, "allocated": 0}
+	}
+}
+`
+
+const NetworkQuotaDetailOutput = `
+{
+	"quota": {
+		"port": {"used": 40, "limit": 50, "reserved": 0},
+		"floatingip": {"used": 9, "limit": 10, "reserved": 0},
+		"network": {"used": 1, "limit": 10, "reserved": 0},
+		"subnet": {"used": 1, "limit": 10, "reserved": 0},
+		"router": {"used": 1, "limit": 10, "reserved": 0},
+		"security_group": {"used": 1, "limit": 10, "reserved": 0},
+		"security_group_rule": {"used": 4, "limit": 100, "reserved": 0},
+		"rbac_policy": {"used": 0, "limit": 10, "reserved": 0},
+		"subnetpool": {"used": 0, "limit": -1, "reserved": 0},
+		"trunk": {"used": 0, "limit": -1, "reserved": 0}
+	}
+}
+`
+
+const LoadBalancerQuotaOutput = `
+{
+	"quota": {
+		"loadbalancer": 2,
+		"listener": -1,
+		"member": -1,
+		"pool": -1,
+		"healthmonitor": -1,
+		"l7policy": -1,
+		"l7rule": -1
+	}
+}
+`
+
+const LoadBalancersOutput = `
+{
+	"loadbalancers": [
+		{"id": "36e08a3e-a78f-4b40-a229-1e7e23eee1ab", "name": "web", "project_id": "15153a0979884b59b0592248ef947921"}
+	]
+}
+`
+
+// Clients returns service clients of the fake cloud.
+func Clients() preflight.Clients {
+	clients := preflight.Clients{
+		Compute:      client.ServiceClient(),
+		BlockStorage: client.ServiceClient(),
+		Network:      client.ServiceClient(),
+		LoadBalancer: client.ServiceClient(),
+	}
+	clients.Compute.Endpoint = th.Endpoint() + "compute/"
+	clients.BlockStorage.Endpoint = th.Endpoint() + "volume/"
+	clients.Network.Endpoint = th.Endpoint() + "network/"
+	clients.LoadBalancer.Endpoint = th.Endpoint() + "load-balancer/"
+	return clients
+}
+
+func handle(t *testing.T, path, output string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, output)
+	})
+}
+
+// HandleQuotasSuccessfully sets up the fake cloud to return the flavors
+// m1.small (1 vCPU, 2 GB) and m1.large (4 vCPUs, 8 GB), and the quotas of
+// the project.
+func HandleQuotasSuccessfully(t *testing.T) {
+	handle(t, "/compute/flavors/1", fmt.Sprintf(FlavorOutput, "1", "small", 1, 2048))
+	handle(t, "/compute/flavors/3", fmt.Sprintf(FlavorOutput, "3", "large", 4, 8192))
+	handle(t, "/compute/os-quota-sets/"+ProjectID+"/detail", ComputeQuotaDetailOutput)
+	handle(t, "/compute/limits", ComputeLimitsOutput)
+	handle(t, "/volume/os-quota-sets/"+ProjectID, BlockStorageQuotaUsageOutput)
+	handle(t, "/network/quotas/"+ProjectID+"/details.json", NetworkQuotaDetailOutput)
+	handle(t, "/load-balancer/quotas/"+ProjectID, LoadBalancerQuotaOutput)
+	handle(t, "/load-balancer/lbaas/loadbalancers", LoadBalancersOutput)
+}
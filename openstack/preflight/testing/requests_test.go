@@ -0,0 +1,73 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/preflight"
+	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
+)
+
+func TestCheck(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleQuotasSuccessfully(t)
+
+	report, err := preflight.Check(context.TODO(), Clients(), ProjectID, preflight.Plan{
+		Servers: []preflight.ServerPlan{
+			{FlavorID: "3", Count: 2},
+			{FlavorID: "1"},
+		},
+		Volumes: []preflight.VolumePlan{
+			{Size: 40, Count: 2},
+		},
+		Ports:         3,
+		FloatingIPs:   2,
+		LoadBalancers: 1,
+	})
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []preflight.QuotaCheck{
+		{Service: preflight.ServiceCompute, Resource: "instances", Limit: 10, InUse: 5, Reserved: 1, Requested: 3},
+		{Service: preflight.ServiceCompute, Resource: "cores", Limit: 20, InUse: 12, Reserved: 2, Requested: 9},
+		{Service: preflight.ServiceCompute, Resource: "ram", Limit: -1, InUse: 24576, Requested: 18432},
+		{Service: preflight.ServiceBlockStorage, Resource: "volumes", Limit: 10, InUse: 8, Requested: 2},
+		{Service: preflight.ServiceBlockStorage, Resource: "gigabytes", Limit: 1000, InUse: 900, Requested: 80},
+		{Service: preflight.ServiceBlockStorage, Resource: "per_volume_gigabytes", Limit: 500, Requested: 40},
+		{Service: preflight.ServiceNetwork, Resource: "port", Limit: 50, InUse: 40, Requested: 3},
+		{Service: preflight.ServiceNetwork, Resource: "floatingip", Limit: 10, InUse: 9, Requested: 2},
+		{Service: preflight.ServiceLoadBalancer, Resource: "loadbalancer", Limit: 2, InUse: 1, Requested: 1},
+	}, report.Checks)
+
+	th.CheckEquals(t, false, report.Fits())
+	failures := report.Failures()
+	th.AssertEquals(t, 2, len(failures))
+	th.CheckEquals(t, "cores", failures[0].Resource)
+	th.CheckEquals(t, 3, failures[0].Shortfall())
+	th.CheckEquals(t, "floatingip", failures[1].Resource)
+	th.CheckEquals(t, 1, failures[1].Shortfall())
+
+	var quotaErr preflight.ErrQuotaExceeded
+	th.AssertEquals(t, true, errors.As(report.Err(), &quotaErr))
+	th.CheckEquals(t, "quota exceeded: compute cores short by 3, network floatingip short by 1", quotaErr.Error())
+}
+
+func TestCheckCurrentProject(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleQuotasSuccessfully(t)
+
+	report, err := preflight.Check(context.TODO(), Clients(), "", preflight.Plan{
+		Servers: []preflight.ServerPlan{{FlavorID: "1", Count: 5}},
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, true, report.Fits())
+	th.AssertNoErr(t, report.Err())
+	th.CheckEquals(t, 3, len(report.Checks))
+	th.CheckEquals(t, 26624, report.Checks[2].Available())
+
+	_, err = preflight.Check(context.TODO(), Clients(), "", preflight.Plan{Ports: 1})
+	th.CheckEquals(t, true, errors.As(err, new(gophercloud.ErrMissingInput)))
+}
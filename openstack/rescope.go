@@ -0,0 +1,92 @@
+package openstack
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vnpaycloud-console/gophercloud/v2"
+	tokens3 "github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/tokens"
+)
+
+// Rescope exchanges the current token of the ProviderClient, with the token
+// authentication method, for a token scoped to another project, domain or
+// system. It returns a new ProviderClient, with its own token and its own
+// EndpointLocator built from the service catalog of the new token; the
+// original ProviderClient is left untouched.
+//
+// The returned ProviderClient re-authenticates by rescoping the current token
+// of the original ProviderClient again. If the original token has expired
+// too, the original ProviderClient is re-authenticated first, provided it can
+// re-authenticate.
+//
+// Example:
+//
+//	provider, err := openstack.AuthenticatedClient(ctx, ao)
+//	projectProvider, err := openstack.Rescope(ctx, provider, tokens.Scope{ProjectID: "0fe36e73809d46aeae6705c39077b1b3"})
+//	client, err := openstack.NewComputeV2(projectProvider, gophercloud.EndpointOpts{
+//		Region: os.Getenv("OS_REGION_NAME"),
+//	})
+func Rescope(ctx context.Context, provider *gophercloud.ProviderClient, scope tokens3.Scope) (*gophercloud.ProviderClient, error) {
+	if scope == (tokens3.Scope{}) {
+		return nil, gophercloud.ErrMissingInput{Argument: "Scope"}
+	}
+
+	client := &gophercloud.ProviderClient{
+		IdentityBase:      provider.IdentityBase,
+		IdentityEndpoint:  provider.IdentityEndpoint,
+		HTTPClient:        provider.HTTPClient,
+		UserAgent:         provider.UserAgent,
+		RetryBackoffFunc:  provider.RetryBackoffFunc,
+		MaxBackoffRetries: provider.MaxBackoffRetries,
+		RetryFunc:         provider.RetryFunc,
+	}
+	client.UseTokenLock()
+
+	rescope := func(ctx context.Context, client *gophercloud.ProviderClient) error {
+		tokenID := provider.Token()
+		if tokenID == "" {
+			return gophercloud.ErrMissingInput{Argument: "TokenID"}
+		}
+		opts := &tokens3.AuthOptions{
+			TokenID: tokenID,
+			Scope:   scope,
+		}
+		err := AuthenticateV3(ctx, client, opts, gophercloud.EndpointOpts{})
+		if err == nil || provider.ReauthFunc == nil {
+			return err
+		}
+		// Keystone answers 404 when the token being rescoped has expired.
+		if !gophercloud.ResponseCodeIs(err, http.StatusUnauthorized) && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return err
+		}
+
+		// The original token has expired: renew it, and try again.
+		if err := provider.Reauthenticate(ctx, tokenID); err != nil {
+			return err
+		}
+		opts.TokenID = provider.Token()
+		return AuthenticateV3(ctx, client, opts, gophercloud.EndpointOpts{})
+	}
+
+	if err := rescope(ctx, client); err != nil {
+		return nil, err
+	}
+
+	// here we're creating a throw-away client (tac), like v3auth does, so
+	// that a failed re-authentication does not trigger another one.
+	tac := *client
+	tac.SetThrowaway(true)
+	tac.ReauthFunc = nil
+	if err := tac.SetTokenAndAuthResult(nil); err != nil {
+		return nil, err
+	}
+	client.ReauthFunc = func(ctx context.Context) error {
+		if err := rescope(ctx, &tac); err != nil {
+			return err
+		}
+		client.CopyTokenFrom(&tac)
+		return nil
+	}
+
+	return client, nil
+}
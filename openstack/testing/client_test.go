@@ -2,12 +2,15 @@ package testing
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/vnpaycloud-console/gophercloud/v2"
 	"github.com/vnpaycloud-console/gophercloud/v2/openstack"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/federation"
+	"github.com/vnpaycloud-console/gophercloud/v2/openstack/identity/v3/tokens"
 	th "github.com/vnpaycloud-console/gophercloud/v2/testhelper"
 )
 
@@ -61,6 +64,76 @@ func TestAuthenticatedClientV3(t *testing.T) {
 	th.CheckEquals(t, ID, client.TokenID)
 }
 
+func TestAuthenticatedClientFederated(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						},
+						{
+							"status": "stable",
+							"id": "v2.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, th.Endpoint()+"v3/", th.Endpoint()+"v2.0/")
+	})
+
+	th.Mux.HandleFunc("/v3/OS-FEDERATION/identity_providers/vnpay-sso/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", "Bearer oidc-access-token")
+
+		w.Header().Add("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{ "token": { "expires_at": "2013-02-02T18:30:59.000000Z" } }`)
+	})
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{
+			"auth": {
+				"identity": {
+					"methods": ["token"],
+					"token": {"id": "unscoped-token"}
+				},
+				"scope": {"project": {"id": "project-id"}}
+			}
+		}`)
+
+		w.Header().Add("X-Subject-Token", ID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{ "token": { "expires_at": "2013-02-02T18:30:59.000000Z" } }`)
+	})
+
+	options := gophercloud.AuthOptions{
+		IdentityEndpoint: th.Endpoint(),
+		FederatedAuth: &federation.OIDCAuthOptions{
+			IdentityProvider: "vnpay-sso",
+			AccessToken:      "oidc-access-token",
+			Scope:            tokens.Scope{ProjectID: "project-id"},
+			AllowReauth:      true,
+		},
+	}
+	client, err := openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ID, client.TokenID)
+	th.CheckEquals(t, true, client.ReauthFunc != nil)
+}
+
 func TestAuthenticatedClientV2(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -314,3 +387,183 @@ func TestAuthenticatedClientV3Fails(t *testing.T) {
 func TestAuthenticatedClientV2Fails(t *testing.T) {
 	testAuthenticatedClientFails(t, "http://bad-address.example.com/v2.0")
 }
+
+func TestServiceClientWithOverrides(t *testing.T) {
+	provider := &gophercloud.ProviderClient{
+		EndpointLocator: func(eo gophercloud.EndpointOpts) (string, error) {
+			return "https://catalog.example.com/" + eo.Type + "/" + string(eo.Availability) + "/", nil
+		},
+	}
+	eo := gophercloud.EndpointOpts{
+		Overrides: map[string]gophercloud.ServiceOverride{
+			"compute":       {Endpoint: "https://compute.example.com/v2.1", APIVersion: "2.79"},
+			"block_storage": {Type: "block-storage", Availability: gophercloud.AvailabilityInternal, APIVersion: "3"},
+			"image":         {APIVersion: "2.0"},
+			"sharev2":       {APIVersion: "2.65"},
+		},
+	}
+
+	compute, err := openstack.NewComputeV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://compute.example.com/v2.1/", compute.Endpoint)
+	th.CheckEquals(t, "2.79", compute.Microversion)
+
+	volume, err := openstack.NewBlockStorageV3(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://catalog.example.com/block-storage/internal/", volume.Endpoint)
+	th.CheckEquals(t, "", volume.Microversion)
+
+	network, err := openstack.NewNetworkV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://catalog.example.com/network/public/", network.Endpoint)
+
+	image, err := openstack.NewImageV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "", image.Microversion)
+
+	share, err := openstack.NewSharedFileSystemV2(provider, eo)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "2.65", share.Microversion)
+}
+
+func TestAuthenticateWithCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Password struct {
+						User struct {
+							Password string `json:"password"`
+						} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch password := body.Auth.Identity.Password.User.Password; password {
+		case "old", "new":
+			w.Header().Add("X-Subject-Token", password+"-token")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{ "token": { "expires_at": "2013-02-02T18:30:59.000000Z" } }`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	options := gophercloud.AuthOptions{
+		Username:         "me",
+		Password:         "old",
+		DomainName:       "default",
+		IdentityEndpoint: th.Endpoint() + "v3/",
+	}
+	password := "old"
+	provider := func(context.Context) (gophercloud.AuthOptions, error) {
+		o := options
+		o.Password = password
+		return o, nil
+	}
+
+	client, err := openstack.NewClient(options.IdentityEndpoint)
+	th.AssertNoErr(t, err)
+	err = openstack.AuthenticateWithCredentials(context.TODO(), client, options, provider)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "old-token", client.Token())
+
+	// The new secret is not accepted yet: fall back to the old one.
+	password = "not-propagated-yet"
+	th.AssertNoErr(t, client.ReauthFunc(context.TODO()))
+	th.CheckEquals(t, "old-token", client.Token())
+
+	password = "new"
+	th.AssertNoErr(t, client.ReauthFunc(context.TODO()))
+	th.CheckEquals(t, "new-token", client.Token())
+}
+
+func TestRescope(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Methods []string `json:"methods"`
+					Token   struct {
+						ID string `json:"id"`
+					} `json:"token"`
+				} `json:"identity"`
+				Scope struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+
+		project := "other-project"
+		switch body.Auth.Identity.Methods[0] {
+		case "password":
+			project = "original-project"
+			w.Header().Add("X-Subject-Token", "password-token")
+		case "token":
+			th.CheckEquals(t, "other-project", body.Auth.Scope.Project.ID)
+			if body.Auth.Identity.Token.ID == "expired-token" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Add("X-Subject-Token", "rescoped-"+body.Auth.Identity.Token.ID)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{
+			"token": {
+				"expires_at": "2013-02-02T18:30:59.000000Z",
+				"project": {"id": "%[1]s"},
+				"catalog": [
+					{
+						"type": "compute",
+						"name": "nova",
+						"endpoints": [
+							{"interface": "public", "region": "RegionOne", "url": "https://compute.example.com/%[1]s"}
+						]
+					}
+				]
+			}
+		}`, project)
+	})
+
+	provider, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+	err = openstack.AuthenticateV3(context.TODO(), provider, &gophercloud.AuthOptions{
+		Username:    "me",
+		Password:    "secret",
+		DomainName:  "default",
+		AllowReauth: true,
+	}, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+
+	_, err = openstack.Rescope(context.TODO(), provider, tokens.Scope{})
+	th.AssertErr(t, err)
+
+	rescoped, err := openstack.Rescope(context.TODO(), provider, tokens.Scope{ProjectID: "other-project"})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "rescoped-password-token", rescoped.Token())
+	th.CheckEquals(t, "password-token", provider.Token())
+
+	compute, err := openstack.NewComputeV2(rescoped, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://compute.example.com/other-project/", compute.Endpoint)
+	compute, err = openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "https://compute.example.com/original-project/", compute.Endpoint)
+
+	// The original token has expired: it is renewed before rescoping again.
+	provider.SetToken("expired-token")
+	th.AssertNoErr(t, rescoped.ReauthFunc(context.TODO()))
+	th.CheckEquals(t, "password-token", provider.Token())
+	th.CheckEquals(t, "rescoped-password-token", rescoped.Token())
+}
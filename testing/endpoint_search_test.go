@@ -18,3 +18,21 @@ func TestApplyDefaultsToEndpointOpts(t *testing.T) {
 	expected = gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityPublic, Type: "compute"}
 	th.CheckDeepEquals(t, expected, eo)
 }
+
+func TestApplyDefaultsWithOverrides(t *testing.T) {
+	eo := gophercloud.EndpointOpts{
+		Region: "RegionOne",
+		Overrides: map[string]gophercloud.ServiceOverride{
+			"block_storage": {Type: "volumev3", Availability: gophercloud.AvailabilityInternal},
+		},
+	}
+	eo.ApplyDefaults("volumev3")
+	th.CheckEquals(t, "volumev3", eo.Type)
+	th.CheckEquals(t, gophercloud.AvailabilityInternal, eo.Availability)
+
+	_, ok := eo.Override("compute")
+	th.CheckEquals(t, false, ok)
+
+	th.CheckEquals(t, "block-storage", gophercloud.CanonicalServiceType("volume"))
+	th.CheckEquals(t, "load-balancer", gophercloud.CanonicalServiceType("load_balancer"))
+}